@@ -4,11 +4,16 @@ import (
 	"KaungHtetHein116/IVY-backend/api/middleware"
 	v1 "KaungHtetHein116/IVY-backend/api/v1"
 	"KaungHtetHein116/IVY-backend/config"
-	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/db/migration"
+	"KaungHtetHein116/IVY-backend/internal/payment"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/internal/storage"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
 	"KaungHtetHein116/IVY-backend/utils"
+	"context"
 	"os"
+	"strings"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	log "github.com/sirupsen/logrus"
 
@@ -27,25 +32,42 @@ func StartServer() {
 
 	db := config.ConnectDB()
 
-	db.AutoMigrate(
-		&entity.Booking{},
-		&entity.Branch{},
-		&entity.Category{},
-		&entity.Service{},
-		&entity.User{},
-	)
+	if err := migration.Migrate(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	provider, err := payment.NewProviderFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to set up payment provider: %v", err)
+	}
+
+	store, err := storage.NewStorageFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to set up storage: %v", err)
+	}
 
 	e := echo.New()
-	e.Validator = &utils.CustomValidator{Validator: validator.New()}
+	e.Validator = utils.NewValidator()
+
+	accessPolicy := v1.AccessPolicy()
+	userUsecase := usecase.NewUserUsecase(repository.NewUserRepository(db), repository.NewBranchMemberRepository(db))
 
 	middleware.RegisterBasicMiddlewares(e)
-	middleware.RegisterAuthMiddleware(e)
+	middleware.RegisterAuthMiddleware(e, accessPolicy, userUsecase.GetAccess)
+
+	// Files kept on the local filesystem are served by the app itself
+	if local, ok := store.(*storage.LocalStorage); ok && strings.HasPrefix(local.BaseURL, "/") {
+		route := e.Static(local.BaseURL, local.Dir)
+		accessPolicy.Route(route.Method, route.Path, middleware.Public)
+	}
+
+	v1.RegisterRoutes(e, db, store, provider)
+
+	if err := accessPolicy.Verify(e.Routes()); err != nil {
+		log.Fatalf("Failed to set up access control: %v", err)
+	}
 
-	v1.RegisterUserRoutes(e, db)
-	v1.RegisterBranchRoutes(e, db)
-	v1.RegisterCategoryRoutes(e, db)
-	v1.RegisterServiceRoutes(e, db)
-	v1.RegisterBookingRoutes(e, db)
+	startJobs(context.Background(), db, provider, store)
 
 	port := ":" + os.Getenv("APP_PORT")
 
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"KaungHtetHein116/IVY-backend/internal/job"
+	"KaungHtetHein116/IVY-backend/internal/payment"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/internal/storage"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// startJobs schedules the background jobs for the lifetime of ctx.
+func startJobs(ctx context.Context, db *gorm.DB, provider payment.Provider, store storage.Storage) {
+	packageUsecase := usecase.NewPackageUsecase(repository.NewPackageRepository(db))
+	giftCardUsecase := usecase.NewGiftCardUsecase(repository.NewGiftCardRepository(db))
+	loyaltyUsecase := usecase.NewLoyaltyUsecase(repository.NewLoyaltyRepository(db))
+	membershipUsecase := usecase.NewMembershipUsecase(repository.NewMembershipRepository(db), provider)
+	imageUsecase := usecase.NewImageUsecase(repository.NewImageRepository(db), store)
+
+	job.Every(ctx, "expire-prepaid-balances", job.IntervalFromEnv("EXPIRY_SWEEP_INTERVAL", time.Hour),
+		func(ctx context.Context) error {
+			packages, err := packageUsecase.ExpirePackages(ctx)
+			if err != nil {
+				return err
+			}
+			giftCards, err := giftCardUsecase.ExpireGiftCards(ctx)
+			if err != nil {
+				return err
+			}
+			points, err := loyaltyUsecase.ExpirePoints(ctx)
+			if err != nil {
+				return err
+			}
+			if packages > 0 || giftCards > 0 || points > 0 {
+				log.Infof("expired %d packages, %d gift cards and %d points lots", packages, giftCards, points)
+			}
+			return nil
+		})
+	job.Every(ctx, "renew-memberships", job.IntervalFromEnv("RENEWAL_SWEEP_INTERVAL", 15*time.Minute),
+		func(ctx context.Context) error {
+			renewed, err := membershipUsecase.RenewSubscriptions(ctx)
+			if err != nil {
+				return err
+			}
+			if renewed > 0 {
+				log.Infof("renewed %d membership subscriptions", renewed)
+			}
+			return nil
+		})
+	job.Every(ctx, "cleanup-orphan-images", job.IntervalFromEnv("ORPHAN_IMAGE_SWEEP_INTERVAL", time.Hour),
+		func(ctx context.Context) error {
+			removed, err := imageUsecase.CleanupOrphans(ctx)
+			if err != nil {
+				return err
+			}
+			if removed > 0 {
+				log.Infof("removed %d orphaned images", removed)
+			}
+			return nil
+		})
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Access is who may call a route: anyone, any signed-in user, or signed-in
+// users with one of a set of roles.
+type Access struct {
+	public bool
+	roles  []string
+}
+
+var (
+	// Public routes need no session
+	Public = Access{public: true}
+	// Authenticated routes need a session, whatever the role
+	Authenticated = Access{}
+)
+
+// Roles limits a route to signed-in users with one of the roles.
+func Roles(roles ...string) Access {
+	return Access{roles: roles}
+}
+
+// Allows reports whether a signed-in user with the role may call the route.
+func (a Access) Allows(role string) bool {
+	if len(a.roles) == 0 {
+		return true
+	}
+	for _, allowed := range a.roles {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (a Access) String() string {
+	switch {
+	case a.public:
+		return "public"
+	case len(a.roles) == 0:
+		return "authenticated"
+	default:
+		return strings.Join(a.roles, "|")
+	}
+}
+
+// AccessPolicy declares the access of every route, either one route at a
+// time or for all routes under a path prefix. A route's own access wins over
+// its group's, and the longest matching prefix wins among groups.
+type AccessPolicy struct {
+	routes map[string]Access
+	groups map[string]Access
+}
+
+func NewAccessPolicy() *AccessPolicy {
+	return &AccessPolicy{
+		routes: map[string]Access{},
+		groups: map[string]Access{},
+	}
+}
+
+// Group sets the access of every route under the prefix that isn't declared
+// on its own.
+func (p *AccessPolicy) Group(prefix string, access Access) *AccessPolicy {
+	p.groups[strings.TrimSuffix(prefix, "/")] = access
+	return p
+}
+
+// Route sets the access of one route, by method and path as registered.
+func (p *AccessPolicy) Route(method, path string, access Access) *AccessPolicy {
+	p.routes[method+" "+path] = access
+	return p
+}
+
+// Lookup returns the access of the route registered with the method and
+// path, and false when the policy doesn't cover it.
+func (p *AccessPolicy) Lookup(method, path string) (Access, bool) {
+	if access, ok := p.routes[method+" "+path]; ok {
+		return access, true
+	}
+
+	var (
+		access  Access
+		matched = -1
+	)
+	for prefix, groupAccess := range p.groups {
+		if len(prefix) > matched && (path == prefix || strings.HasPrefix(path, prefix+"/")) {
+			access, matched = groupAccess, len(prefix)
+		}
+	}
+	return access, matched >= 0
+}
+
+// Verify returns an error naming the routes the policy doesn't cover, so
+// that a route can't be served without its access being declared.
+func (p *AccessPolicy) Verify(routes []*echo.Route) error {
+	missing := []string{}
+	for _, route := range routes {
+		if _, ok := p.Lookup(route.Method, route.Path); !ok {
+			missing = append(missing, route.Method+" "+route.Path)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf("no access declared for %s", strings.Join(missing, ", "))
+}
+
+// Matrix lists the access of every route, as "METHOD path" to access, for
+// logging and review.
+func (p *AccessPolicy) Matrix(routes []*echo.Route) map[string]string {
+	matrix := make(map[string]string, len(routes))
+	for _, route := range routes {
+		access, ok := p.Lookup(route.Method, route.Path)
+		if !ok {
+			matrix[route.Method+" "+route.Path] = "undeclared"
+			continue
+		}
+		matrix[route.Method+" "+route.Path] = access.String()
+	}
+	return matrix
+}
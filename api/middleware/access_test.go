@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestAccessPolicyLookup(t *testing.T) {
+	policy := NewAccessPolicy().
+		Group("/api/v1/branch", Roles("ADMIN")).
+		Group("/api/v1/branch/:id/services", Roles("ADMIN", "BRANCH_MANAGER")).
+		Route(http.MethodGet, "/api/v1/branch", Public).
+		Route(http.MethodGet, "/api/v1/branch/:id/services", Authenticated)
+
+	tests := []struct {
+		method, path string
+		access       string
+		ok           bool
+	}{
+		{http.MethodGet, "/api/v1/branch", "public", true},
+		{http.MethodPost, "/api/v1/branch", "ADMIN", true},
+		{http.MethodPut, "/api/v1/branch/:id", "ADMIN", true},
+		{http.MethodGet, "/api/v1/branch/:id/services", "authenticated", true},
+		{http.MethodPut, "/api/v1/branch/:id/services/:service_id", "ADMIN|BRANCH_MANAGER", true},
+		{http.MethodGet, "/api/v1/branches", "", false},
+		{http.MethodGet, "/api/v1/service", "", false},
+	}
+	for _, tt := range tests {
+		access, ok := policy.Lookup(tt.method, tt.path)
+		if ok != tt.ok || (ok && access.String() != tt.access) {
+			t.Errorf("Lookup(%s %s) = %s, %v; want %s, %v", tt.method, tt.path, access, ok, tt.access, tt.ok)
+		}
+	}
+}
+
+func TestAccessAllows(t *testing.T) {
+	if !Authenticated.Allows("USER") {
+		t.Error("authenticated routes should allow any role")
+	}
+	admin := Roles("ADMIN", "BRANCH_MANAGER")
+	if !admin.Allows("BRANCH_MANAGER") || admin.Allows("USER") || admin.Allows("") {
+		t.Error("role routes should only allow their roles")
+	}
+}
+
+func TestAccessPolicyVerify(t *testing.T) {
+	routes := []*echo.Route{
+		{Method: http.MethodGet, Path: "/api/v1/branch"},
+		{Method: http.MethodDelete, Path: "/api/v1/service/:id"},
+	}
+	policy := NewAccessPolicy().Group("/api/v1/branch", Public)
+	if err := policy.Verify(routes); err == nil {
+		t.Error("Verify should report the undeclared service route")
+	}
+	policy.Group("/api/v1/service", Roles("ADMIN"))
+	if err := policy.Verify(routes); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"KaungHtetHein116/IVY-backend/api/transport"
-	"KaungHtetHein116/IVY-backend/pkg/constants"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/pkg/scope"
+	"context"
 	"net/http"
 	"strings"
 
@@ -11,75 +13,29 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-type paths struct {
-	path   string
-	method string
-}
-
-var openRoutes = []paths{
-	{
-		path:   "/",
-		method: http.MethodGet,
-	},
-	{
-		path:   "/api/v1/user/login",
-		method: http.MethodPost,
-	},
-	{
-		path:   "/api/v1/user/register",
-		method: http.MethodPost,
-	},
-
-	{
-		path:   "/api/v1/branch",
-		method: http.MethodGet,
-	},
-	{
-		path:   "/api/v1/branch/:id",
-		method: http.MethodPut,
-	},
-
-	{
-		path:   "/api/v1/category",
-		method: http.MethodGet,
-	},
-	{
-		path:   "/api/v1/category/:id",
-		method: http.MethodGet,
-	},
-
-	{
-		path:   "/api/v1/service",
-		method: http.MethodGet,
-	},
-	{
-		path:   "/api/v1/service/:id",
-		method: http.MethodGet,
-	},
-
-	{
-		path:   "/api/v1/booking",
-		method: http.MethodGet,
-	},
-	{
-		path:   "/api/v1/booking/:id",
-		method: http.MethodGet,
-	},
-	{
-		path:   "/api/v1/user/clerk-user-webhook",
-		method: http.MethodPost,
-	},
-}
+// AccessLoader returns the role of a signed-in user and the scope of what
+// they may see.
+type AccessLoader func(ctx context.Context, userID string) (string, scope.Scope, error)
 
-func RegisterAuthMiddleware(e *echo.Echo) {
-	e.Use(JWTMiddleware())
+// RegisterAuthMiddleware checks every request against the access policy.
+// Routes the policy doesn't cover are refused.
+func RegisterAuthMiddleware(e *echo.Echo, policy *AccessPolicy, access AccessLoader) {
+	e.Use(JWTMiddleware(policy, access))
 }
 
-func JWTMiddleware() echo.MiddlewareFunc {
+func JWTMiddleware(policy *AccessPolicy, loadAccess AccessLoader) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			// Unmatched requests have no path and end in a 404
+			if c.Path() == "" {
+				return next(c)
+			}
 
-			if !shouldAuthorize(c) {
+			access, ok := policy.Lookup(c.Request().Method, c.Path())
+			if !ok {
+				return transport.NewApiErrorResponse(c, http.StatusForbidden, i18n.CodeForbidden, nil)
+			}
+			if access.public {
 				return next(c)
 			}
 
@@ -94,7 +50,7 @@ func JWTMiddleware() echo.MiddlewareFunc {
 			}
 
 			if sessionToken == "" {
-				return transport.NewApiErrorResponse(c, http.StatusUnauthorized, constants.ErrUnauthorized, nil)
+				return transport.NewApiErrorResponse(c, http.StatusUnauthorized, i18n.CodeUnauthorized, nil)
 			}
 
 			claims, err := jwt.Verify(c.Request().Context(), &jwt.VerifyParams{
@@ -102,32 +58,29 @@ func JWTMiddleware() echo.MiddlewareFunc {
 			})
 
 			if err != nil {
-				return transport.NewApiErrorResponse(c, http.StatusUnauthorized, constants.ErrInvalidToken, nil)
+				return transport.NewApiErrorResponse(c, http.StatusUnauthorized, i18n.CodeInvalidToken, nil)
 			}
 
 			usr, err := user.Get(c.Request().Context(), claims.Subject)
 
 			if err != nil {
-				return transport.NewApiErrorResponse(c, http.StatusUnauthorized, constants.ErrInvalidToken, nil)
+				return transport.NewApiErrorResponse(c, http.StatusUnauthorized, i18n.CodeInvalidToken, nil)
 			}
 
-			c.Set("user_id", usr.ID)
+			role, callerScope, err := loadAccess(c.Request().Context(), usr.ID)
+			if err != nil {
+				return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to verify user permissions", err)
+			}
 
-			return next(c)
-		}
-	}
-}
+			c.Set("user_id", usr.ID)
+			c.Set("role", role)
+			c.SetRequest(c.Request().WithContext(scope.WithContext(c.Request().Context(), callerScope)))
 
-func shouldAuthorize(c echo.Context) bool {
-	requestPath := c.Path()
-	requestMethod := c.Request().Method
+			if !access.Allows(role) {
+				return transport.NewApiErrorResponse(c, http.StatusForbidden, i18n.CodeForbidden, nil)
+			}
 
-	// Check if the current route is in excluded routes
-	for _, route := range openRoutes {
-		if route.path == requestPath && route.method == requestMethod {
-			return false
+			return next(c)
 		}
 	}
-
-	return true
 }
@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"KaungHtetHein116/IVY-backend/config"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -13,13 +16,24 @@ func RegisterBasicMiddlewares(e *echo.Echo) {
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     []string{"http://localhost:*", "https://ivy-dashboard.vercel.app", "https://ivy-frontend-xi.vercel.app"},
 		AllowMethods:     []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "Accept-Language"},
+		ExposeHeaders:    []string{"Content-Language"},
 		AllowCredentials: true,
 	}))
 	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Format: `${time_rfc3339} ${status} ${method} ${uri} ${latency_human}` + "\n",
 	}))
-	e.Use(middleware.BodyLimit("1M"))
+	// Image uploads may be larger than any JSON body, up to the upload limit
+	// plus room for the multipart framing
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Skipper: isImageUpload,
+		Limit:   "1M",
+	}))
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Skipper: func(c echo.Context) bool { return !isImageUpload(c) },
+		Limit:   strconv.FormatInt(config.UploadMaxBytes()+64<<10, 10),
+	}))
+	e.Use(LocaleMiddleware())
 
 	e.GET("/", func(ctx echo.Context) error {
 		return ctx.JSON(http.StatusOK, echo.Map{
@@ -27,3 +41,7 @@ func RegisterBasicMiddlewares(e *echo.Echo) {
 		})
 	})
 }
+
+func isImageUpload(c echo.Context) bool {
+	return c.Request().Method == http.MethodPost && strings.HasSuffix(c.Path(), "/:id/image")
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"KaungHtetHein116/IVY-backend/config"
+	"KaungHtetHein116/IVY-backend/pkg/locale"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LocaleMiddleware negotiates the response language from the lang query
+// parameter or the Accept-Language header and stores it in the request
+// context for the usecases.
+func LocaleMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			code := locale.Negotiate(
+				c.QueryParam("lang"),
+				c.Request().Header.Get("Accept-Language"),
+				config.DefaultLocale(),
+			)
+
+			c.Set("locale", code)
+			c.SetRequest(c.Request().WithContext(locale.WithContext(c.Request().Context(), code)))
+			c.Response().Header().Set("Content-Language", code)
+			c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+
+			return next(c)
+		}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/pkg/locale"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -25,15 +28,24 @@ type PaginationResponse struct {
 	HasPrev    bool `json:"has_prev"`
 }
 
+// NewApiErrorResponse writes an error in the request's locale together with
+// a stable error_code clients can match on instead of the message.
 func NewApiErrorResponse(c echo.Context, code int, message string, data interface{}) error {
+	errorCode, message := Localize(c, message)
+	if errorCode == "" {
+		errorCode = i18n.StatusCode(code)
+	}
+
 	return c.JSON(code, echo.Map{
-		"code":    code,
-		"message": message,
-		"data":    data,
+		"code":       code,
+		"error_code": errorCode,
+		"message":    message,
+		"data":       data,
 	})
 }
 
 func NewApiSuccessResponse(c echo.Context, code int, message string, data interface{}, opts ...interface{}) error {
+	_, message = Localize(c, message)
 	response := echo.Map{
 		"code":    code,
 		"message": message,
@@ -54,6 +66,7 @@ func NewApiCreateSuccessResponse(c echo.Context, message string, data interface{
 	if message == "" {
 		formattedMessage = http.StatusText(http.StatusCreated)
 	}
+	_, formattedMessage = Localize(c, formattedMessage)
 	return c.JSON(http.StatusCreated, echo.Map{
 		"code":    http.StatusCreated,
 		"message": formattedMessage,
@@ -74,3 +87,17 @@ func NewBindingErrorResponse(c echo.Context, err *json.UnmarshalTypeError) error
 		}},
 	})
 }
+
+// Localize returns the code of an English message and its text in the locale
+// negotiated for the request.
+func Localize(c echo.Context, message string) (string, string) {
+	return i18n.Localize(RequestLocale(c), message)
+}
+
+// RequestLocale is the locale negotiated for the request, English if none was.
+func RequestLocale(c echo.Context) string {
+	if code := locale.FromContext(c.Request().Context()); code != "" {
+		return code
+	}
+	return locale.English
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"KaungHtetHein116/IVY-backend/api/middleware"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"net/http"
+)
+
+// AccessPolicy declares who may call each v1 route. Groups set the access of
+// their routes and the routes listed under them are the exceptions. Every
+// route must be covered, see middleware.AccessPolicy.Verify, and is listed
+// in access_test.go.
+func AccessPolicy() *middleware.AccessPolicy {
+	admin := middleware.Roles(entity.RoleAdmin)
+	managers := middleware.Roles(entity.RoleAdmin, entity.RoleBranchManager)
+	branchTeam := middleware.Roles(entity.RoleAdmin, entity.RoleBranchManager, entity.RoleStaff)
+
+	return middleware.NewAccessPolicy().
+		Route(http.MethodGet, "/", middleware.Public).
+
+		// Customers manage their own profile and favorites; the handler
+		// only lets admins update someone else's profile
+		Group("/api/v1/user", middleware.Authenticated).
+		Route(http.MethodGet, "/api/v1/user", admin).
+		Route(http.MethodPost, "/api/v1/user/clerk-user-webhook", middleware.Public).
+
+		// The catalog is public and managed by admins. Branch managers
+		// manage the details and services of their own branches
+		Group("/api/v1/branch", admin).
+		Route(http.MethodGet, "/api/v1/branch", middleware.Public).
+		Route(http.MethodGet, "/api/v1/branch/nearby", middleware.Public).
+		Route(http.MethodGet, "/api/v1/branch/:id", middleware.Public).
+		Route(http.MethodPut, "/api/v1/branch/:id", managers).
+		Route(http.MethodGet, "/api/v1/branch/:id/services", managers).
+		Route(http.MethodPut, "/api/v1/branch/:id/services/:service_id", managers).
+		Route(http.MethodDelete, "/api/v1/branch/:id/services/:service_id", managers).
+		Group("/api/v1/category", admin).
+		Route(http.MethodGet, "/api/v1/category", middleware.Public).
+		Route(http.MethodGet, "/api/v1/category/tree", middleware.Public).
+		Route(http.MethodGet, "/api/v1/category/:id", middleware.Public).
+		Group("/api/v1/service", admin).
+		Route(http.MethodGet, "/api/v1/service", middleware.Public).
+		Route(http.MethodGet, "/api/v1/service/search", middleware.Public).
+		Route(http.MethodGet, "/api/v1/service/:id", middleware.Public).
+		Route(http.MethodGet, "/api/v1/service/:id/variants", middleware.Public).
+		Route(http.MethodGet, "/api/v1/service/:id/add-ons", middleware.Public).
+		Route(http.MethodGet, "/api/v1/service/:id/reviews", middleware.Public).
+		Group("/api/v1/package", admin).
+		Route(http.MethodGet, "/api/v1/package", middleware.Public).
+		Route(http.MethodGet, "/api/v1/package/:id", middleware.Public).
+		Route(http.MethodGet, "/api/v1/package/me", middleware.Authenticated).
+		Group("/api/v1/membership", admin).
+		Route(http.MethodGet, "/api/v1/membership/plans", middleware.Public).
+		Route(http.MethodGet, "/api/v1/membership/plans/:id", middleware.Public).
+		Route(http.MethodPost, "/api/v1/membership/plans/:id/subscribe", middleware.Authenticated).
+		Route(http.MethodGet, "/api/v1/membership/subscriptions/me", middleware.Authenticated).
+		Route(http.MethodPost, "/api/v1/membership/subscriptions/me/cancel", middleware.Authenticated).
+
+		// Back office
+		Group("/api/v1/tax-rate", admin).
+		Group("/api/v1/pricing-rule", admin).
+		Group("/api/v1/promotion", admin).
+		Group("/api/v1/gift-card", admin).
+		Route(http.MethodGet, "/api/v1/gift-card/code/:code", middleware.Authenticated).
+		Group("/api/v1/product", admin).
+		Group("/api/v1/inventory", admin).
+		Group("/api/v1/ledger", admin).
+		Group("/api/v1/refund", admin).
+		Group("/api/v1/loyalty", admin).
+		Route(http.MethodGet, "/api/v1/loyalty/me", middleware.Authenticated).
+		Route(http.MethodGet, "/api/v1/loyalty/me/history", middleware.Authenticated).
+		Group("/api/v1/review", admin).
+		Route(http.MethodGet, "/api/v1/review/me", middleware.Authenticated).
+
+		// Customers book, pay, cancel and review their own bookings. Staff
+		// see the bookings of their branches and branch managers manage them.
+		// Only admins delete unpaid bookings outright
+		Group("/api/v1/booking", middleware.Authenticated).
+		Route(http.MethodGet, "/api/v1/booking", branchTeam).
+		Route(http.MethodPut, "/api/v1/booking/:id", managers).
+		Route(http.MethodDelete, "/api/v1/booking/:id", admin).
+		Group("/api/v1/notification", middleware.Authenticated)
+}
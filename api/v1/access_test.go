@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"KaungHtetHein116/IVY-backend/api/middleware"
+	"KaungHtetHein116/IVY-backend/internal/payment"
+	"KaungHtetHein116/IVY-backend/internal/storage"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	public        = "public"
+	authenticated = "authenticated"
+	admin         = "ADMIN"
+	managers      = "ADMIN|BRANCH_MANAGER"
+	branchTeam    = "ADMIN|BRANCH_MANAGER|STAFF"
+)
+
+// expectedAccess is who may call every route. Adding a route or changing
+// its access has to be reflected here.
+var expectedAccess = map[string]string{
+	"GET /":                                                          public,
+	"GET /uploads*":                                                  public,
+	"GET /api/v1/booking":                                            branchTeam,
+	"POST /api/v1/booking":                                           authenticated,
+	"DELETE /api/v1/booking/:id":                                     admin,
+	"GET /api/v1/booking/:id":                                        authenticated,
+	"PUT /api/v1/booking/:id":                                        managers,
+	"GET /api/v1/booking/:id/book-again":                             authenticated,
+	"POST /api/v1/booking/:id/cancel":                                authenticated,
+	"GET /api/v1/booking/:id/invoice":                                authenticated,
+	"GET /api/v1/booking/:id/invoice.pdf":                            authenticated,
+	"POST /api/v1/booking/:id/pay":                                   authenticated,
+	"GET /api/v1/booking/:id/payments":                               authenticated,
+	"POST /api/v1/booking/:id/review":                                authenticated,
+	"GET /api/v1/booking/me":                                         authenticated,
+	"GET /api/v1/booking/slots":                                      authenticated,
+	"GET /api/v1/branch":                                             public,
+	"POST /api/v1/branch":                                            admin,
+	"DELETE /api/v1/branch/:id":                                      admin,
+	"GET /api/v1/branch/:id":                                         public,
+	"PUT /api/v1/branch/:id":                                         managers,
+	"GET /api/v1/branch/:id/delete-impact":                           admin,
+	"POST /api/v1/branch/:id/image":                                  admin,
+	"GET /api/v1/branch/:id/members":                                 admin,
+	"DELETE /api/v1/branch/:id/members/:user_id":                     admin,
+	"PUT /api/v1/branch/:id/members/:user_id":                        admin,
+	"POST /api/v1/branch/:id/restore":                                admin,
+	"GET /api/v1/branch/:id/services":                                managers,
+	"DELETE /api/v1/branch/:id/services/:service_id":                 managers,
+	"PUT /api/v1/branch/:id/services/:service_id":                    managers,
+	"GET /api/v1/branch/:id/translations":                            admin,
+	"DELETE /api/v1/branch/:id/translations/:locale":                 admin,
+	"PUT /api/v1/branch/:id/translations/:locale":                    admin,
+	"GET /api/v1/branch/nearby":                                      public,
+	"GET /api/v1/branch/trash":                                       admin,
+	"GET /api/v1/category":                                           public,
+	"POST /api/v1/category":                                          admin,
+	"DELETE /api/v1/category/:id":                                    admin,
+	"GET /api/v1/category/:id":                                       public,
+	"PUT /api/v1/category/:id":                                       admin,
+	"GET /api/v1/category/:id/delete-impact":                         admin,
+	"POST /api/v1/category/:id/restore":                              admin,
+	"GET /api/v1/category/:id/translations":                          admin,
+	"DELETE /api/v1/category/:id/translations/:locale":               admin,
+	"PUT /api/v1/category/:id/translations/:locale":                  admin,
+	"PUT /api/v1/category/reorder":                                   admin,
+	"GET /api/v1/category/trash":                                     admin,
+	"GET /api/v1/category/tree":                                      public,
+	"GET /api/v1/gift-card":                                          admin,
+	"POST /api/v1/gift-card":                                         admin,
+	"GET /api/v1/gift-card/:id":                                      admin,
+	"PUT /api/v1/gift-card/:id":                                      admin,
+	"GET /api/v1/gift-card/code/:code":                               authenticated,
+	"GET /api/v1/inventory/branches/:branch_id":                      admin,
+	"PUT /api/v1/inventory/branches/:branch_id/products/:product_id": admin,
+	"GET /api/v1/inventory/low-stock":                                admin,
+	"GET /api/v1/inventory/movements":                                admin,
+	"POST /api/v1/inventory/movements":                               admin,
+	"GET /api/v1/ledger":                                             admin,
+	"GET /api/v1/loyalty/me":                                         authenticated,
+	"GET /api/v1/loyalty/me/history":                                 authenticated,
+	"GET /api/v1/loyalty/rules":                                      admin,
+	"POST /api/v1/loyalty/rules":                                     admin,
+	"DELETE /api/v1/loyalty/rules/:id":                               admin,
+	"PUT /api/v1/loyalty/rules/:id":                                  admin,
+	"GET /api/v1/loyalty/users/:id":                                  admin,
+	"POST /api/v1/loyalty/users/:id/adjust":                          admin,
+	"GET /api/v1/loyalty/users/:id/history":                          admin,
+	"GET /api/v1/membership/plans":                                   public,
+	"POST /api/v1/membership/plans":                                  admin,
+	"DELETE /api/v1/membership/plans/:id":                            admin,
+	"GET /api/v1/membership/plans/:id":                               public,
+	"PUT /api/v1/membership/plans/:id":                               admin,
+	"POST /api/v1/membership/plans/:id/subscribe":                    authenticated,
+	"GET /api/v1/membership/subscriptions":                           admin,
+	"GET /api/v1/membership/subscriptions/me":                        authenticated,
+	"POST /api/v1/membership/subscriptions/me/cancel":                authenticated,
+	"PUT /api/v1/notification/:id/read":                              authenticated,
+	"GET /api/v1/notification/me":                                    authenticated,
+	"GET /api/v1/package":                                            public,
+	"POST /api/v1/package":                                           admin,
+	"DELETE /api/v1/package/:id":                                     admin,
+	"GET /api/v1/package/:id":                                        public,
+	"PUT /api/v1/package/:id":                                        admin,
+	"POST /api/v1/package/:id/sell":                                  admin,
+	"GET /api/v1/package/me":                                         authenticated,
+	"GET /api/v1/pricing-rule":                                       admin,
+	"POST /api/v1/pricing-rule":                                      admin,
+	"DELETE /api/v1/pricing-rule/:id":                                admin,
+	"GET /api/v1/pricing-rule/:id":                                   admin,
+	"PUT /api/v1/pricing-rule/:id":                                   admin,
+	"GET /api/v1/product":                                            admin,
+	"POST /api/v1/product":                                           admin,
+	"DELETE /api/v1/product/:id":                                     admin,
+	"GET /api/v1/product/:id":                                        admin,
+	"PUT /api/v1/product/:id":                                        admin,
+	"GET /api/v1/promotion":                                          admin,
+	"POST /api/v1/promotion":                                         admin,
+	"DELETE /api/v1/promotion/:id":                                   admin,
+	"GET /api/v1/promotion/:id":                                      admin,
+	"PUT /api/v1/promotion/:id":                                      admin,
+	"GET /api/v1/refund":                                             admin,
+	"GET /api/v1/refund/:id":                                         admin,
+	"POST /api/v1/refund/:id/approve":                                admin,
+	"POST /api/v1/refund/:id/reject":                                 admin,
+	"GET /api/v1/refund/rules":                                       admin,
+	"POST /api/v1/refund/rules":                                      admin,
+	"DELETE /api/v1/refund/rules/:id":                                admin,
+	"PUT /api/v1/refund/rules/:id":                                   admin,
+	"GET /api/v1/review":                                             admin,
+	"DELETE /api/v1/review/:id":                                      admin,
+	"GET /api/v1/review/:id":                                         admin,
+	"POST /api/v1/review/:id/approve":                                admin,
+	"POST /api/v1/review/:id/reject":                                 admin,
+	"GET /api/v1/review/me":                                          authenticated,
+	"GET /api/v1/service":                                            public,
+	"POST /api/v1/service":                                           admin,
+	"DELETE /api/v1/service/:id":                                     admin,
+	"GET /api/v1/service/:id":                                        public,
+	"PUT /api/v1/service/:id":                                        admin,
+	"GET /api/v1/service/:id/add-ons":                                public,
+	"POST /api/v1/service/:id/add-ons":                               admin,
+	"DELETE /api/v1/service/:id/add-ons/:add_on_id":                  admin,
+	"PUT /api/v1/service/:id/add-ons/:add_on_id":                     admin,
+	"GET /api/v1/service/:id/consumables":                            admin,
+	"PUT /api/v1/service/:id/consumables":                            admin,
+	"GET /api/v1/service/:id/delete-impact":                          admin,
+	"POST /api/v1/service/:id/image":                                 admin,
+	"POST /api/v1/service/:id/restore":                               admin,
+	"GET /api/v1/service/:id/reviews":                                public,
+	"GET /api/v1/service/:id/translations":                           admin,
+	"DELETE /api/v1/service/:id/translations/:locale":                admin,
+	"PUT /api/v1/service/:id/translations/:locale":                   admin,
+	"GET /api/v1/service/:id/variants":                               public,
+	"POST /api/v1/service/:id/variants":                              admin,
+	"DELETE /api/v1/service/:id/variants/:variant_id":                admin,
+	"PUT /api/v1/service/:id/variants/:variant_id":                   admin,
+	"GET /api/v1/service/:id/versions":                               admin,
+	"GET /api/v1/service/search":                                     public,
+	"GET /api/v1/service/trash":                                      admin,
+	"GET /api/v1/tax-rate":                                           admin,
+	"POST /api/v1/tax-rate":                                          admin,
+	"DELETE /api/v1/tax-rate/:id":                                    admin,
+	"GET /api/v1/tax-rate/:id":                                       admin,
+	"PUT /api/v1/tax-rate/:id":                                       admin,
+	"GET /api/v1/user":                                               admin,
+	"GET /api/v1/user/:id":                                           authenticated,
+	"PUT /api/v1/user/:id":                                           authenticated,
+	"POST /api/v1/user/clerk-user-webhook":                           public,
+	"GET /api/v1/user/me":                                            authenticated,
+	"GET /api/v1/user/me/branches":                                   authenticated,
+	"GET /api/v1/user/me/favorites":                                  authenticated,
+	"DELETE /api/v1/user/me/favorites/branches/:branch_id":           authenticated,
+	"PUT /api/v1/user/me/favorites/branches/:branch_id":              authenticated,
+	"DELETE /api/v1/user/me/favorites/services/:service_id":          authenticated,
+	"PUT /api/v1/user/me/favorites/services/:service_id":             authenticated,
+}
+
+func TestAccessMatrix(t *testing.T) {
+	e := echo.New()
+	policy := AccessPolicy()
+	middleware.RegisterBasicMiddlewares(e)
+	route := e.Static("/uploads", t.TempDir())
+	policy.Route(route.Method, route.Path, middleware.Public)
+	RegisterRoutes(e, nil, storage.NewLocalStorage(t.TempDir(), "/uploads"), payment.NewFakeProvider())
+
+	if err := policy.Verify(e.Routes()); err != nil {
+		t.Error(err)
+	}
+
+	matrix := policy.Matrix(e.Routes())
+	for route, access := range matrix {
+		expected, ok := expectedAccess[route]
+		if !ok {
+			t.Errorf("%s is %s but missing from expectedAccess", route, access)
+			continue
+		}
+		if access != expected {
+			t.Errorf("%s is %s, expected %s", route, access, expected)
+		}
+	}
+	for route := range expectedAccess {
+		if _, ok := matrix[route]; !ok {
+			t.Errorf("%s is expected but not registered", route)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"KaungHtetHein116/IVY-backend/api/v1/params"
 	"KaungHtetHein116/IVY-backend/api/v1/request"
 	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
 	"KaungHtetHein116/IVY-backend/utils"
 	"errors"
 	"net/http"
@@ -28,13 +29,63 @@ func (h *BookingHandler) CreateBooking(c echo.Context, req *request.CreateBookin
 	booking, err := h.usecase.CreateBooking(c.Request().Context(), userID, req)
 
 	if err == utils.ErrUserHadBooking {
-		return transport.NewApiErrorResponse(c, http.StatusConflict,
-			"You already have a booking for this service at this time. If you wish to book, please cancel the first booking.",
-			err)
+		return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
 	}
 
-	if errors.Is(err, utils.ErrServiceNotFound) || errors.Is(err, utils.ErrCategoryNotFound) {
-		return transport.NewApiErrorResponse(c, http.StatusNotFound, "Service or Branch not found", nil)
+	if errors.Is(err, utils.ErrServiceNotFound) || errors.Is(err, utils.ErrBranchNotFound) || errors.Is(err, utils.ErrCategoryNotFound) {
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrVariantNotFound) || errors.Is(err, utils.ErrAddOnNotFound) {
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrVariantRequired) {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrServiceNotAtBranch) {
+		return transport.NewApiErrorResponse(c, http.StatusUnprocessableEntity, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrSlotFull) {
+		return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrPriceChanged) {
+		return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrPromotionNotFound) {
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrPromotionInactive) || errors.Is(err, utils.ErrPromotionNotApplicable) {
+		return transport.NewApiErrorResponse(c, http.StatusUnprocessableEntity, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrPromotionUsageLimitReached) {
+		return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrPackageCreditNotFound) || errors.Is(err, utils.ErrGiftCardNotFound) {
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrPackageCreditExhausted) || errors.Is(err, utils.ErrGiftCardUnusable) {
+		return transport.NewApiErrorResponse(c, http.StatusUnprocessableEntity, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrRedemptionConflict) {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrSubscriptionNotFound) || errors.Is(err, utils.ErrMembershipCreditsExhausted) {
+		return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+	}
+
+	if errors.Is(err, utils.ErrInsufficientPoints) || errors.Is(err, utils.ErrInvalidPoints) {
+		return transport.NewApiErrorResponse(c, http.StatusUnprocessableEntity, utils.ErrorCode(err), nil)
 	}
 
 	if err != nil {
@@ -66,7 +117,7 @@ func (h *BookingHandler) GetAllBookings(c echo.Context) error {
 	err := c.Bind(filter)
 
 	if err != nil {
-		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
 	}
 
 	bookings, pagination, err := h.usecase.GetAllBookings(c.Request().Context(), filter)
@@ -99,6 +150,12 @@ func (h *BookingHandler) UpdateBooking(c echo.Context, req *request.UpdateBookin
 		if err == gorm.ErrRecordNotFound {
 			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Booking not found", err)
 		}
+		if errors.Is(err, utils.ErrBookingCancelled) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrBookingCompleted) || errors.Is(err, utils.ErrBookingHasPayments) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
 
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update booking", err)
 	}
@@ -106,6 +163,27 @@ func (h *BookingHandler) UpdateBooking(c echo.Context, req *request.UpdateBookin
 	return transport.NewApiSuccessResponse(c, http.StatusOK, "Booking updated successfully", booking)
 }
 
+func (h *BookingHandler) CancelBooking(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid booking ID", err)
+	}
+
+	userID := c.Get("user_id").(string)
+	booking, err := h.usecase.CancelBooking(c.Request().Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Booking not found", nil)
+		}
+		if errors.Is(err, utils.ErrBookingCompleted) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to cancel booking", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Booking cancelled successfully", booking)
+}
+
 func (h *BookingHandler) DeleteBooking(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -117,6 +195,12 @@ func (h *BookingHandler) DeleteBooking(c echo.Context) error {
 		if err == gorm.ErrRecordNotFound {
 			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Booking not found", err)
 		}
+		if errors.Is(err, utils.ErrBookingCompleted) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrBookingHasPayments) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete booking", err)
 	}
 
@@ -124,22 +208,127 @@ func (h *BookingHandler) DeleteBooking(c echo.Context) error {
 }
 
 func (h *BookingHandler) GetAvailableSlots(c echo.Context) error {
-	branchID := c.QueryParam("branch_id")
 	bookedDate := c.QueryParam("booked_date")
 
-	if branchID == "" || bookedDate == "" {
-		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Branch ID and booked date are required", nil)
+	if c.QueryParam("branch_id") == "" || bookedDate == "" {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeSlotQueryRequired, nil)
+	}
+	branchID, err := uuid.Parse(c.QueryParam("branch_id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	serviceID := uuid.Nil
+	if c.QueryParam("service_id") != "" {
+		serviceID, err = uuid.Parse(c.QueryParam("service_id"))
+		if err != nil {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+		}
+	}
+
+	var variantID *uuid.UUID
+	if c.QueryParam("variant_id") != "" {
+		id, err := uuid.Parse(c.QueryParam("variant_id"))
+		if err != nil {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid variant ID", err)
+		}
+		variantID = &id
 	}
 
 	timeSlots, err := h.usecase.GetTimeSlotsByBranchIDAndDate(
 		c.Request().Context(),
-		uuid.MustParse(branchID),
+		branchID,
 		bookedDate,
+		serviceID,
+		variantID,
 	)
 
+	if errors.Is(err, utils.ErrServiceNotFound) {
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	}
+	if errors.Is(err, utils.ErrVariantNotFound) {
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	}
+	if errors.Is(err, utils.ErrServiceNotAtBranch) {
+		return transport.NewApiErrorResponse(c, http.StatusUnprocessableEntity, utils.ErrorCode(err), nil)
+	}
+
 	if err != nil {
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get available slots", err)
 	}
 
 	return transport.NewApiSuccessResponse(c, http.StatusOK, "Available slots retrieved successfully", timeSlots)
 }
+
+func (h *BookingHandler) PayBooking(c echo.Context, req *request.PayBookingRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid booking ID", err)
+	}
+	userID := c.Get("user_id").(string)
+
+	booking, err := h.usecase.PayBooking(c.Request().Context(), id, userID, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Booking not found", err)
+		}
+		if errors.Is(err, utils.ErrBookingNotPayable) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrInvalidPaymentAmount) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrPaymentDeclined) {
+			return transport.NewApiErrorResponse(c, http.StatusPaymentRequired, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to pay booking", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, i18n.CodeBookingPaid, booking)
+}
+
+func (h *BookingHandler) GetBookingPayments(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid booking ID", err)
+	}
+
+	payments, err := h.usecase.GetBookingPayments(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Booking not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get booking payments", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Booking payments retrieved successfully", payments)
+}
+
+func (h *BookingHandler) BookAgain(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid booking ID", err)
+	}
+
+	filter := params.NewBookAgainQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	userID := c.Get("user_id").(string)
+	proposal, err := h.usecase.BookAgain(c.Request().Context(), id, userID, filter)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Booking not found", nil)
+		}
+		if errors.Is(err, utils.ErrServiceNotFound) || errors.Is(err, utils.ErrServiceNotAtBranch) {
+			return transport.NewApiErrorResponse(c, http.StatusUnprocessableEntity, i18n.CodeServiceNotAtBranch, nil)
+		}
+		if errors.Is(err, utils.ErrVariantNotFound) || errors.Is(err, utils.ErrVariantRequired) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, i18n.CodeBookingOptionUnavailable, nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to find slots to book again", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Available slots retrieved successfully", proposal)
+}
@@ -5,6 +5,8 @@ import (
 	"KaungHtetHein116/IVY-backend/api/v1/params"
 	"KaungHtetHein116/IVY-backend/api/v1/request"
 	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
 	"net/http"
 
 	"errors"
@@ -47,7 +49,7 @@ func (h *BranchHandler) GetAllBranches(c echo.Context) error {
 	filter := params.NewBranchQueryParams()
 	err := c.Bind(filter)
 	if err != nil {
-		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
 	}
 
 	branches, pagination, err := h.usecase.GetAllBranches(c.Request().Context(), filter)
@@ -57,6 +59,22 @@ func (h *BranchHandler) GetAllBranches(c echo.Context) error {
 	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branches retrieved successfully", branches, pagination)
 }
 
+func (h *BranchHandler) GetNearbyBranches(c echo.Context) error {
+	filter := params.NewBranchNearbyQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	branches, pagination, err := h.usecase.GetNearbyBranches(c.Request().Context(), filter)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidCoordinates) || errors.Is(err, utils.ErrInvalidRadius) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get branches", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branches retrieved successfully", branches, pagination)
+}
+
 func (h *BranchHandler) UpdateBranch(c echo.Context, req *request.UpdateBranchRequest) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -74,17 +92,96 @@ func (h *BranchHandler) UpdateBranch(c echo.Context, req *request.UpdateBranchRe
 	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branch updated successfully", branch)
 }
 
+func (h *BranchHandler) GetBranchDeleteImpact(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+	impact, err := h.usecase.GetDeleteImpact(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Branch not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get delete impact", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Delete impact retrieved successfully", impact)
+}
+
 func (h *BranchHandler) DeleteBranch(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
 	}
-	err = h.usecase.DeleteBranch(c.Request().Context(), id)
+	opts := new(params.DeleteQueryParams)
+	if err := c.Bind(opts); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+	impact, err := h.usecase.DeleteBranch(c.Request().Context(), id, opts)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Branch not found", err)
 		}
+		if status, ok := deleteSafeguardStatus(err); ok {
+			// A refused delete carries the impact that stands in its way
+			return transport.NewApiErrorResponse(c, status, utils.ErrorCode(err), impact)
+		}
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete branch", err)
 	}
 	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Branch deleted successfully", nil)
 }
+
+func (h *BranchHandler) GetDeletedBranches(c echo.Context) error {
+	filter := params.NewTrashQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	branches, pagination, err := h.usecase.GetDeletedBranches(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get deleted branches", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Deleted branches retrieved successfully", branches, pagination)
+}
+
+func (h *BranchHandler) RestoreBranch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	branch, err := h.usecase.RestoreBranch(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Deleted branch not found", nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to restore branch", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branch restored successfully", branch)
+}
+
+func (h *BranchHandler) UploadBranchImage(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	data, err := readImageUpload(c)
+	if err != nil {
+		if status := imageUploadStatus(err); status != 0 {
+			return transport.NewApiErrorResponse(c, status, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeImageRequired, err)
+	}
+
+	branch, err := h.usecase.UploadBranchImage(c.Request().Context(), id, data)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Branch not found", err)
+		}
+		if status := imageUploadStatus(err); status != 0 {
+			return transport.NewApiErrorResponse(c, status, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to upload branch image", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branch image uploaded successfully", branch)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type BranchMemberHandler struct {
+	usecase usecase.BranchMemberUsecase
+}
+
+func NewBranchMemberHandler(u usecase.BranchMemberUsecase) *BranchMemberHandler {
+	return &BranchMemberHandler{usecase: u}
+}
+
+func (h *BranchMemberHandler) GetBranchMembers(c echo.Context) error {
+	branchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	members, err := h.usecase.GetBranchMembers(c.Request().Context(), branchID)
+	if err != nil {
+		if errors.Is(err, utils.ErrBranchNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get branch members", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branch members retrieved successfully", members)
+}
+
+func (h *BranchMemberHandler) GetMyBranches(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	members, err := h.usecase.GetMyBranches(c.Request().Context(), userID)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get branches", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branches retrieved successfully", members)
+}
+
+func (h *BranchMemberHandler) AddBranchMember(c echo.Context) error {
+	branchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	member, err := h.usecase.AddBranchMember(c.Request().Context(), branchID, c.Param("user_id"))
+	if err != nil {
+		if errors.Is(err, utils.ErrBranchNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrUserNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrNotBranchRole) {
+			return transport.NewApiErrorResponse(c, http.StatusUnprocessableEntity, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to add branch member", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branch member created successfully", member)
+}
+
+func (h *BranchMemberHandler) RemoveBranchMember(c echo.Context) error {
+	branchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	if err := h.usecase.RemoveBranchMember(c.Request().Context(), branchID, c.Param("user_id")); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Branch member not found", nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to remove branch member", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Branch member deleted successfully", nil)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type BranchServiceHandler struct {
+	usecase usecase.BranchServiceUsecase
+}
+
+func NewBranchServiceHandler(u usecase.BranchServiceUsecase) *BranchServiceHandler {
+	return &BranchServiceHandler{usecase: u}
+}
+
+func (h *BranchServiceHandler) GetBranchServices(c echo.Context) error {
+	branchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	services, err := h.usecase.GetBranchServices(c.Request().Context(), branchID)
+	if err != nil {
+		if errors.Is(err, utils.ErrBranchNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get branch services", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branch services retrieved successfully", services)
+}
+
+func (h *BranchServiceHandler) SaveBranchService(c echo.Context, req *request.SaveBranchServiceRequest) error {
+	branchID, serviceID, err := branchServiceIDs(c)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch or service ID", err)
+	}
+
+	branchService, err := h.usecase.SaveBranchService(c.Request().Context(), branchID, serviceID, req)
+	if err != nil {
+		if errors.Is(err, utils.ErrBranchNotFound) || errors.Is(err, utils.ErrServiceNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to save branch service", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branch service updated successfully", branchService)
+}
+
+func (h *BranchServiceHandler) RemoveBranchService(c echo.Context) error {
+	branchID, serviceID, err := branchServiceIDs(c)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch or service ID", err)
+	}
+
+	if err := h.usecase.RemoveBranchService(c.Request().Context(), branchID, serviceID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Branch service not found", nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to remove branch service", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Branch service deleted successfully", nil)
+}
+
+// branchServiceIDs parses the :id and :service_id path parameters.
+func branchServiceIDs(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	branchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	serviceID, err := uuid.Parse(c.Param("service_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return branchID, serviceID, nil
+}
@@ -5,6 +5,8 @@ import (
 	"KaungHtetHein116/IVY-backend/api/v1/params"
 	"KaungHtetHein116/IVY-backend/api/v1/request"
 	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
 	"net/http"
 
 	"errors"
@@ -26,7 +28,10 @@ func (h *CategoryHandler) CreateCategory(c echo.Context, req *request.CreateCate
 	category, err := h.usecase.CreateCategory(c.Request().Context(), req)
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			return transport.NewApiErrorResponse(c, http.StatusConflict, "Category already exists", err)
+			return transport.NewApiErrorResponse(c, http.StatusConflict, i18n.CodeCategoryExists, err)
+		}
+		if errors.Is(err, utils.ErrParentCategoryNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
 		}
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create category", err)
 	}
@@ -49,7 +54,7 @@ func (h *CategoryHandler) GetAllCategories(c echo.Context) error {
 	filter := params.NewCategoryQueryParams()
 	err := c.Bind(filter)
 	if err != nil {
-		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
 	}
 
 	categories, pagination, err := h.usecase.GetAllCategories(c.Request().Context(), filter)
@@ -59,6 +64,27 @@ func (h *CategoryHandler) GetAllCategories(c echo.Context) error {
 	return transport.NewApiSuccessResponse(c, http.StatusOK, "Categories retrieved successfully", categories, pagination)
 }
 
+func (h *CategoryHandler) GetCategoryTree(c echo.Context) error {
+	includeHidden := c.QueryParam("include_hidden") == "true"
+
+	tree, err := h.usecase.GetCategoryTree(c.Request().Context(), includeHidden)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get category tree", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Category tree retrieved successfully", tree)
+}
+
+func (h *CategoryHandler) ReorderCategories(c echo.Context, req *request.ReorderCategoriesRequest) error {
+	categories, err := h.usecase.ReorderCategories(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, utils.ErrCategoryNotSibling) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to reorder categories", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, i18n.CodeCategoriesReordered, categories)
+}
+
 func (h *CategoryHandler) UpdateCategory(c echo.Context, req *request.UpdateCategoryRequest) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -69,22 +95,86 @@ func (h *CategoryHandler) UpdateCategory(c echo.Context, req *request.UpdateCate
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Category not found", err)
 		}
+		if errors.Is(err, utils.ErrParentCategoryNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrCategoryCycle) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, i18n.CodeCategoryExists, err)
+		}
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update category", err)
 	}
 	return transport.NewApiSuccessResponse(c, http.StatusOK, "Category updated successfully", category)
 }
 
+func (h *CategoryHandler) GetCategoryDeleteImpact(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err)
+	}
+	impact, err := h.usecase.GetDeleteImpact(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Category not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get delete impact", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Delete impact retrieved successfully", impact)
+}
+
 func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err)
 	}
-	err = h.usecase.DeleteCategory(c.Request().Context(), id)
+	opts := new(params.DeleteQueryParams)
+	if err := c.Bind(opts); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+	impact, err := h.usecase.DeleteCategory(c.Request().Context(), id, opts)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Category not found", err)
 		}
+		if status, ok := deleteSafeguardStatus(err); ok {
+			// A refused delete carries the impact that stands in its way
+			return transport.NewApiErrorResponse(c, status, utils.ErrorCode(err), impact)
+		}
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete category", err)
 	}
 	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Category deleted successfully", nil)
 }
+
+func (h *CategoryHandler) GetDeletedCategories(c echo.Context) error {
+	filter := params.NewTrashQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	categories, pagination, err := h.usecase.GetDeletedCategories(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get deleted categories", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Deleted categories retrieved successfully", categories, pagination)
+}
+
+func (h *CategoryHandler) RestoreCategory(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err)
+	}
+
+	category, err := h.usecase.RestoreCategory(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Deleted category not found", nil)
+		}
+		if errors.Is(err, utils.ErrParentCategoryInTrash) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to restore category", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Category restored successfully", category)
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"KaungHtetHein116/IVY-backend/utils"
+)
+
+// deleteSafeguardStatus is the status for the errors of a delete guarded by
+// a strategy, and false for any other error.
+func deleteSafeguardStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, utils.ErrDeleteBlocked), errors.Is(err, utils.ErrCategoryHasChildren):
+		return http.StatusConflict, true
+	case errors.Is(err, utils.ErrInvalidDeleteStrategy), errors.Is(err, utils.ErrReassignNotAllowed),
+		errors.Is(err, utils.ErrReassignTargetInvalid):
+		return http.StatusBadRequest, true
+	}
+	return 0, false
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type FavoriteHandler struct {
+	usecase usecase.FavoriteUsecase
+}
+
+func NewFavoriteHandler(u usecase.FavoriteUsecase) *FavoriteHandler {
+	return &FavoriteHandler{usecase: u}
+}
+
+func (h *FavoriteHandler) GetMyFavorites(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	favorites, err := h.usecase.GetFavorites(c.Request().Context(), userID)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get favorites", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Favorites retrieved successfully", favorites)
+}
+
+func (h *FavoriteHandler) AddFavoriteService(c echo.Context) error {
+	serviceID, err := uuid.Parse(c.Param("service_id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	userID := c.Get("user_id").(string)
+	if err := h.usecase.AddFavoriteService(c.Request().Context(), userID, serviceID); err != nil {
+		if errors.Is(err, utils.ErrServiceNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to save favorite", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Favorite created successfully", nil)
+}
+
+func (h *FavoriteHandler) RemoveFavoriteService(c echo.Context) error {
+	serviceID, err := uuid.Parse(c.Param("service_id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	userID := c.Get("user_id").(string)
+	if err := h.usecase.RemoveFavoriteService(c.Request().Context(), userID, serviceID); err != nil {
+		return favoriteRemoveError(c, err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Favorite deleted successfully", nil)
+}
+
+func (h *FavoriteHandler) AddFavoriteBranch(c echo.Context) error {
+	branchID, err := uuid.Parse(c.Param("branch_id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	userID := c.Get("user_id").(string)
+	if err := h.usecase.AddFavoriteBranch(c.Request().Context(), userID, branchID); err != nil {
+		if errors.Is(err, utils.ErrBranchNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to save favorite", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Favorite created successfully", nil)
+}
+
+func (h *FavoriteHandler) RemoveFavoriteBranch(c echo.Context) error {
+	branchID, err := uuid.Parse(c.Param("branch_id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	userID := c.Get("user_id").(string)
+	if err := h.usecase.RemoveFavoriteBranch(c.Request().Context(), userID, branchID); err != nil {
+		return favoriteRemoveError(c, err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Favorite deleted successfully", nil)
+}
+
+func favoriteRemoveError(c echo.Context, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, "Favorite not found", nil)
+	}
+	return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to remove favorite", err)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type GiftCardHandler struct {
+	usecase usecase.GiftCardUsecase
+}
+
+func NewGiftCardHandler(u usecase.GiftCardUsecase) *GiftCardHandler {
+	return &GiftCardHandler{usecase: u}
+}
+
+func (h *GiftCardHandler) IssueGiftCard(c echo.Context, req *request.CreateGiftCardRequest) error {
+	giftCard, err := h.usecase.IssueGiftCard(c.Request().Context(), req)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to issue gift card", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, i18n.CodeGiftCardIssued, giftCard)
+}
+
+func (h *GiftCardHandler) GetGiftCardByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid gift card ID", err)
+	}
+
+	giftCard, err := h.usecase.GetGiftCardByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Gift card not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get gift card", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Gift card retrieved successfully", giftCard)
+}
+
+func (h *GiftCardHandler) GetGiftCardByCode(c echo.Context) error {
+	giftCard, err := h.usecase.GetGiftCardByCode(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Gift card not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get gift card", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Gift card retrieved successfully", giftCard)
+}
+
+func (h *GiftCardHandler) GetAllGiftCards(c echo.Context) error {
+	filter := params.NewGiftCardQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	giftCards, pagination, err := h.usecase.GetAllGiftCards(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get gift cards", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Gift cards retrieved successfully", giftCards, pagination)
+}
+
+func (h *GiftCardHandler) UpdateGiftCard(c echo.Context, req *request.UpdateGiftCardRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid gift card ID", err)
+	}
+
+	giftCard, err := h.usecase.UpdateGiftCard(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Gift card not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update gift card", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Gift card updated successfully", giftCard)
+}
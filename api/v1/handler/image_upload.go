@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/config"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// readImageUpload reads the "image" file of a multipart form, refusing files
+// over the upload limit before reading them.
+func readImageUpload(c echo.Context) ([]byte, error) {
+	header, err := c.FormFile("image")
+	if err != nil {
+		return nil, err
+	}
+	maxBytes := config.UploadMaxBytes()
+	if header.Size > maxBytes {
+		return nil, utils.ErrImageTooLarge
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, utils.ErrImageTooLarge
+	}
+	return data, nil
+}
+
+// imageUploadStatus is the status answering a rejected upload, or 0 when
+// err is not about the uploaded file.
+func imageUploadStatus(err error) int {
+	switch {
+	case errors.Is(err, utils.ErrImageTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, utils.ErrUnsupportedImageType):
+		return http.StatusUnsupportedMediaType
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+func imageUploadContext(t *testing.T, size int) echo.Context {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("image", "photo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(bytes.Repeat([]byte{0}, size))
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", &body)
+	req.Header.Set(echo.HeaderContentType, form.FormDataContentType())
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestReadImageUpload(t *testing.T) {
+	t.Setenv("UPLOAD_MAX_BYTES", "1024")
+
+	data, err := readImageUpload(imageUploadContext(t, 1024))
+	if err != nil || len(data) != 1024 {
+		t.Errorf("read %d bytes with error %v, want the whole file", len(data), err)
+	}
+	if _, err := readImageUpload(imageUploadContext(t, 1025)); !errors.Is(err, utils.ErrImageTooLarge) {
+		t.Errorf("error = %v, want %v", err, utils.ErrImageTooLarge)
+	}
+}
+
+func TestImageUploadStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{utils.ErrImageTooLarge, http.StatusRequestEntityTooLarge},
+		{utils.ErrUnsupportedImageType, http.StatusUnsupportedMediaType},
+		{errors.New("disk full"), 0},
+	}
+	for _, tt := range tests {
+		if got := imageUploadStatus(tt.err); got != tt.want {
+			t.Errorf("imageUploadStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
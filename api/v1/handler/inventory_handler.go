@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type InventoryHandler struct {
+	usecase usecase.InventoryUsecase
+}
+
+func NewInventoryHandler(u usecase.InventoryUsecase) *InventoryHandler {
+	return &InventoryHandler{usecase: u}
+}
+
+func (h *InventoryHandler) CreateProduct(c echo.Context, req *request.CreateProductRequest) error {
+	product, err := h.usecase.CreateProduct(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, i18n.CodeProductSKUExists, nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create product", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Product created successfully", product)
+}
+
+func (h *InventoryHandler) GetProductByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid product ID", err)
+	}
+
+	product, err := h.usecase.GetProductByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Product not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get product", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Product retrieved successfully", product)
+}
+
+func (h *InventoryHandler) GetAllProducts(c echo.Context) error {
+	filter := params.NewProductQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	products, pagination, err := h.usecase.GetAllProducts(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get products", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Products retrieved successfully", products, pagination)
+}
+
+func (h *InventoryHandler) UpdateProduct(c echo.Context, req *request.UpdateProductRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid product ID", err)
+	}
+
+	product, err := h.usecase.UpdateProduct(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Product not found", err)
+		}
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, i18n.CodeProductSKUExists, nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update product", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Product updated successfully", product)
+}
+
+func (h *InventoryHandler) DeleteProduct(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid product ID", err)
+	}
+
+	err = h.usecase.DeleteProduct(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Product not found", err)
+		}
+		if errors.Is(err, utils.ErrProductInUse) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete product", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Product deleted successfully", nil)
+}
+
+func (h *InventoryHandler) GetBranchStock(c echo.Context) error {
+	branchID, err := uuid.Parse(c.Param("branch_id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	stock, err := h.usecase.GetBranchStock(c.Request().Context(), branchID)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get stock", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Stock retrieved successfully", stock)
+}
+
+func (h *InventoryHandler) SetLowStockLevel(c echo.Context, req *request.SetLowStockLevelRequest) error {
+	branchID, err := uuid.Parse(c.Param("branch_id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+	productID, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid product ID", err)
+	}
+
+	stock, err := h.usecase.SetLowStockLevel(c.Request().Context(), branchID, productID, req)
+	if err != nil {
+		if errors.Is(err, utils.ErrBranchNotFound) || errors.Is(err, utils.ErrProductNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update stock", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Stock updated successfully", stock)
+}
+
+func (h *InventoryHandler) GetLowStockReport(c echo.Context) error {
+	var branchID *uuid.UUID
+	if value := c.QueryParam("branch_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+		}
+		branchID = &id
+	}
+
+	reports, err := h.usecase.GetLowStockReport(c.Request().Context(), branchID)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get low-stock report", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Low-stock report retrieved successfully", reports)
+}
+
+func (h *InventoryHandler) RecordMovement(c echo.Context, req *request.RecordStockMovementRequest) error {
+	adminID := c.Get("user_id").(string)
+
+	movement, err := h.usecase.RecordMovement(c.Request().Context(), adminID, req)
+	if err != nil {
+		if errors.Is(err, utils.ErrBranchNotFound) || errors.Is(err, utils.ErrProductNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrInvalidStockChange) || errors.Is(err, utils.ErrInsufficientStock) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to record stock movement", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Stock movement created successfully", movement)
+}
+
+func (h *InventoryHandler) GetMovements(c echo.Context) error {
+	filter := params.NewStockMovementQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	movements, pagination, err := h.usecase.GetMovements(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get stock movements", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Stock movements retrieved successfully", movements, pagination)
+}
+
+func (h *InventoryHandler) GetServiceConsumables(c echo.Context) error {
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	consumables, err := h.usecase.GetServiceConsumables(c.Request().Context(), serviceID)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get consumables", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Consumables retrieved successfully", consumables)
+}
+
+func (h *InventoryHandler) SetServiceConsumables(c echo.Context, req *request.SetServiceConsumablesRequest) error {
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	consumables, err := h.usecase.SetServiceConsumables(c.Request().Context(), serviceID, req)
+	if err != nil {
+		if errors.Is(err, utils.ErrServiceNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrProductNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update consumables", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Consumables updated successfully", consumables)
+}
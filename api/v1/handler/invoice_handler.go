@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type InvoiceHandler struct {
+	usecase usecase.InvoiceUsecase
+}
+
+func NewInvoiceHandler(u usecase.InvoiceUsecase) *InvoiceHandler {
+	return &InvoiceHandler{usecase: u}
+}
+
+func (h *InvoiceHandler) GetInvoice(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid booking ID", err)
+	}
+
+	invoice, err := h.usecase.GetInvoice(c.Request().Context(), id)
+	if err != nil {
+		return invoiceErrorResponse(c, err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Invoice retrieved successfully", invoice)
+}
+
+func (h *InvoiceHandler) GetInvoicePDF(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid booking ID", err)
+	}
+
+	invoice, err := h.usecase.GetInvoice(c.Request().Context(), id)
+	if err != nil {
+		return invoiceErrorResponse(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", invoice.Number+".pdf"))
+	return c.Blob(http.StatusOK, "application/pdf", h.usecase.RenderPDF(invoice))
+}
+
+func invoiceErrorResponse(c echo.Context, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, "Invoice not found", err)
+	}
+	return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get invoice", err)
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LedgerHandler struct {
+	usecase usecase.LedgerUsecase
+}
+
+func NewLedgerHandler(u usecase.LedgerUsecase) *LedgerHandler {
+	return &LedgerHandler{usecase: u}
+}
+
+func (h *LedgerHandler) GetLedgerEntries(c echo.Context) error {
+	filter := params.NewLedgerQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	entries, pagination, err := h.usecase.GetLedgerEntries(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get ledger entries", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Ledger entries retrieved successfully", entries, pagination)
+}
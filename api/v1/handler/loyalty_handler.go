@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type LoyaltyHandler struct {
+	usecase usecase.LoyaltyUsecase
+}
+
+func NewLoyaltyHandler(u usecase.LoyaltyUsecase) *LoyaltyHandler {
+	return &LoyaltyHandler{usecase: u}
+}
+
+func (h *LoyaltyHandler) GetMyBalance(c echo.Context) error {
+	return h.getBalance(c, c.Get("user_id").(string))
+}
+
+func (h *LoyaltyHandler) GetUserBalance(c echo.Context) error {
+	return h.getBalance(c, c.Param("id"))
+}
+
+func (h *LoyaltyHandler) getBalance(c echo.Context, userID string) error {
+	balance, err := h.usecase.GetBalance(c.Request().Context(), userID)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get points balance", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Points balance retrieved successfully", balance)
+}
+
+func (h *LoyaltyHandler) GetMyHistory(c echo.Context) error {
+	return h.getHistory(c, c.Get("user_id").(string))
+}
+
+func (h *LoyaltyHandler) GetUserHistory(c echo.Context) error {
+	return h.getHistory(c, c.Param("id"))
+}
+
+func (h *LoyaltyHandler) getHistory(c echo.Context, userID string) error {
+	filter := params.NewPointsQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+	filter.UserID = userID
+
+	entries, pagination, err := h.usecase.GetHistory(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get points history", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Points history retrieved successfully", entries, pagination)
+}
+
+func (h *LoyaltyHandler) AdjustPoints(c echo.Context, req *request.AdjustPointsRequest) error {
+	adminID := c.Get("user_id").(string)
+
+	entry, err := h.usecase.AdjustPoints(c.Request().Context(), c.Param("id"), adminID, req)
+	if err != nil {
+		if errors.Is(err, utils.ErrUserNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrInvalidPoints) || errors.Is(err, utils.ErrInsufficientPoints) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to adjust points", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, i18n.CodePointsAdjusted, entry)
+}
+
+func (h *LoyaltyHandler) CreateRule(c echo.Context, req *request.CreateLoyaltyRuleRequest) error {
+	rule, err := h.usecase.CreateRule(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, utils.ErrCategoryNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, i18n.CodeLoyaltyRuleExists, nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create loyalty rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Loyalty rule created successfully", rule)
+}
+
+func (h *LoyaltyHandler) GetRules(c echo.Context) error {
+	rules, err := h.usecase.GetRules(c.Request().Context())
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get loyalty rules", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Loyalty rules retrieved successfully", rules)
+}
+
+func (h *LoyaltyHandler) UpdateRule(c echo.Context, req *request.UpdateLoyaltyRuleRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid loyalty rule ID", err)
+	}
+
+	rule, err := h.usecase.UpdateRule(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Loyalty rule not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update loyalty rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Loyalty rule updated successfully", rule)
+}
+
+func (h *LoyaltyHandler) DeleteRule(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid loyalty rule ID", err)
+	}
+
+	err = h.usecase.DeleteRule(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Loyalty rule not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete loyalty rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Loyalty rule deleted successfully", nil)
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type MembershipHandler struct {
+	usecase usecase.MembershipUsecase
+}
+
+func NewMembershipHandler(u usecase.MembershipUsecase) *MembershipHandler {
+	return &MembershipHandler{usecase: u}
+}
+
+func (h *MembershipHandler) CreatePlan(c echo.Context, req *request.CreateMembershipPlanRequest) error {
+	plan, err := h.usecase.CreatePlan(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, utils.ErrServiceNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create membership plan", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Membership plan created successfully", plan)
+}
+
+func (h *MembershipHandler) GetPlanByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid membership plan ID", err)
+	}
+
+	plan, err := h.usecase.GetPlanByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Membership plan not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get membership plan", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Membership plan retrieved successfully", plan)
+}
+
+func (h *MembershipHandler) GetAllPlans(c echo.Context) error {
+	filter := params.NewMembershipPlanQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	plans, pagination, err := h.usecase.GetAllPlans(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get membership plans", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Membership plans retrieved successfully", plans, pagination)
+}
+
+func (h *MembershipHandler) UpdatePlan(c echo.Context, req *request.UpdateMembershipPlanRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid membership plan ID", err)
+	}
+
+	plan, err := h.usecase.UpdatePlan(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Membership plan not found", err)
+		}
+		if errors.Is(err, utils.ErrServiceNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update membership plan", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Membership plan updated successfully", plan)
+}
+
+func (h *MembershipHandler) DeletePlan(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid membership plan ID", err)
+	}
+
+	err = h.usecase.DeletePlan(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Membership plan not found", err)
+		}
+		if errors.Is(err, utils.ErrMembershipPlanInUse) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete membership plan", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Membership plan deleted successfully", nil)
+}
+
+func (h *MembershipHandler) Subscribe(c echo.Context) error {
+	planID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid membership plan ID", err)
+	}
+	userID := c.Get("user_id").(string)
+
+	subscription, err := h.usecase.Subscribe(c.Request().Context(), userID, planID)
+	if err != nil {
+		if errors.Is(err, utils.ErrMembershipPlanNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrUserNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrAlreadySubscribed) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrPaymentDeclined) {
+			return transport.NewApiErrorResponse(c, http.StatusPaymentRequired, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to subscribe", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, i18n.CodeSubscribed, subscription)
+}
+
+func (h *MembershipHandler) GetMySubscription(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	subscription, err := h.usecase.GetUserSubscription(c.Request().Context(), userID)
+	if err != nil {
+		if errors.Is(err, utils.ErrSubscriptionNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get subscription", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Subscription retrieved successfully", subscription)
+}
+
+func (h *MembershipHandler) CancelMySubscription(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	subscription, err := h.usecase.CancelSubscription(c.Request().Context(), userID)
+	if err != nil {
+		if errors.Is(err, utils.ErrSubscriptionNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to cancel subscription", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, i18n.CodeSubscriptionCancelled, subscription)
+}
+
+func (h *MembershipHandler) GetAllSubscriptions(c echo.Context) error {
+	filter := params.NewSubscriptionQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	subscriptions, pagination, err := h.usecase.GetAllSubscriptions(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get subscriptions", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Subscriptions retrieved successfully", subscriptions, pagination)
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/pkg/locale"
+)
+
+// TestResponseMessages checks that every message a handler answers with
+// written out in English has a code and a Myanmar rendering.
+func TestResponseMessages(t *testing.T) {
+	files, err := filepath.Glob("*_handler.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			arg := -1
+			switch sel.Sel.Name {
+			case "NewApiErrorResponse", "NewApiSuccessResponse":
+				arg = 2
+			case "NewApiCreateSuccessResponse":
+				arg = 1
+			}
+			if arg < 0 || len(call.Args) <= arg {
+				return true
+			}
+			lit, ok := call.Args[arg].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			message, _ := strconv.Unquote(lit.Value)
+			code, text := i18n.Localize(locale.Myanmar, message)
+			if code == "" || text == message {
+				t.Errorf("%s: %q has no code or no Myanmar rendering", fset.Position(lit.Pos()), message)
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type NotificationHandler struct {
+	usecase usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(u usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{usecase: u}
+}
+
+func (h *NotificationHandler) GetMyNotifications(c echo.Context) error {
+	filter := params.NewNotificationQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	userID := c.Get("user_id").(string)
+	notifications, pagination, err := h.usecase.GetUserNotifications(c.Request().Context(), userID, filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get notifications", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Notifications retrieved successfully", notifications, pagination)
+}
+
+func (h *NotificationHandler) MarkRead(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid notification ID", err)
+	}
+
+	userID := c.Get("user_id").(string)
+	if err := h.usecase.MarkRead(c.Request().Context(), id, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Notification not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update notification", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Notification updated successfully", nil)
+}
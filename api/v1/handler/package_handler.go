@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type PackageHandler struct {
+	usecase usecase.PackageUsecase
+}
+
+func NewPackageHandler(u usecase.PackageUsecase) *PackageHandler {
+	return &PackageHandler{usecase: u}
+}
+
+func (h *PackageHandler) CreatePackage(c echo.Context, req *request.CreatePackageRequest) error {
+	pkg, err := h.usecase.CreatePackage(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, utils.ErrServiceNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create package", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Package created successfully", pkg)
+}
+
+func (h *PackageHandler) GetPackageByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid package ID", err)
+	}
+
+	pkg, err := h.usecase.GetPackageByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Package not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get package", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Package retrieved successfully", pkg)
+}
+
+func (h *PackageHandler) GetAllPackages(c echo.Context) error {
+	filter := params.NewPackageQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	packages, pagination, err := h.usecase.GetAllPackages(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get packages", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Packages retrieved successfully", packages, pagination)
+}
+
+func (h *PackageHandler) UpdatePackage(c echo.Context, req *request.UpdatePackageRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid package ID", err)
+	}
+
+	pkg, err := h.usecase.UpdatePackage(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Package not found", err)
+		}
+		if errors.Is(err, utils.ErrServiceNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update package", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Package updated successfully", pkg)
+}
+
+func (h *PackageHandler) DeletePackage(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid package ID", err)
+	}
+
+	err = h.usecase.DeletePackage(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Package not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete package", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Package deleted successfully", nil)
+}
+
+func (h *PackageHandler) SellPackage(c echo.Context, req *request.SellPackageRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid package ID", err)
+	}
+
+	customerPackage, err := h.usecase.SellPackage(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, utils.ErrPackageNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrUserNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to sell package", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, i18n.CodePackageSold, customerPackage)
+}
+
+func (h *PackageHandler) GetUserPackages(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	customerPackages, err := h.usecase.GetUserPackages(c.Request().Context(), userID)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get user packages", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "User packages retrieved successfully", customerPackages)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type PricingRuleHandler struct {
+	usecase usecase.PricingRuleUsecase
+}
+
+func NewPricingRuleHandler(u usecase.PricingRuleUsecase) *PricingRuleHandler {
+	return &PricingRuleHandler{usecase: u}
+}
+
+func (h *PricingRuleHandler) CreatePricingRule(c echo.Context, req *request.CreatePricingRuleRequest) error {
+	rule, err := h.usecase.CreatePricingRule(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, utils.ErrServiceNotFound) || errors.Is(err, utils.ErrBranchNotFound) || errors.Is(err, utils.ErrCategoryNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrPricingRuleInvalid) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create pricing rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Pricing rule created successfully", rule)
+}
+
+func (h *PricingRuleHandler) GetPricingRuleByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid pricing rule ID", err)
+	}
+
+	rule, err := h.usecase.GetPricingRuleByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Pricing rule not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get pricing rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Pricing rule retrieved successfully", rule)
+}
+
+func (h *PricingRuleHandler) GetAllPricingRules(c echo.Context) error {
+	filter := params.NewPricingRuleQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	rules, pagination, err := h.usecase.GetAllPricingRules(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get pricing rules", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Pricing rules retrieved successfully", rules, pagination)
+}
+
+func (h *PricingRuleHandler) UpdatePricingRule(c echo.Context, req *request.UpdatePricingRuleRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid pricing rule ID", err)
+	}
+
+	rule, err := h.usecase.UpdatePricingRule(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Pricing rule not found", err)
+		}
+		if errors.Is(err, utils.ErrPricingRuleInvalid) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update pricing rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Pricing rule updated successfully", rule)
+}
+
+func (h *PricingRuleHandler) DeletePricingRule(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid pricing rule ID", err)
+	}
+
+	err = h.usecase.DeletePricingRule(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Pricing rule not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete pricing rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Pricing rule deleted successfully", nil)
+}
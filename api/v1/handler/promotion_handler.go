@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type PromotionHandler struct {
+	usecase usecase.PromotionUsecase
+}
+
+func NewPromotionHandler(u usecase.PromotionUsecase) *PromotionHandler {
+	return &PromotionHandler{usecase: u}
+}
+
+func (h *PromotionHandler) CreatePromotion(c echo.Context, req *request.CreatePromotionRequest) error {
+	promotion, err := h.usecase.CreatePromotion(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, i18n.CodePromotionCodeExists, err)
+		}
+		if errors.Is(err, utils.ErrPromotionInvalid) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrServiceNotFound) || errors.Is(err, utils.ErrCategoryNotFound) || errors.Is(err, utils.ErrBranchNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create promotion", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Promotion created successfully", promotion)
+}
+
+func (h *PromotionHandler) GetPromotionByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid promotion ID", err)
+	}
+
+	promotion, err := h.usecase.GetPromotionByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Promotion not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get promotion", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Promotion retrieved successfully", promotion)
+}
+
+func (h *PromotionHandler) GetAllPromotions(c echo.Context) error {
+	filter := params.NewPromotionQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	promotions, pagination, err := h.usecase.GetAllPromotions(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get promotions", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Promotions retrieved successfully", promotions, pagination)
+}
+
+func (h *PromotionHandler) UpdatePromotion(c echo.Context, req *request.UpdatePromotionRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid promotion ID", err)
+	}
+
+	promotion, err := h.usecase.UpdatePromotion(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Promotion not found", err)
+		}
+		if errors.Is(err, utils.ErrPromotionInvalid) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrServiceNotFound) || errors.Is(err, utils.ErrCategoryNotFound) || errors.Is(err, utils.ErrBranchNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update promotion", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Promotion updated successfully", promotion)
+}
+
+func (h *PromotionHandler) DeletePromotion(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid promotion ID", err)
+	}
+
+	err = h.usecase.DeletePromotion(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Promotion not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete promotion", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Promotion deleted successfully", nil)
+}
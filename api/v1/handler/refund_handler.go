@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type RefundHandler struct {
+	usecase usecase.RefundUsecase
+}
+
+func NewRefundHandler(u usecase.RefundUsecase) *RefundHandler {
+	return &RefundHandler{usecase: u}
+}
+
+func (h *RefundHandler) GetAllRefunds(c echo.Context) error {
+	filter := params.NewRefundQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	refunds, pagination, err := h.usecase.GetAllRefunds(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get refunds", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Refunds retrieved successfully", refunds, pagination)
+}
+
+func (h *RefundHandler) GetRefundByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid refund ID", err)
+	}
+
+	refund, err := h.usecase.GetRefundByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, utils.ErrRefundNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get refund", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Refund retrieved successfully", refund)
+}
+
+func (h *RefundHandler) ApproveRefund(c echo.Context, req *request.ApproveRefundRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid refund ID", err)
+	}
+	reviewerID := c.Get("user_id").(string)
+
+	refund, err := h.usecase.ApproveRefund(c.Request().Context(), id, reviewerID, req)
+	if err != nil {
+		return refundErrorResponse(c, err, "Failed to approve refund")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, i18n.CodeRefundIssued, refund)
+}
+
+func (h *RefundHandler) RejectRefund(c echo.Context, req *request.RejectRefundRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid refund ID", err)
+	}
+	reviewerID := c.Get("user_id").(string)
+
+	refund, err := h.usecase.RejectRefund(c.Request().Context(), id, reviewerID, req)
+	if err != nil {
+		return refundErrorResponse(c, err, "Failed to reject refund")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, i18n.CodeRefundRejected, refund)
+}
+
+func refundErrorResponse(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, utils.ErrRefundNotFound):
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	case errors.Is(err, utils.ErrRefundProcessed):
+		return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+	case errors.Is(err, utils.ErrInvalidRefundAmount):
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+	case errors.Is(err, utils.ErrRefundFailed):
+		return transport.NewApiErrorResponse(c, http.StatusBadGateway, utils.ErrorCode(err), err.Error())
+	default:
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
+
+func (h *RefundHandler) CreateRule(c echo.Context, req *request.CreateRefundRuleRequest) error {
+	rule, err := h.usecase.CreateRule(c.Request().Context(), req)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create refund rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Refund rule created successfully", rule)
+}
+
+func (h *RefundHandler) GetRules(c echo.Context) error {
+	rules, err := h.usecase.GetRules(c.Request().Context())
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get refund rules", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Refund rules retrieved successfully", rules)
+}
+
+func (h *RefundHandler) UpdateRule(c echo.Context, req *request.UpdateRefundRuleRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid refund rule ID", err)
+	}
+
+	rule, err := h.usecase.UpdateRule(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Refund rule not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update refund rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Refund rule updated successfully", rule)
+}
+
+func (h *RefundHandler) DeleteRule(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid refund rule ID", err)
+	}
+
+	err = h.usecase.DeleteRule(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Refund rule not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete refund rule", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Refund rule deleted successfully", nil)
+}
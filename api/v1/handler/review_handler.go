@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type ReviewHandler struct {
+	usecase usecase.ReviewUsecase
+}
+
+func NewReviewHandler(u usecase.ReviewUsecase) *ReviewHandler {
+	return &ReviewHandler{usecase: u}
+}
+
+func (h *ReviewHandler) CreateReview(c echo.Context, req *request.CreateReviewRequest) error {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid booking ID", err)
+	}
+
+	userID := c.Get("user_id").(string)
+	review, err := h.usecase.CreateReview(c.Request().Context(), userID, bookingID, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Booking not found", err)
+		}
+		if errors.Is(err, utils.ErrNotBranchStaff) {
+			return transport.NewApiErrorResponse(c, http.StatusUnprocessableEntity, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrReviewExists) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrBookingNotCompleted) || errors.Is(err, utils.ErrStaffRatingInvalid) {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create review", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Review created successfully", review)
+}
+
+func (h *ReviewHandler) GetReviewByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid review ID", err)
+	}
+
+	review, err := h.usecase.GetReviewByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Review not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get review", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Review retrieved successfully", review)
+}
+
+func (h *ReviewHandler) GetAllReviews(c echo.Context) error {
+	filter := params.NewReviewQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	reviews, pagination, err := h.usecase.GetAllReviews(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get reviews", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Reviews retrieved successfully", reviews, pagination)
+}
+
+func (h *ReviewHandler) GetServiceReviews(c echo.Context) error {
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+	filter := params.NewReviewQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	reviews, pagination, err := h.usecase.GetServiceReviews(c.Request().Context(), serviceID, filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get reviews", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Reviews retrieved successfully", reviews, pagination)
+}
+
+func (h *ReviewHandler) GetMyReviews(c echo.Context) error {
+	filter := params.NewReviewQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	userID := c.Get("user_id").(string)
+	reviews, pagination, err := h.usecase.GetUserReviews(c.Request().Context(), userID, filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get reviews", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Reviews retrieved successfully", reviews, pagination)
+}
+
+func (h *ReviewHandler) ApproveReview(c echo.Context, req *request.ModerateReviewRequest) error {
+	return h.moderate(c, req, h.usecase.ApproveReview)
+}
+
+func (h *ReviewHandler) RejectReview(c echo.Context, req *request.ModerateReviewRequest) error {
+	return h.moderate(c, req, h.usecase.RejectReview)
+}
+
+func (h *ReviewHandler) moderate(c echo.Context, req *request.ModerateReviewRequest,
+	decide func(ctx context.Context, id uuid.UUID, adminID string, req *request.ModerateReviewRequest) (*entity.Review, error)) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid review ID", err)
+	}
+
+	adminID := c.Get("user_id").(string)
+	review, err := decide(c.Request().Context(), id, adminID, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Review not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update review", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Review updated successfully", review)
+}
+
+func (h *ReviewHandler) DeleteReview(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid review ID", err)
+	}
+
+	if err := h.usecase.DeleteReview(c.Request().Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Review not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete review", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Review deleted successfully", nil)
+}
@@ -5,9 +5,11 @@ import (
 	"KaungHtetHein116/IVY-backend/api/v1/params"
 	"KaungHtetHein116/IVY-backend/api/v1/request"
 	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
 	"KaungHtetHein116/IVY-backend/utils"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -26,7 +28,7 @@ func (h *ServiceHandler) CreateService(c echo.Context, req *request.CreateServic
 	service, err := h.usecase.CreateService(c.Request().Context(), req)
 	if err != nil {
 		if errors.Is(err, utils.ErrBranchNotFound) || errors.Is(err, utils.ErrCategoryNotFound) {
-			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Category or Branch not found", nil)
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
 		}
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create service", err)
 	}
@@ -39,7 +41,16 @@ func (h *ServiceHandler) GetServiceByID(c echo.Context) error {
 	if err != nil {
 		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
 	}
-	service, err := h.usecase.GetServiceByID(c.Request().Context(), id)
+
+	branchID := uuid.Nil
+	if branchIDParam := c.QueryParam("branch_id"); branchIDParam != "" {
+		branchID, err = uuid.Parse(branchIDParam)
+		if err != nil {
+			return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+		}
+	}
+
+	service, err := h.usecase.GetServiceByID(c.Request().Context(), id, branchID)
 	if err != nil {
 		return transport.NewApiErrorResponse(c, http.StatusNotFound, "Service not found", err)
 	}
@@ -52,7 +63,7 @@ func (h *ServiceHandler) GetAllServices(c echo.Context) error {
 	err := c.Bind(filter)
 
 	if err != nil {
-		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
 	}
 
 	services, pagination, err := h.usecase.GetAllServices(c.Request().Context(), filter)
@@ -63,6 +74,32 @@ func (h *ServiceHandler) GetAllServices(c echo.Context) error {
 	return transport.NewApiSuccessResponse(c, http.StatusOK, "Services retrieved successfully", services, pagination)
 }
 
+// SearchServices answers GET /service/search?q=. With mode=autocomplete it
+// returns suggestions only.
+func (h *ServiceHandler) SearchServices(c echo.Context) error {
+	filter := params.NewServiceSearchQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+	if strings.TrimSpace(filter.Q) == "" {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeSearchTextRequired, nil)
+	}
+
+	if filter.Mode == "autocomplete" {
+		suggestions, err := h.usecase.SuggestServices(c.Request().Context(), filter)
+		if err != nil {
+			return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to search services", err)
+		}
+		return transport.NewApiSuccessResponse(c, http.StatusOK, "Suggestions retrieved successfully", suggestions)
+	}
+
+	services, pagination, err := h.usecase.SearchServices(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to search services", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Services retrieved successfully", services, pagination)
+}
+
 func (h *ServiceHandler) UpdateService(c echo.Context, req *request.UpdateServiceRequest) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -76,24 +113,122 @@ func (h *ServiceHandler) UpdateService(c echo.Context, req *request.UpdateServic
 			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Service not found", err)
 		}
 		if errors.Is(err, utils.ErrBranchNotFound) || errors.Is(err, utils.ErrCategoryNotFound) {
-			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Category or Branch not found", nil)
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
 		}
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update service", err)
 	}
 	return transport.NewApiSuccessResponse(c, http.StatusOK, "Service updated successfully", service)
 }
 
+func (h *ServiceHandler) GetServiceDeleteImpact(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+	impact, err := h.usecase.GetDeleteImpact(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Service not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get delete impact", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Delete impact retrieved successfully", impact)
+}
+
 func (h *ServiceHandler) DeleteService(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
 	}
-	err = h.usecase.DeleteService(c.Request().Context(), id)
+	opts := new(params.DeleteQueryParams)
+	if err := c.Bind(opts); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+	impact, err := h.usecase.DeleteService(c.Request().Context(), id, opts)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Service not found", err)
 		}
+		if status, ok := deleteSafeguardStatus(err); ok {
+			// A refused delete carries the impact that stands in its way
+			return transport.NewApiErrorResponse(c, status, utils.ErrorCode(err), impact)
+		}
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete service", err)
 	}
 	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Service deleted successfully", nil)
 }
+
+func (h *ServiceHandler) GetDeletedServices(c echo.Context) error {
+	filter := params.NewTrashQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	services, pagination, err := h.usecase.GetDeletedServices(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get deleted services", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Deleted services retrieved successfully", services, pagination)
+}
+
+func (h *ServiceHandler) RestoreService(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	service, err := h.usecase.RestoreService(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Deleted service not found", nil)
+		}
+		if errors.Is(err, utils.ErrCategoryInTrash) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to restore service", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Service restored successfully", service)
+}
+
+func (h *ServiceHandler) GetServiceVersions(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	versions, err := h.usecase.GetServiceVersions(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Service not found", nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get service versions", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Service versions retrieved successfully", versions)
+}
+
+func (h *ServiceHandler) UploadServiceImage(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	data, err := readImageUpload(c)
+	if err != nil {
+		if status := imageUploadStatus(err); status != 0 {
+			return transport.NewApiErrorResponse(c, status, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeImageRequired, err)
+	}
+
+	service, err := h.usecase.UploadServiceImage(c.Request().Context(), id, data)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Service not found", err)
+		}
+		if status := imageUploadStatus(err); status != 0 {
+			return transport.NewApiErrorResponse(c, status, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to upload service image", err)
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Service image uploaded successfully", service)
+}
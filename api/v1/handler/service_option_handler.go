@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ServiceOptionHandler struct {
+	usecase usecase.ServiceOptionUsecase
+}
+
+func NewServiceOptionHandler(u usecase.ServiceOptionUsecase) *ServiceOptionHandler {
+	return &ServiceOptionHandler{usecase: u}
+}
+
+func (h *ServiceOptionHandler) CreateVariant(c echo.Context, req *request.CreateServiceVariantRequest) error {
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	variant, err := h.usecase.CreateVariant(c.Request().Context(), serviceID, req)
+	if err != nil {
+		return serviceOptionErrorResponse(c, err, "Failed to create variant")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Variant created successfully", variant)
+}
+
+func (h *ServiceOptionHandler) GetVariants(c echo.Context) error {
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	variants, err := h.usecase.GetVariants(c.Request().Context(), serviceID)
+	if err != nil {
+		return serviceOptionErrorResponse(c, err, "Failed to get variants")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Variants retrieved successfully", variants)
+}
+
+func (h *ServiceOptionHandler) UpdateVariant(c echo.Context, req *request.UpdateServiceVariantRequest) error {
+	serviceID, id, err := serviceOptionIDs(c, "variant_id")
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service or variant ID", err)
+	}
+
+	variant, err := h.usecase.UpdateVariant(c.Request().Context(), serviceID, id, req)
+	if err != nil {
+		return serviceOptionErrorResponse(c, err, "Failed to update variant")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Variant updated successfully", variant)
+}
+
+func (h *ServiceOptionHandler) DeleteVariant(c echo.Context) error {
+	serviceID, id, err := serviceOptionIDs(c, "variant_id")
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service or variant ID", err)
+	}
+
+	if err := h.usecase.DeleteVariant(c.Request().Context(), serviceID, id); err != nil {
+		return serviceOptionErrorResponse(c, err, "Failed to delete variant")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Variant deleted successfully", nil)
+}
+
+func (h *ServiceOptionHandler) CreateAddOn(c echo.Context, req *request.CreateServiceAddOnRequest) error {
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	addOn, err := h.usecase.CreateAddOn(c.Request().Context(), serviceID, req)
+	if err != nil {
+		return serviceOptionErrorResponse(c, err, "Failed to create add-on")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Add-on created successfully", addOn)
+}
+
+func (h *ServiceOptionHandler) GetAddOns(c echo.Context) error {
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	addOns, err := h.usecase.GetAddOns(c.Request().Context(), serviceID)
+	if err != nil {
+		return serviceOptionErrorResponse(c, err, "Failed to get add-ons")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Add-ons retrieved successfully", addOns)
+}
+
+func (h *ServiceOptionHandler) UpdateAddOn(c echo.Context, req *request.UpdateServiceAddOnRequest) error {
+	serviceID, id, err := serviceOptionIDs(c, "add_on_id")
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service or add-on ID", err)
+	}
+
+	addOn, err := h.usecase.UpdateAddOn(c.Request().Context(), serviceID, id, req)
+	if err != nil {
+		return serviceOptionErrorResponse(c, err, "Failed to update add-on")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Add-on updated successfully", addOn)
+}
+
+func (h *ServiceOptionHandler) DeleteAddOn(c echo.Context) error {
+	serviceID, id, err := serviceOptionIDs(c, "add_on_id")
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service or add-on ID", err)
+	}
+
+	if err := h.usecase.DeleteAddOn(c.Request().Context(), serviceID, id); err != nil {
+		return serviceOptionErrorResponse(c, err, "Failed to delete add-on")
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Add-on deleted successfully", nil)
+}
+
+// serviceOptionIDs parses the service ID and the ID of the variant or add-on
+// under it from the path.
+func serviceOptionIDs(c echo.Context, param string) (uuid.UUID, uuid.UUID, error) {
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return serviceID, id, nil
+}
+
+func serviceOptionErrorResponse(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, utils.ErrServiceNotFound):
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	case errors.Is(err, utils.ErrVariantNotFound), errors.Is(err, utils.ErrAddOnNotFound):
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	default:
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
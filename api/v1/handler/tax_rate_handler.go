@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type TaxRateHandler struct {
+	usecase usecase.TaxRateUsecase
+}
+
+func NewTaxRateHandler(u usecase.TaxRateUsecase) *TaxRateHandler {
+	return &TaxRateHandler{usecase: u}
+}
+
+func (h *TaxRateHandler) CreateTaxRate(c echo.Context, req *request.CreateTaxRateRequest) error {
+	taxRate, err := h.usecase.CreateTaxRate(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, utils.ErrBranchNotFound) || errors.Is(err, utils.ErrCategoryNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+		}
+		if errors.Is(err, utils.ErrTaxRateConflict) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to create tax rate", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusCreated, "Tax rate created successfully", taxRate)
+}
+
+func (h *TaxRateHandler) GetTaxRateByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid tax rate ID", err)
+	}
+
+	taxRate, err := h.usecase.GetTaxRateByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Tax rate not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get tax rate", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Tax rate retrieved successfully", taxRate)
+}
+
+func (h *TaxRateHandler) GetAllTaxRates(c echo.Context) error {
+	filter := params.NewTaxRateQueryParams()
+	if err := c.Bind(filter); err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
+	}
+
+	taxRates, pagination, err := h.usecase.GetAllTaxRates(c.Request().Context(), filter)
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to get tax rates", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Tax rates retrieved successfully", taxRates, pagination)
+}
+
+func (h *TaxRateHandler) UpdateTaxRate(c echo.Context, req *request.UpdateTaxRateRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid tax rate ID", err)
+	}
+
+	taxRate, err := h.usecase.UpdateTaxRate(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Tax rate not found", err)
+		}
+		if errors.Is(err, utils.ErrTaxRateConflict) {
+			return transport.NewApiErrorResponse(c, http.StatusConflict, utils.ErrorCode(err), nil)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to update tax rate", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Tax rate updated successfully", taxRate)
+}
+
+func (h *TaxRateHandler) DeleteTaxRate(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid tax rate ID", err)
+	}
+
+	err = h.usecase.DeleteTaxRate(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transport.NewApiErrorResponse(c, http.StatusNotFound, "Tax rate not found", err)
+		}
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to delete tax rate", err)
+	}
+
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Tax rate deleted successfully", nil)
+}
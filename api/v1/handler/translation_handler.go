@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/utils"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type TranslationHandler struct {
+	usecase usecase.TranslationUsecase
+}
+
+func NewTranslationHandler(u usecase.TranslationUsecase) *TranslationHandler {
+	return &TranslationHandler{usecase: u}
+}
+
+func (h *TranslationHandler) GetServiceTranslations(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	translations, err := h.usecase.GetServiceTranslations(c.Request().Context(), id)
+	if err != nil {
+		return translationErrorResponse(c, err, "Failed to get service translations")
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Service translations retrieved successfully", translations)
+}
+
+func (h *TranslationHandler) SaveServiceTranslation(c echo.Context, req *request.ServiceTranslationRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	translation, err := h.usecase.SaveServiceTranslation(c.Request().Context(), id, c.Param("locale"), req)
+	if err != nil {
+		return translationErrorResponse(c, err, "Failed to save service translation")
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Service translation saved successfully", translation)
+}
+
+func (h *TranslationHandler) DeleteServiceTranslation(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid service ID", err)
+	}
+
+	if err := h.usecase.DeleteServiceTranslation(c.Request().Context(), id, c.Param("locale")); err != nil {
+		return translationErrorResponse(c, err, "Failed to delete service translation")
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Service translation deleted successfully", nil)
+}
+
+func (h *TranslationHandler) GetCategoryTranslations(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err)
+	}
+
+	translations, err := h.usecase.GetCategoryTranslations(c.Request().Context(), id)
+	if err != nil {
+		return translationErrorResponse(c, err, "Failed to get category translations")
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Category translations retrieved successfully", translations)
+}
+
+func (h *TranslationHandler) SaveCategoryTranslation(c echo.Context, req *request.CategoryTranslationRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err)
+	}
+
+	translation, err := h.usecase.SaveCategoryTranslation(c.Request().Context(), id, c.Param("locale"), req)
+	if err != nil {
+		return translationErrorResponse(c, err, "Failed to save category translation")
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Category translation saved successfully", translation)
+}
+
+func (h *TranslationHandler) DeleteCategoryTranslation(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err)
+	}
+
+	if err := h.usecase.DeleteCategoryTranslation(c.Request().Context(), id, c.Param("locale")); err != nil {
+		return translationErrorResponse(c, err, "Failed to delete category translation")
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Category translation deleted successfully", nil)
+}
+
+func (h *TranslationHandler) GetBranchTranslations(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	translations, err := h.usecase.GetBranchTranslations(c.Request().Context(), id)
+	if err != nil {
+		return translationErrorResponse(c, err, "Failed to get branch translations")
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branch translations retrieved successfully", translations)
+}
+
+func (h *TranslationHandler) SaveBranchTranslation(c echo.Context, req *request.BranchTranslationRequest) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	translation, err := h.usecase.SaveBranchTranslation(c.Request().Context(), id, c.Param("locale"), req)
+	if err != nil {
+		return translationErrorResponse(c, err, "Failed to save branch translation")
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusOK, "Branch translation saved successfully", translation)
+}
+
+func (h *TranslationHandler) DeleteBranchTranslation(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid branch ID", err)
+	}
+
+	if err := h.usecase.DeleteBranchTranslation(c.Request().Context(), id, c.Param("locale")); err != nil {
+		return translationErrorResponse(c, err, "Failed to delete branch translation")
+	}
+	return transport.NewApiSuccessResponse(c, http.StatusNoContent, "Branch translation deleted successfully", nil)
+}
+
+func translationErrorResponse(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, utils.ErrServiceNotFound):
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	case errors.Is(err, utils.ErrCategoryNotFound):
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	case errors.Is(err, utils.ErrBranchNotFound):
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	case errors.Is(err, utils.ErrTranslationNotFound):
+		return transport.NewApiErrorResponse(c, http.StatusNotFound, utils.ErrorCode(err), nil)
+	case errors.Is(err, utils.ErrUnsupportedLocale), errors.Is(err, utils.ErrDefaultLocale):
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, utils.ErrorCode(err), nil)
+	default:
+		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"KaungHtetHein116/IVY-backend/api/transport"
 	"KaungHtetHein116/IVY-backend/api/v1/params"
 	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
 	"KaungHtetHein116/IVY-backend/internal/usecase"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
 	"KaungHtetHein116/IVY-backend/utils"
 	"net/http"
 
@@ -32,7 +34,7 @@ func NewUserHandler(userUsecase usecase.UserUsecase) UserHandler {
 func (h *userHandler) ClerkWebhook(c echo.Context) error {
 	var req *request.ClerkWebhookRequest
 	if err := c.Bind(&req); err != nil {
-		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidRequest, err)
 	}
 
 	err := h.userUsecase.HandleClerkWebhook(c.Request().Context(), req)
@@ -41,13 +43,13 @@ func (h *userHandler) ClerkWebhook(c echo.Context) error {
 		return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to process Clerk webhook", err)
 	}
 
-	return transport.NewApiSuccessResponse(c, http.StatusOK, "Clerk webhook received successfully", nil)
+	return transport.NewApiSuccessResponse(c, http.StatusOK, i18n.CodeWebhookReceived, nil)
 }
 
 func (h *userHandler) GetUserByID(c echo.Context) error {
 	userID := c.Param("id")
 	if userID == "" {
-		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "User ID is required", nil)
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeUserIDRequired, nil)
 	}
 
 	userData, err := h.userUsecase.GetUserByID(c.Request().Context(), userID)
@@ -64,7 +66,7 @@ func (h *userHandler) GetMe(c echo.Context) error {
 	userID := c.Get("user_id").(string)
 
 	if userID == "" {
-		return transport.NewApiErrorResponse(c, http.StatusUnauthorized, "Unauthorized access", nil)
+		return transport.NewApiErrorResponse(c, http.StatusUnauthorized, i18n.CodeUnauthorized, nil)
 	}
 
 	userData, err := h.userUsecase.GetMe(c.Request().Context(), userID)
@@ -84,7 +86,7 @@ func (h *userHandler) GetAllUsers(c echo.Context) error {
 	err := c.Bind(filter)
 
 	if err != nil {
-		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidQuery, err)
 	}
 
 	users, pagination, err := h.userUsecase.GetAllUsers(c.Request().Context(), filter)
@@ -99,24 +101,12 @@ func (h *userHandler) UpdateUser(c echo.Context, req *request.UserUpdateRequest)
 	// Get the ID of the user to update
 	userID := c.Param("id")
 	if userID == "" {
-		return transport.NewApiErrorResponse(c, http.StatusBadRequest, "User ID is required", nil)
+		return transport.NewApiErrorResponse(c, http.StatusBadRequest, i18n.CodeUserIDRequired, nil)
 	}
 
-	// Get the current user's ID from the context
-	currentUserID := c.Get("user_id").(string)
-
-	// If trying to update someone else's profile
-	if currentUserID != userID {
-		// Check if current user is admin
-		currentUser, err := h.userUsecase.GetMe(c.Request().Context(), currentUserID)
-		if err != nil {
-			return transport.NewApiErrorResponse(c, http.StatusInternalServerError, "Failed to verify user permissions", err)
-		}
-
-		// Only admins can update other users
-		if currentUser.Role == nil || *currentUser.Role != "ADMIN" {
-			return transport.NewApiErrorResponse(c, http.StatusForbidden, "Only admins can update other users' profiles", nil)
-		}
+	// Only admins can update other users or change roles
+	if c.Get("role") != entity.RoleAdmin && (c.Get("user_id") != userID || req.Role != nil) {
+		return transport.NewApiErrorResponse(c, http.StatusForbidden, i18n.CodeProfileNotEditable, nil)
 	}
 
 	// Proceed with update
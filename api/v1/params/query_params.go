@@ -68,15 +68,38 @@ func NewBranchQueryParams() *BranchQueryParams {
 	}
 }
 
+// BranchNearbyQueryParams finds the branches within RadiusKm of the point
+// Lat, Lng.
+type BranchNearbyQueryParams struct {
+	BaseQueryParams
+	Lat       *float64 `query:"lat"`
+	Lng       *float64 `query:"lng"`
+	RadiusKm  float64  `query:"radius_km"`
+	ServiceID string   `query:"service_id"`
+	IsActive  *bool    `query:"is_active"`
+}
+
+func NewBranchNearbyQueryParams() *BranchNearbyQueryParams {
+	return &BranchNearbyQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+		RadiusKm: 10,
+	}
+}
+
 // service
 
 type ServiceQueryParams struct {
 	BaseQueryParams
 	Name           string `query:"name"`
 	DurationMinute int    `query:"duration_minute"`
-	Price          int    `query:"price"`
+	Price          int64  `query:"price"`
 	CategoryID     string `query:"category_id"`
 	BranchID       string `query:"branch_id"`
+	// IncludeDescendants also lists services of the categories under CategoryID
+	IncludeDescendants bool `query:"include_descendants"`
 }
 
 func NewServiceQueryParams() *ServiceQueryParams {
@@ -88,11 +111,32 @@ func NewServiceQueryParams() *ServiceQueryParams {
 	}
 }
 
+// ServiceSearchQueryParams searches active services by Q. Mode "autocomplete"
+// returns short suggestions instead of full services.
+type ServiceSearchQueryParams struct {
+	BaseQueryParams
+	Q          string `query:"q"`
+	Mode       string `query:"mode"`
+	CategoryID string `query:"category_id"`
+	BranchID   string `query:"branch_id"`
+}
+
+func NewServiceSearchQueryParams() *ServiceSearchQueryParams {
+	return &ServiceSearchQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
 // category
 
 type CategoryQueryParams struct {
 	BaseQueryParams
-	Name string `query:"name"`
+	Name          string `query:"name"`
+	ParentID      string `query:"parent_id"`
+	IncludeHidden bool   `query:"include_hidden"`
 }
 
 func NewCategoryQueryParams() *CategoryQueryParams {
@@ -103,3 +147,292 @@ func NewCategoryQueryParams() *CategoryQueryParams {
 		},
 	}
 }
+
+// promotion
+
+type PromotionQueryParams struct {
+	BaseQueryParams
+	Code         string `query:"code"`
+	DiscountType string `query:"discount_type"`
+	IsActive     *bool  `query:"is_active"`
+}
+
+func NewPromotionQueryParams() *PromotionQueryParams {
+	return &PromotionQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// package
+
+type PackageQueryParams struct {
+	BaseQueryParams
+	Name     string `query:"name"`
+	IsActive *bool  `query:"is_active"`
+}
+
+func NewPackageQueryParams() *PackageQueryParams {
+	return &PackageQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// gift card
+
+type GiftCardQueryParams struct {
+	BaseQueryParams
+	Code            string `query:"code"`
+	PurchaserUserID string `query:"purchaser_user_id"`
+	IsActive        *bool  `query:"is_active"`
+}
+
+func NewGiftCardQueryParams() *GiftCardQueryParams {
+	return &GiftCardQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// ledger
+
+type LedgerQueryParams struct {
+	BaseQueryParams
+	AccountType string `query:"account_type"`
+	AccountID   string `query:"account_id"`
+	UserID      string `query:"user_id"`
+	BookingID   string `query:"booking_id"`
+	EntryType   string `query:"entry_type"`
+}
+
+func NewLedgerQueryParams() *LedgerQueryParams {
+	return &LedgerQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// loyalty points
+
+type PointsQueryParams struct {
+	BaseQueryParams
+	UserID    string `query:"-"`
+	EntryType string `query:"entry_type"`
+}
+
+func NewPointsQueryParams() *PointsQueryParams {
+	return &PointsQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// tax rate
+
+type TaxRateQueryParams struct {
+	BaseQueryParams
+	BranchID   string `query:"branch_id"`
+	CategoryID string `query:"category_id"`
+	IsActive   *bool  `query:"is_active"`
+}
+
+func NewTaxRateQueryParams() *TaxRateQueryParams {
+	return &TaxRateQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// pricing rule
+
+type PricingRuleQueryParams struct {
+	BaseQueryParams
+	ServiceID  string `query:"service_id"`
+	CategoryID string `query:"category_id"`
+	BranchID   string `query:"branch_id"`
+	IsActive   *bool  `query:"is_active"`
+}
+
+func NewPricingRuleQueryParams() *PricingRuleQueryParams {
+	return &PricingRuleQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// membership
+
+type MembershipPlanQueryParams struct {
+	BaseQueryParams
+	IsActive *bool `query:"is_active"`
+}
+
+func NewMembershipPlanQueryParams() *MembershipPlanQueryParams {
+	return &MembershipPlanQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+type SubscriptionQueryParams struct {
+	BaseQueryParams
+	UserID string `query:"user_id"`
+	PlanID string `query:"plan_id"`
+	Status string `query:"status"`
+}
+
+func NewSubscriptionQueryParams() *SubscriptionQueryParams {
+	return &SubscriptionQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// refund
+
+type RefundQueryParams struct {
+	BaseQueryParams
+	UserID    string `query:"user_id"`
+	BookingID string `query:"booking_id"`
+	Status    string `query:"status"`
+}
+
+func NewRefundQueryParams() *RefundQueryParams {
+	return &RefundQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// trash
+
+// TrashQueryParams lists soft-deleted services, categories or branches,
+// optionally matching part of the name.
+type TrashQueryParams struct {
+	BaseQueryParams
+	Name string `query:"name"`
+}
+
+func NewTrashQueryParams() *TrashQueryParams {
+	return &TrashQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// notification
+
+type NotificationQueryParams struct {
+	BaseQueryParams
+}
+
+func NewNotificationQueryParams() *NotificationQueryParams {
+	return &NotificationQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  20,
+			Offset: 0,
+		},
+	}
+}
+
+// BookAgainQueryParams sets how many days ahead to look for open slots and
+// how many of them to propose.
+type BookAgainQueryParams struct {
+	Days  int `query:"days"`
+	Limit int `query:"limit"`
+}
+
+func NewBookAgainQueryParams() *BookAgainQueryParams {
+	return &BookAgainQueryParams{
+		Days:  14,
+		Limit: 5,
+	}
+}
+
+// delete
+
+// DeleteQueryParams chooses what happens to what depends on a deleted
+// category, service or branch: block (the default) refuses while anything
+// does, reassign moves a category's services to ReassignTo, and cancel
+// cancels the upcoming bookings and notifies the customers.
+type DeleteQueryParams struct {
+	Strategy   string `query:"strategy"`
+	ReassignTo string `query:"reassign_to"`
+}
+
+// inventory
+
+type ProductQueryParams struct {
+	BaseQueryParams
+	Name     string `query:"name"`
+	IsRetail *bool  `query:"is_retail"`
+	IsActive *bool  `query:"is_active"`
+}
+
+func NewProductQueryParams() *ProductQueryParams {
+	return &ProductQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+type StockMovementQueryParams struct {
+	BaseQueryParams
+	BranchID     string `query:"branch_id"`
+	ProductID    string `query:"product_id"`
+	MovementType string `query:"movement_type"`
+}
+
+func NewStockMovementQueryParams() *StockMovementQueryParams {
+	return &StockMovementQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
+
+// review
+
+type ReviewQueryParams struct {
+	BaseQueryParams
+	ServiceID string `query:"service_id"`
+	BranchID  string `query:"branch_id"`
+	UserID    string `query:"user_id"`
+	StaffID   string `query:"staff_id"`
+	Status    string `query:"status"`
+}
+
+func NewReviewQueryParams() *ReviewQueryParams {
+	return &ReviewQueryParams{
+		BaseQueryParams: BaseQueryParams{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+}
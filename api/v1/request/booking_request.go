@@ -12,9 +12,29 @@ type CreateBookingRequest struct {
 	BookedDate string    `json:"booked_date" validate:"required"`
 	BookedTime string    `json:"booked_time" validate:"required"`
 	Note       *string   `json:"note" validate:"omitempty,max=100"`
+	PromoCode  *string   `json:"promo_code" validate:"omitempty,max=50"`
+	// VariantID is required when the service has variants; AddOnIDs are
+	// optional extras booked with it
+	VariantID *uuid.UUID  `json:"variant_id"`
+	AddOnIDs  []uuid.UUID `json:"add_on_ids" validate:"omitempty,max=10"`
+	// QuotedPrice is the slot price shown to the customer; the booking is
+	// rejected if the price has changed since
+	QuotedPrice *int64 `json:"quoted_price" validate:"omitempty,min=0"`
+
+	// CustomerPackageID redeems one session of the booked service from a purchased package
+	CustomerPackageID *uuid.UUID `json:"customer_package_id"`
+	GiftCardCode      *string    `json:"gift_card_code" validate:"omitempty,max=20"`
+	RedeemPoints      int64      `json:"redeem_points" validate:"omitempty,min=1"`
 }
 
 type UpdateBookingRequest struct {
-	Status    string    `json:"status" validate:"required,oneof=PENDING CONFIRMED CANCELLED COMPLETED"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	Status        string    `json:"status" validate:"required,oneof=PENDING CONFIRMED CANCELLED COMPLETED"`
+	PaymentStatus *string   `json:"payment_status" validate:"omitempty,oneof=UNPAID PAID"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// PayBookingRequest charges Amount online, or the whole amount still due
+// when it is omitted. Paying less leaves a deposit.
+type PayBookingRequest struct {
+	Amount *int64 `json:"amount" validate:"omitempty,min=1"`
 }
@@ -3,20 +3,30 @@ package request
 import "time"
 
 type CreateBranchRequest struct {
-	Name        string `json:"name" validate:"required"`
-	Location    string `json:"location" validate:"required"`
-	Longitude   string `json:"longitude" validate:"required"`
-	Latitude    string `json:"latitude" validate:"required"`
-	PhoneNumber string `json:"phone_number" validate:"required"`
-	IsActive    bool   `json:"is_active" validate:"omitempty"`
+	Name        string   `json:"name" validate:"required"`
+	Location    string   `json:"location" validate:"required"`
+	Longitude   *float64 `json:"longitude" validate:"required,min=-180,max=180"`
+	Latitude    *float64 `json:"latitude" validate:"required,min=-90,max=90"`
+	PhoneNumber string   `json:"phone_number" validate:"required"`
+	IsActive    bool     `json:"is_active" validate:"omitempty"`
 }
 
 type UpdateBranchRequest struct {
 	Name        string    `json:"name"`
 	Location    string    `json:"location"`
-	Longitude   string    `json:"longitude"`
-	Latitude    string    `json:"latitude"`
+	Longitude   *float64  `json:"longitude" validate:"omitempty,min=-180,max=180"`
+	Latitude    *float64  `json:"latitude" validate:"omitempty,min=-90,max=90"`
 	PhoneNumber string    `json:"phone_number"`
 	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 	IsActive    *bool     `json:"is_active" validate:"omitempty"`
 }
+
+// SaveBranchServiceRequest sets how a branch offers a service. Overrides left
+// out fall back to the service's own price and duration, and a service is
+// active unless is_active is false.
+type SaveBranchServiceRequest struct {
+	IsActive         *bool  `json:"is_active"`
+	PriceOverride    *int64 `json:"price_override" validate:"omitempty,min=0"`
+	DurationOverride *int   `json:"duration_override" validate:"omitempty,min=1"`
+	Capacity         *int   `json:"capacity" validate:"omitempty,min=1"`
+}
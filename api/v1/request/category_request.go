@@ -1,12 +1,36 @@
 package request
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type CreateCategoryRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name      string     `json:"name" validate:"required"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	SortOrder int        `json:"sort_order"`
+	Icon      string     `json:"icon" validate:"omitempty,max=255"`
+	Image     string     `json:"image"`
+	IsHidden  bool       `json:"is_hidden"`
 }
 
+// UpdateCategoryRequest moves the category under ParentID when given; set
+// MoveToRoot to make it a top-level category.
 type UpdateCategoryRequest struct {
-	Name      string    `json:"name"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	Name       string     `json:"name"`
+	ParentID   *uuid.UUID `json:"parent_id"`
+	MoveToRoot bool       `json:"move_to_root"`
+	SortOrder  *int       `json:"sort_order"`
+	Icon       *string    `json:"icon" validate:"omitempty,max=255"`
+	Image      *string    `json:"image"`
+	IsHidden   *bool      `json:"is_hidden"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ReorderCategoriesRequest lists the children of ParentID, or the top-level
+// categories when it is omitted, in their new order.
+type ReorderCategoriesRequest struct {
+	ParentID    *uuid.UUID  `json:"parent_id"`
+	CategoryIDs []uuid.UUID `json:"category_ids" validate:"required,min=1"`
 }
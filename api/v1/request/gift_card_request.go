@@ -0,0 +1,17 @@
+package request
+
+import "time"
+
+type CreateGiftCardRequest struct {
+	InitialBalance  int64      `json:"initial_balance" validate:"required,min=1"`
+	Currency        string     `json:"currency" validate:"omitempty,iso4217"`
+	PurchaserUserID *string    `json:"purchaser_user_id"`
+	RecipientName   string     `json:"recipient_name" validate:"omitempty,max=255"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+}
+
+type UpdateGiftCardRequest struct {
+	RecipientName *string    `json:"recipient_name" validate:"omitempty,max=255"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	IsActive      *bool      `json:"is_active"`
+}
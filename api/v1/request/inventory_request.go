@@ -0,0 +1,50 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreateProductRequest struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	SKU         *string `json:"sku" validate:"omitempty,max=64"`
+	Description string  `json:"description"`
+	Unit        string  `json:"unit" validate:"omitempty,max=20"`
+	Price       int64   `json:"price" validate:"min=0"`
+	Currency    string  `json:"currency" validate:"omitempty,iso4217"`
+	IsRetail    bool    `json:"is_retail"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+type UpdateProductRequest struct {
+	Name        *string `json:"name" validate:"omitempty,max=255"`
+	SKU         *string `json:"sku" validate:"omitempty,max=64"`
+	Description *string `json:"description"`
+	Unit        *string `json:"unit" validate:"omitempty,max=20"`
+	Price       *int64  `json:"price" validate:"omitempty,min=0"`
+	Currency    *string `json:"currency" validate:"omitempty,iso4217"`
+	IsRetail    *bool   `json:"is_retail"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+// RecordStockMovementRequest books stock in or out of a branch. Quantity is
+// what came in for a RESTOCK, what was sold for a SALE, and the signed
+// correction for an ADJUSTMENT.
+type RecordStockMovementRequest struct {
+	BranchID     uuid.UUID `json:"branch_id" validate:"required"`
+	ProductID    uuid.UUID `json:"product_id" validate:"required"`
+	MovementType string    `json:"movement_type" validate:"required,oneof=RESTOCK SALE ADJUSTMENT"`
+	Quantity     int64     `json:"quantity" validate:"required"`
+	Note         string    `json:"note" validate:"max=500"`
+}
+
+type SetLowStockLevelRequest struct {
+	LowStockLevel int64 `json:"low_stock_level" validate:"min=0"`
+}
+
+type ServiceConsumableRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int64     `json:"quantity" validate:"required,min=1"`
+}
+
+// SetServiceConsumablesRequest replaces what one session of a service uses.
+type SetServiceConsumablesRequest struct {
+	Consumables []ServiceConsumableRequest `json:"consumables" validate:"dive"`
+}
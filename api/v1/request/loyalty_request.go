@@ -0,0 +1,21 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreateLoyaltyRuleRequest struct {
+	CategoryID  *uuid.UUID `json:"category_id"`
+	Points      int64      `json:"points" validate:"required,min=1"`
+	SpendAmount int64      `json:"spend_amount" validate:"required,min=1"`
+	IsActive    *bool      `json:"is_active"`
+}
+
+type UpdateLoyaltyRuleRequest struct {
+	Points      *int64 `json:"points" validate:"omitempty,min=1"`
+	SpendAmount *int64 `json:"spend_amount" validate:"omitempty,min=1"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+type AdjustPointsRequest struct {
+	Points int64  `json:"points" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=500"`
+}
@@ -0,0 +1,34 @@
+package request
+
+import (
+	"github.com/google/uuid"
+)
+
+type MembershipBenefitRequest struct {
+	ServiceID uuid.UUID `json:"service_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1"`
+}
+
+type CreateMembershipPlanRequest struct {
+	Name                string                     `json:"name" validate:"required,max=255"`
+	Description         string                     `json:"description"`
+	Price               int64                      `json:"price" validate:"min=0"`
+	Currency            string                     `json:"currency" validate:"omitempty,iso4217"`
+	BillingPeriodMonths int                        `json:"billing_period_months" validate:"required,min=1,max=12"`
+	DiscountPercent     int                        `json:"discount_percent" validate:"min=0,max=100"`
+	IsActive            *bool                      `json:"is_active"`
+	Benefits            []MembershipBenefitRequest `json:"benefits" validate:"omitempty,dive"`
+}
+
+// UpdateMembershipPlanRequest changes the plan for new subscriptions and
+// renewals. Benefits already granted for the current period are kept.
+type UpdateMembershipPlanRequest struct {
+	Name                *string                    `json:"name" validate:"omitempty,max=255"`
+	Description         *string                    `json:"description"`
+	Price               *int64                     `json:"price" validate:"omitempty,min=0"`
+	Currency            *string                    `json:"currency" validate:"omitempty,iso4217"`
+	BillingPeriodMonths *int                       `json:"billing_period_months" validate:"omitempty,min=1,max=12"`
+	DiscountPercent     *int                       `json:"discount_percent" validate:"omitempty,min=0,max=100"`
+	IsActive            *bool                      `json:"is_active"`
+	Benefits            []MembershipBenefitRequest `json:"benefits" validate:"omitempty,dive"`
+}
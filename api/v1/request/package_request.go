@@ -0,0 +1,34 @@
+package request
+
+import (
+	"github.com/google/uuid"
+)
+
+type PackageItemRequest struct {
+	ServiceID uuid.UUID `json:"service_id" validate:"required"`
+	Sessions  int       `json:"sessions" validate:"required,min=1"`
+}
+
+type CreatePackageRequest struct {
+	Name         string               `json:"name" validate:"required,max=255"`
+	Description  string               `json:"description"`
+	Price        int64                `json:"price" validate:"min=0"`
+	Currency     string               `json:"currency" validate:"omitempty,iso4217"`
+	ValidityDays int                  `json:"validity_days" validate:"required,min=1"`
+	IsActive     *bool                `json:"is_active"`
+	Items        []PackageItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type UpdatePackageRequest struct {
+	Name         *string              `json:"name" validate:"omitempty,max=255"`
+	Description  *string              `json:"description"`
+	Price        *int64               `json:"price" validate:"omitempty,min=0"`
+	Currency     *string              `json:"currency" validate:"omitempty,iso4217"`
+	ValidityDays *int                 `json:"validity_days" validate:"omitempty,min=1"`
+	IsActive     *bool                `json:"is_active"`
+	Items        []PackageItemRequest `json:"items" validate:"omitempty,dive"`
+}
+
+type SellPackageRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+}
@@ -0,0 +1,33 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreatePricingRuleRequest struct {
+	Name       string     `json:"name" validate:"required,max=255"`
+	ServiceID  *uuid.UUID `json:"service_id"`
+	CategoryID *uuid.UUID `json:"category_id"`
+	BranchID   *uuid.UUID `json:"branch_id"`
+	// DaysOfWeek is a comma-separated list such as "SAT,SUN"; empty means every day
+	DaysOfWeek      string `json:"days_of_week" validate:"omitempty,max=30"`
+	StartTime       string `json:"start_time" validate:"omitempty,datetime=15:04"`
+	EndTime         string `json:"end_time" validate:"omitempty,datetime=15:04"`
+	MinLeadHours    *int   `json:"min_lead_hours" validate:"omitempty,min=0"`
+	MaxLeadHours    *int   `json:"max_lead_hours" validate:"omitempty,min=0"`
+	AdjustmentType  string `json:"adjustment_type" validate:"required,oneof=PERCENTAGE FIXED"`
+	AdjustmentValue int64  `json:"adjustment_value" validate:"required"`
+	Priority        int    `json:"priority"`
+	IsActive        *bool  `json:"is_active"`
+}
+
+type UpdatePricingRuleRequest struct {
+	Name            *string `json:"name" validate:"omitempty,max=255"`
+	DaysOfWeek      *string `json:"days_of_week" validate:"omitempty,max=30"`
+	StartTime       *string `json:"start_time" validate:"omitempty,datetime=15:04"`
+	EndTime         *string `json:"end_time" validate:"omitempty,datetime=15:04"`
+	MinLeadHours    *int    `json:"min_lead_hours" validate:"omitempty,min=0"`
+	MaxLeadHours    *int    `json:"max_lead_hours" validate:"omitempty,min=0"`
+	AdjustmentType  *string `json:"adjustment_type" validate:"omitempty,oneof=PERCENTAGE FIXED"`
+	AdjustmentValue *int64  `json:"adjustment_value"`
+	Priority        *int    `json:"priority"`
+	IsActive        *bool   `json:"is_active"`
+}
@@ -0,0 +1,42 @@
+package request
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreatePromotionRequest struct {
+	Code           string      `json:"code" validate:"required,max=50"`
+	Name           string      `json:"name" validate:"required,max=255"`
+	Description    string      `json:"description"`
+	DiscountType   string      `json:"discount_type" validate:"required,oneof=PERCENTAGE FIXED"`
+	DiscountValue  int64       `json:"discount_value" validate:"required,min=1"`
+	MaxDiscount    *int64      `json:"max_discount" validate:"omitempty,min=0"`
+	Currency       string      `json:"currency" validate:"omitempty,iso4217"`
+	StartsAt       *time.Time  `json:"starts_at"`
+	EndsAt         *time.Time  `json:"ends_at"`
+	MaxUses        *int        `json:"max_uses" validate:"omitempty,min=1"`
+	MaxUsesPerUser *int        `json:"max_uses_per_user" validate:"omitempty,min=1"`
+	IsActive       *bool       `json:"is_active"`
+	ServiceIDs     []uuid.UUID `json:"service_ids" validate:"omitempty,dive,uuid"`
+	CategoryIDs    []uuid.UUID `json:"category_ids" validate:"omitempty,dive,uuid"`
+	BranchIDs      []uuid.UUID `json:"branch_ids" validate:"omitempty,dive,uuid"`
+}
+
+type UpdatePromotionRequest struct {
+	Name           *string     `json:"name" validate:"omitempty,max=255"`
+	Description    *string     `json:"description"`
+	DiscountType   *string     `json:"discount_type" validate:"omitempty,oneof=PERCENTAGE FIXED"`
+	DiscountValue  *int64      `json:"discount_value" validate:"omitempty,min=1"`
+	MaxDiscount    *int64      `json:"max_discount" validate:"omitempty,min=0"`
+	Currency       *string     `json:"currency" validate:"omitempty,iso4217"`
+	StartsAt       *time.Time  `json:"starts_at"`
+	EndsAt         *time.Time  `json:"ends_at"`
+	MaxUses        *int        `json:"max_uses" validate:"omitempty,min=1"`
+	MaxUsesPerUser *int        `json:"max_uses_per_user" validate:"omitempty,min=1"`
+	IsActive       *bool       `json:"is_active"`
+	ServiceIDs     []uuid.UUID `json:"service_ids" validate:"omitempty,dive,uuid"`
+	CategoryIDs    []uuid.UUID `json:"category_ids" validate:"omitempty,dive,uuid"`
+	BranchIDs      []uuid.UUID `json:"branch_ids" validate:"omitempty,dive,uuid"`
+}
@@ -0,0 +1,26 @@
+package request
+
+type CreateRefundRuleRequest struct {
+	Name           string `json:"name" validate:"required,max=255"`
+	MinNoticeHours int    `json:"min_notice_hours" validate:"min=0"`
+	Percent        int    `json:"percent" validate:"min=0,max=100"`
+	IsActive       *bool  `json:"is_active"`
+}
+
+type UpdateRefundRuleRequest struct {
+	Name           *string `json:"name" validate:"omitempty,max=255"`
+	MinNoticeHours *int    `json:"min_notice_hours" validate:"omitempty,min=0"`
+	Percent        *int    `json:"percent" validate:"omitempty,min=0,max=100"`
+	IsActive       *bool   `json:"is_active"`
+}
+
+// ApproveRefundRequest approves the refundable amount, or Amount for a
+// partial or goodwill refund.
+type ApproveRefundRequest struct {
+	Amount *int64 `json:"amount" validate:"omitempty,min=1"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+type RejectRefundRequest struct {
+	Note string `json:"note" validate:"required,max=500"`
+}
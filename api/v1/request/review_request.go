@@ -0,0 +1,12 @@
+package request
+
+type CreateReviewRequest struct {
+	Rating      int     `json:"rating" validate:"required,min=1,max=5"`
+	Comment     string  `json:"comment" validate:"max=2000"`
+	StaffID     *string `json:"staff_id" validate:"omitempty,max=36"`
+	StaffRating *int    `json:"staff_rating" validate:"omitempty,min=1,max=5"`
+}
+
+type ModerateReviewRequest struct {
+	Note string `json:"note" validate:"max=500"`
+}
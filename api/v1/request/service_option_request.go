@@ -0,0 +1,35 @@
+package request
+
+type CreateServiceVariantRequest struct {
+	Name           string `json:"name" validate:"required,max=255"`
+	DurationMinute int    `json:"duration_minute" validate:"required,min=1"`
+	Price          int64  `json:"price" validate:"min=0"`
+	SortOrder      int    `json:"sort_order"`
+	IsActive       *bool  `json:"is_active"`
+}
+
+type UpdateServiceVariantRequest struct {
+	Name           *string `json:"name" validate:"omitempty,max=255"`
+	DurationMinute *int    `json:"duration_minute" validate:"omitempty,min=1"`
+	Price          *int64  `json:"price" validate:"omitempty,min=0"`
+	SortOrder      *int    `json:"sort_order"`
+	IsActive       *bool   `json:"is_active"`
+}
+
+// CreateServiceAddOnRequest adds DurationMinute and Price on top of the
+// service or variant booked.
+type CreateServiceAddOnRequest struct {
+	Name           string `json:"name" validate:"required,max=255"`
+	DurationMinute int    `json:"duration_minute" validate:"min=0"`
+	Price          int64  `json:"price" validate:"min=0"`
+	SortOrder      int    `json:"sort_order"`
+	IsActive       *bool  `json:"is_active"`
+}
+
+type UpdateServiceAddOnRequest struct {
+	Name           *string `json:"name" validate:"omitempty,max=255"`
+	DurationMinute *int    `json:"duration_minute" validate:"omitempty,min=0"`
+	Price          *int64  `json:"price" validate:"omitempty,min=0"`
+	SortOrder      *int    `json:"sort_order"`
+	IsActive       *bool   `json:"is_active"`
+}
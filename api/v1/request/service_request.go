@@ -7,25 +7,36 @@ import (
 )
 
 type CreateServiceRequest struct {
-	Name           string      `json:"name" validate:"required"`
-	Description    string      `json:"description" validate:"required"`
-	DurationMinute int         `json:"duration_minute" validate:"required,number,min=1"`
-	Price          int         `json:"price" validate:"required,number,min=0"`
-	CategoryID     uuid.UUID   `json:"category_id" validate:"required"`
-	Image          string      `json:"image" validate:"required"`
-	IsActive       bool        `json:"is_active" validate:"required,boolean"`
-	BranchIDs      []uuid.UUID `json:"branch_ids" validate:"required,dive,uuid"`
+	Name           string               `json:"name" validate:"required"`
+	Description    string               `json:"description" validate:"required"`
+	DurationMinute int                  `json:"duration_minute" validate:"required,number,min=1"`
+	Price          int64                `json:"price" validate:"required,number,min=0"`
+	Currency       string               `json:"currency" validate:"omitempty,iso4217"`
+	CategoryID     uuid.UUID            `json:"category_id" validate:"required"`
+	Image          string               `json:"image"`
+	IsActive       bool                 `json:"is_active" validate:"required,boolean"`
+	BranchIDs      []uuid.UUID          `json:"branch_ids" validate:"required,dive,uuid"`
+	BranchPrices   []BranchPriceRequest `json:"branch_prices" validate:"omitempty,dive"`
 }
 
 type UpdateServiceRequest struct {
-	Name           string      `json:"name"`
-	Description    string      `json:"description"`
-	DurationMinute int         `json:"duration_minute" validate:"omitempty,number,min=1"`
-	Price          int         `json:"price" validate:"omitempty,number,min=0"`
-	CategoryID     uuid.UUID   `json:"category_id"`
-	BranchID       uuid.UUID   `json:"branch_id"`
-	Image          string      `json:"image"`
-	IsActive       bool        `json:"is_active" validate:"boolean"`
-	BranchIDs      []uuid.UUID `json:"branch_ids" validate:"omitempty,dive,uuid"`
-	UpdatedAt      time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
+	Name           string               `json:"name"`
+	Description    string               `json:"description"`
+	DurationMinute int                  `json:"duration_minute" validate:"omitempty,number,min=1"`
+	Price          *int64               `json:"price" validate:"omitempty,min=0"`
+	Currency       string               `json:"currency" validate:"omitempty,iso4217"`
+	CategoryID     uuid.UUID            `json:"category_id"`
+	BranchID       uuid.UUID            `json:"branch_id"`
+	Image          string               `json:"image"`
+	IsActive       bool                 `json:"is_active" validate:"boolean"`
+	BranchIDs      []uuid.UUID          `json:"branch_ids" validate:"omitempty,dive,uuid"`
+	BranchPrices   []BranchPriceRequest `json:"branch_prices" validate:"omitempty,dive"`
+	UpdatedAt      time.Time            `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BranchPriceRequest overrides the service price at one branch.
+// A null price removes the override.
+type BranchPriceRequest struct {
+	BranchID uuid.UUID `json:"branch_id" validate:"required"`
+	Price    *int64    `json:"price" validate:"omitempty,min=0"`
 }
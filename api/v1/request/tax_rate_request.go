@@ -0,0 +1,20 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreateTaxRateRequest struct {
+	Name       string     `json:"name" validate:"required,max=100"`
+	BranchID   *uuid.UUID `json:"branch_id"`
+	CategoryID *uuid.UUID `json:"category_id"`
+	// Rate is in basis points, e.g. 500 for 5%
+	Rate      int   `json:"rate" validate:"min=0,max=10000"`
+	Inclusive bool  `json:"inclusive"`
+	IsActive  *bool `json:"is_active"`
+}
+
+type UpdateTaxRateRequest struct {
+	Name      *string `json:"name" validate:"omitempty,max=100"`
+	Rate      *int    `json:"rate" validate:"omitempty,min=0,max=10000"`
+	Inclusive *bool   `json:"inclusive"`
+	IsActive  *bool   `json:"is_active"`
+}
@@ -0,0 +1,17 @@
+package request
+
+type ServiceTranslationRequest struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description"`
+}
+
+type CategoryTranslationRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// BranchTranslationRequest translates a branch. Location may be left empty
+// to show the branch's own.
+type BranchTranslationRequest struct {
+	Name     string `json:"name" validate:"required,max=255"`
+	Location string `json:"location" validate:"max=255"`
+}
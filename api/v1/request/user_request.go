@@ -12,11 +12,11 @@ type UserRegisterRequest struct {
 
 	Name        string  `json:"name" validate:"required,min=3,max=255"`
 	PhoneNumber *string `json:"phone_number" validate:"omitempty,max=20"`
-	Role        *string `json:"role" validate:"omitempty,oneof=USER ADMIN"`
+	Role        *string `json:"role" validate:"omitempty,oneof=USER ADMIN STAFF BRANCH_MANAGER"`
 }
 
 type UserUpdateRequest struct {
-	Role        *string   `json:"role" validate:"omitempty,oneof=USER ADMIN"`
+	Role        *string   `json:"role" validate:"omitempty,oneof=USER ADMIN STAFF BRANCH_MANAGER"`
 	PhoneNumber *string   `json:"phone_number" validate:"omitempty,max=20"`
 	Gender      *string   `json:"gender" validate:"omitempty,max=20"`
 	Birthday    *string   `json:"birthday" validate:"omitempty"`
@@ -2,7 +2,9 @@ package v1
 
 import (
 	"KaungHtetHein116/IVY-backend/api/v1/handler"
+	"KaungHtetHein116/IVY-backend/internal/payment"
 	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/internal/storage"
 	"KaungHtetHein116/IVY-backend/internal/usecase"
 	"KaungHtetHein116/IVY-backend/utils"
 
@@ -10,62 +12,359 @@ import (
 	"gorm.io/gorm"
 )
 
+// RegisterRoutes registers every v1 route.
+func RegisterRoutes(e *echo.Echo, db *gorm.DB, store storage.Storage, provider payment.Provider) {
+	RegisterUserRoutes(e, db)
+	RegisterBranchRoutes(e, db, store, provider)
+	RegisterCategoryRoutes(e, db, provider)
+	RegisterServiceRoutes(e, db, store, provider)
+	RegisterNotificationRoutes(e, db)
+	RegisterTaxRateRoutes(e, db)
+	RegisterPricingRuleRoutes(e, db)
+	RegisterPromotionRoutes(e, db)
+	RegisterPackageRoutes(e, db)
+	RegisterGiftCardRoutes(e, db)
+	RegisterInventoryRoutes(e, db)
+	RegisterLedgerRoutes(e, db)
+	RegisterLoyaltyRoutes(e, db)
+	RegisterMembershipRoutes(e, db, provider)
+	RegisterRefundRoutes(e, db, provider)
+	RegisterBookingRoutes(e, db, provider)
+	RegisterReviewRoutes(e, db)
+}
+
 func RegisterUserRoutes(e *echo.Echo, db *gorm.DB) {
 	userRepo := repository.NewUserRepository(db)
-	userUsecase := usecase.NewUserUsecase(userRepo)
+	memberRepo := repository.NewBranchMemberRepository(db)
+	userUsecase := usecase.NewUserUsecase(userRepo, memberRepo)
 	userHandler := handler.NewUserHandler(userUsecase)
 
 	userRoutes := e.Group("/api/v1/user")
 	userRoutes.GET("", userHandler.GetAllUsers)
 	userRoutes.POST("/clerk-user-webhook", userHandler.ClerkWebhook)
 	userRoutes.GET("/me", userHandler.GetMe)
+
+	favoriteUsecase := usecase.NewFavoriteUsecase(repository.NewFavoriteRepository(db),
+		usecase.NewTranslationUsecase(repository.NewTranslationRepository(db)))
+	favoriteHandler := handler.NewFavoriteHandler(favoriteUsecase)
+	userRoutes.GET("/me/favorites", favoriteHandler.GetMyFavorites)
+	userRoutes.PUT("/me/favorites/services/:service_id", favoriteHandler.AddFavoriteService)
+	userRoutes.DELETE("/me/favorites/services/:service_id", favoriteHandler.RemoveFavoriteService)
+	userRoutes.PUT("/me/favorites/branches/:branch_id", favoriteHandler.AddFavoriteBranch)
+	userRoutes.DELETE("/me/favorites/branches/:branch_id", favoriteHandler.RemoveFavoriteBranch)
+
+	branchMemberUsecase := usecase.NewBranchMemberUsecase(memberRepo, repository.NewBranchRepository(db), userRepo)
+	userRoutes.GET("/me/branches", handler.NewBranchMemberHandler(branchMemberUsecase).GetMyBranches)
 	userRoutes.PUT("/:id", utils.BindAndValidateDecorator(userHandler.UpdateUser))
 	userRoutes.GET("/:id", userHandler.GetUserByID)
 }
 
-func RegisterBranchRoutes(e *echo.Echo, db *gorm.DB) {
+// newDeleteSafeguardUsecase builds what a category, service or branch delete
+// needs to cancel the bookings it would strand.
+func newDeleteSafeguardUsecase(db *gorm.DB, provider payment.Provider) usecase.DeleteSafeguardUsecase {
+	bookingRepo := repository.NewBookingRepository(db)
+	refundUsecase := usecase.NewRefundUsecase(repository.NewRefundRepository(db), bookingRepo, provider)
+	notificationUsecase := usecase.NewNotificationUsecase(repository.NewNotificationRepository(db))
+	return usecase.NewDeleteSafeguardUsecase(bookingRepo, refundUsecase, notificationUsecase)
+}
+
+func RegisterBranchRoutes(e *echo.Echo, db *gorm.DB, store storage.Storage, provider payment.Provider) {
 	branchRepo := repository.NewBranchRepository(db)
-	branchUsecase := usecase.NewBranchUsecase(branchRepo)
+	imageUsecase := usecase.NewImageUsecase(repository.NewImageRepository(db), store)
+	translationUsecase := usecase.NewTranslationUsecase(repository.NewTranslationRepository(db))
+	branchUsecase := usecase.NewBranchUsecase(branchRepo, imageUsecase, translationUsecase, newDeleteSafeguardUsecase(db, provider))
 	branchHandler := handler.NewBranchHandler(branchUsecase)
 
 	branchRoutes := e.Group("/api/v1/branch")
 	branchRoutes.POST("", utils.BindAndValidateDecorator(branchHandler.CreateBranch))
 	branchRoutes.GET("", branchHandler.GetAllBranches)
+	branchRoutes.GET("/nearby", branchHandler.GetNearbyBranches)
 	branchRoutes.GET("/:id", branchHandler.GetBranchByID)
 	branchRoutes.PUT("/:id", utils.BindAndValidateDecorator(branchHandler.UpdateBranch))
+	branchRoutes.GET("/:id/delete-impact", branchHandler.GetBranchDeleteImpact)
 	branchRoutes.DELETE("/:id", branchHandler.DeleteBranch)
+	branchRoutes.GET("/trash", branchHandler.GetDeletedBranches)
+	branchRoutes.POST("/:id/restore", branchHandler.RestoreBranch)
+	branchRoutes.POST("/:id/image", branchHandler.UploadBranchImage)
+
+	branchServiceUsecase := usecase.NewBranchServiceUsecase(repository.NewBranchServiceRepository(db), branchRepo)
+	branchServiceHandler := handler.NewBranchServiceHandler(branchServiceUsecase)
+	branchRoutes.GET("/:id/services", branchServiceHandler.GetBranchServices)
+	branchRoutes.PUT("/:id/services/:service_id", utils.BindAndValidateDecorator(branchServiceHandler.SaveBranchService))
+	branchRoutes.DELETE("/:id/services/:service_id", branchServiceHandler.RemoveBranchService)
+
+	branchMemberUsecase := usecase.NewBranchMemberUsecase(repository.NewBranchMemberRepository(db), branchRepo,
+		repository.NewUserRepository(db))
+	branchMemberHandler := handler.NewBranchMemberHandler(branchMemberUsecase)
+	branchRoutes.GET("/:id/members", branchMemberHandler.GetBranchMembers)
+	branchRoutes.PUT("/:id/members/:user_id", branchMemberHandler.AddBranchMember)
+	branchRoutes.DELETE("/:id/members/:user_id", branchMemberHandler.RemoveBranchMember)
+
+	translationHandler := handler.NewTranslationHandler(translationUsecase)
+	branchRoutes.GET("/:id/translations", translationHandler.GetBranchTranslations)
+	branchRoutes.PUT("/:id/translations/:locale", utils.BindAndValidateDecorator(translationHandler.SaveBranchTranslation))
+	branchRoutes.DELETE("/:id/translations/:locale", translationHandler.DeleteBranchTranslation)
 }
 
-func RegisterCategoryRoutes(e *echo.Echo, db *gorm.DB) {
+func RegisterCategoryRoutes(e *echo.Echo, db *gorm.DB, provider payment.Provider) {
 	categoryRepo := repository.NewCategoryRepository(db)
-	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo)
+	translationUsecase := usecase.NewTranslationUsecase(repository.NewTranslationRepository(db))
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo, translationUsecase, newDeleteSafeguardUsecase(db, provider))
 	categoryHandler := handler.NewCategoryHandler(categoryUsecase)
 
 	categoryRoutes := e.Group("/api/v1/category")
 	categoryRoutes.POST("", utils.BindAndValidateDecorator(categoryHandler.CreateCategory))
 	categoryRoutes.GET("", categoryHandler.GetAllCategories)
+	categoryRoutes.GET("/tree", categoryHandler.GetCategoryTree)
+	categoryRoutes.PUT("/reorder", utils.BindAndValidateDecorator(categoryHandler.ReorderCategories))
 	categoryRoutes.GET("/:id", categoryHandler.GetCategoryByID)
 	categoryRoutes.PUT("/:id", utils.BindAndValidateDecorator(categoryHandler.UpdateCategory))
+	categoryRoutes.GET("/:id/delete-impact", categoryHandler.GetCategoryDeleteImpact)
 	categoryRoutes.DELETE("/:id", categoryHandler.DeleteCategory)
+	categoryRoutes.GET("/trash", categoryHandler.GetDeletedCategories)
+	categoryRoutes.POST("/:id/restore", categoryHandler.RestoreCategory)
+
+	translationHandler := handler.NewTranslationHandler(translationUsecase)
+	categoryRoutes.GET("/:id/translations", translationHandler.GetCategoryTranslations)
+	categoryRoutes.PUT("/:id/translations/:locale", utils.BindAndValidateDecorator(translationHandler.SaveCategoryTranslation))
+	categoryRoutes.DELETE("/:id/translations/:locale", translationHandler.DeleteCategoryTranslation)
 }
 
-func RegisterServiceRoutes(e *echo.Echo, db *gorm.DB) {
+func RegisterServiceRoutes(e *echo.Echo, db *gorm.DB, store storage.Storage, provider payment.Provider) {
 	serviceRepo := repository.NewServiceRepository(db)
-	serviceUsecase := usecase.NewServiceUsecase(serviceRepo)
+	taxRateUsecase := usecase.NewTaxRateUsecase(repository.NewTaxRateRepository(db))
+	imageUsecase := usecase.NewImageUsecase(repository.NewImageRepository(db), store)
+	translationUsecase := usecase.NewTranslationUsecase(repository.NewTranslationRepository(db))
+	serviceUsecase := usecase.NewServiceUsecase(serviceRepo, taxRateUsecase, imageUsecase, translationUsecase,
+		newDeleteSafeguardUsecase(db, provider))
 	serviceHandler := handler.NewServiceHandler(serviceUsecase)
 
 	serviceRoutes := e.Group("/api/v1/service")
 	serviceRoutes.POST("", utils.BindAndValidateDecorator(serviceHandler.CreateService))
 	serviceRoutes.GET("", serviceHandler.GetAllServices)
+	serviceRoutes.GET("/search", serviceHandler.SearchServices)
 	serviceRoutes.GET("/:id", serviceHandler.GetServiceByID)
 	serviceRoutes.PUT("/:id", utils.BindAndValidateDecorator(serviceHandler.UpdateService))
+	serviceRoutes.GET("/:id/delete-impact", serviceHandler.GetServiceDeleteImpact)
 	serviceRoutes.DELETE("/:id", serviceHandler.DeleteService)
+	serviceRoutes.GET("/trash", serviceHandler.GetDeletedServices)
+	serviceRoutes.POST("/:id/restore", serviceHandler.RestoreService)
+	serviceRoutes.POST("/:id/image", serviceHandler.UploadServiceImage)
+	serviceRoutes.GET("/:id/versions", serviceHandler.GetServiceVersions)
+
+	serviceOptionUsecase := usecase.NewServiceOptionUsecase(repository.NewServiceOptionRepository(db))
+	serviceOptionHandler := handler.NewServiceOptionHandler(serviceOptionUsecase)
+
+	serviceRoutes.POST("/:id/variants", utils.BindAndValidateDecorator(serviceOptionHandler.CreateVariant))
+	serviceRoutes.GET("/:id/variants", serviceOptionHandler.GetVariants)
+	serviceRoutes.PUT("/:id/variants/:variant_id", utils.BindAndValidateDecorator(serviceOptionHandler.UpdateVariant))
+	serviceRoutes.DELETE("/:id/variants/:variant_id", serviceOptionHandler.DeleteVariant)
+	serviceRoutes.POST("/:id/add-ons", utils.BindAndValidateDecorator(serviceOptionHandler.CreateAddOn))
+	serviceRoutes.GET("/:id/add-ons", serviceOptionHandler.GetAddOns)
+	serviceRoutes.PUT("/:id/add-ons/:add_on_id", utils.BindAndValidateDecorator(serviceOptionHandler.UpdateAddOn))
+	serviceRoutes.DELETE("/:id/add-ons/:add_on_id", serviceOptionHandler.DeleteAddOn)
+
+	inventoryHandler := handler.NewInventoryHandler(usecase.NewInventoryUsecase(repository.NewInventoryRepository(db)))
+	serviceRoutes.GET("/:id/consumables", inventoryHandler.GetServiceConsumables)
+	serviceRoutes.PUT("/:id/consumables", utils.BindAndValidateDecorator(inventoryHandler.SetServiceConsumables))
+
+	translationHandler := handler.NewTranslationHandler(translationUsecase)
+	serviceRoutes.GET("/:id/translations", translationHandler.GetServiceTranslations)
+	serviceRoutes.PUT("/:id/translations/:locale", utils.BindAndValidateDecorator(translationHandler.SaveServiceTranslation))
+	serviceRoutes.DELETE("/:id/translations/:locale", translationHandler.DeleteServiceTranslation)
+}
+
+func RegisterNotificationRoutes(e *echo.Echo, db *gorm.DB) {
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepo)
+	notificationHandler := handler.NewNotificationHandler(notificationUsecase)
+
+	notificationRoutes := e.Group("/api/v1/notification")
+	notificationRoutes.GET("/me", notificationHandler.GetMyNotifications)
+	notificationRoutes.PUT("/:id/read", notificationHandler.MarkRead)
+}
+
+func RegisterTaxRateRoutes(e *echo.Echo, db *gorm.DB) {
+	taxRateRepo := repository.NewTaxRateRepository(db)
+	taxRateUsecase := usecase.NewTaxRateUsecase(taxRateRepo)
+	taxRateHandler := handler.NewTaxRateHandler(taxRateUsecase)
+
+	taxRateRoutes := e.Group("/api/v1/tax-rate")
+	taxRateRoutes.POST("", utils.BindAndValidateDecorator(taxRateHandler.CreateTaxRate))
+	taxRateRoutes.GET("", taxRateHandler.GetAllTaxRates)
+	taxRateRoutes.GET("/:id", taxRateHandler.GetTaxRateByID)
+	taxRateRoutes.PUT("/:id", utils.BindAndValidateDecorator(taxRateHandler.UpdateTaxRate))
+	taxRateRoutes.DELETE("/:id", taxRateHandler.DeleteTaxRate)
+}
+
+func RegisterPricingRuleRoutes(e *echo.Echo, db *gorm.DB) {
+	pricingRuleRepo := repository.NewPricingRuleRepository(db)
+	pricingRuleUsecase := usecase.NewPricingRuleUsecase(pricingRuleRepo)
+	pricingRuleHandler := handler.NewPricingRuleHandler(pricingRuleUsecase)
+
+	pricingRuleRoutes := e.Group("/api/v1/pricing-rule")
+	pricingRuleRoutes.POST("", utils.BindAndValidateDecorator(pricingRuleHandler.CreatePricingRule))
+	pricingRuleRoutes.GET("", pricingRuleHandler.GetAllPricingRules)
+	pricingRuleRoutes.GET("/:id", pricingRuleHandler.GetPricingRuleByID)
+	pricingRuleRoutes.PUT("/:id", utils.BindAndValidateDecorator(pricingRuleHandler.UpdatePricingRule))
+	pricingRuleRoutes.DELETE("/:id", pricingRuleHandler.DeletePricingRule)
+}
+
+func RegisterPromotionRoutes(e *echo.Echo, db *gorm.DB) {
+	promotionRepo := repository.NewPromotionRepository(db)
+	promotionUsecase := usecase.NewPromotionUsecase(promotionRepo)
+	promotionHandler := handler.NewPromotionHandler(promotionUsecase)
+
+	promotionRoutes := e.Group("/api/v1/promotion")
+	promotionRoutes.POST("", utils.BindAndValidateDecorator(promotionHandler.CreatePromotion))
+	promotionRoutes.GET("", promotionHandler.GetAllPromotions)
+	promotionRoutes.GET("/:id", promotionHandler.GetPromotionByID)
+	promotionRoutes.PUT("/:id", utils.BindAndValidateDecorator(promotionHandler.UpdatePromotion))
+	promotionRoutes.DELETE("/:id", promotionHandler.DeletePromotion)
 }
 
-func RegisterBookingRoutes(e *echo.Echo, db *gorm.DB) {
+func RegisterPackageRoutes(e *echo.Echo, db *gorm.DB) {
+	packageRepo := repository.NewPackageRepository(db)
+	packageUsecase := usecase.NewPackageUsecase(packageRepo)
+	packageHandler := handler.NewPackageHandler(packageUsecase)
+
+	packageRoutes := e.Group("/api/v1/package")
+	packageRoutes.POST("", utils.BindAndValidateDecorator(packageHandler.CreatePackage))
+	packageRoutes.GET("", packageHandler.GetAllPackages)
+	packageRoutes.GET("/me", packageHandler.GetUserPackages)
+	packageRoutes.GET("/:id", packageHandler.GetPackageByID)
+	packageRoutes.PUT("/:id", utils.BindAndValidateDecorator(packageHandler.UpdatePackage))
+	packageRoutes.DELETE("/:id", packageHandler.DeletePackage)
+	packageRoutes.POST("/:id/sell", utils.BindAndValidateDecorator(packageHandler.SellPackage))
+}
+
+func RegisterGiftCardRoutes(e *echo.Echo, db *gorm.DB) {
+	giftCardRepo := repository.NewGiftCardRepository(db)
+	giftCardUsecase := usecase.NewGiftCardUsecase(giftCardRepo)
+	giftCardHandler := handler.NewGiftCardHandler(giftCardUsecase)
+
+	giftCardRoutes := e.Group("/api/v1/gift-card")
+	giftCardRoutes.POST("", utils.BindAndValidateDecorator(giftCardHandler.IssueGiftCard))
+	giftCardRoutes.GET("", giftCardHandler.GetAllGiftCards)
+	giftCardRoutes.GET("/code/:code", giftCardHandler.GetGiftCardByCode)
+	giftCardRoutes.GET("/:id", giftCardHandler.GetGiftCardByID)
+	giftCardRoutes.PUT("/:id", utils.BindAndValidateDecorator(giftCardHandler.UpdateGiftCard))
+}
+
+func RegisterInventoryRoutes(e *echo.Echo, db *gorm.DB) {
+	inventoryRepo := repository.NewInventoryRepository(db)
+	inventoryUsecase := usecase.NewInventoryUsecase(inventoryRepo)
+	inventoryHandler := handler.NewInventoryHandler(inventoryUsecase)
+
+	productRoutes := e.Group("/api/v1/product")
+	productRoutes.POST("", utils.BindAndValidateDecorator(inventoryHandler.CreateProduct))
+	productRoutes.GET("", inventoryHandler.GetAllProducts)
+	productRoutes.GET("/:id", inventoryHandler.GetProductByID)
+	productRoutes.PUT("/:id", utils.BindAndValidateDecorator(inventoryHandler.UpdateProduct))
+	productRoutes.DELETE("/:id", inventoryHandler.DeleteProduct)
+
+	inventoryRoutes := e.Group("/api/v1/inventory")
+	inventoryRoutes.GET("/low-stock", inventoryHandler.GetLowStockReport)
+	inventoryRoutes.GET("/movements", inventoryHandler.GetMovements)
+	inventoryRoutes.POST("/movements", utils.BindAndValidateDecorator(inventoryHandler.RecordMovement))
+	inventoryRoutes.GET("/branches/:branch_id", inventoryHandler.GetBranchStock)
+	inventoryRoutes.PUT("/branches/:branch_id/products/:product_id", utils.BindAndValidateDecorator(inventoryHandler.SetLowStockLevel))
+}
+
+func RegisterLedgerRoutes(e *echo.Echo, db *gorm.DB) {
+	ledgerRepo := repository.NewLedgerRepository(db)
+	ledgerUsecase := usecase.NewLedgerUsecase(ledgerRepo)
+	ledgerHandler := handler.NewLedgerHandler(ledgerUsecase)
+
+	ledgerRoutes := e.Group("/api/v1/ledger")
+	ledgerRoutes.GET("", ledgerHandler.GetLedgerEntries)
+}
+
+func RegisterLoyaltyRoutes(e *echo.Echo, db *gorm.DB) {
+	loyaltyRepo := repository.NewLoyaltyRepository(db)
+	loyaltyUsecase := usecase.NewLoyaltyUsecase(loyaltyRepo)
+	loyaltyHandler := handler.NewLoyaltyHandler(loyaltyUsecase)
+
+	loyaltyRoutes := e.Group("/api/v1/loyalty")
+	loyaltyRoutes.GET("/me", loyaltyHandler.GetMyBalance)
+	loyaltyRoutes.GET("/me/history", loyaltyHandler.GetMyHistory)
+	loyaltyRoutes.GET("/users/:id", loyaltyHandler.GetUserBalance)
+	loyaltyRoutes.GET("/users/:id/history", loyaltyHandler.GetUserHistory)
+	loyaltyRoutes.POST("/users/:id/adjust", utils.BindAndValidateDecorator(loyaltyHandler.AdjustPoints))
+	loyaltyRoutes.POST("/rules", utils.BindAndValidateDecorator(loyaltyHandler.CreateRule))
+	loyaltyRoutes.GET("/rules", loyaltyHandler.GetRules)
+	loyaltyRoutes.PUT("/rules/:id", utils.BindAndValidateDecorator(loyaltyHandler.UpdateRule))
+	loyaltyRoutes.DELETE("/rules/:id", loyaltyHandler.DeleteRule)
+}
+
+func RegisterMembershipRoutes(e *echo.Echo, db *gorm.DB, provider payment.Provider) {
+	membershipRepo := repository.NewMembershipRepository(db)
+	membershipUsecase := usecase.NewMembershipUsecase(membershipRepo, provider)
+	membershipHandler := handler.NewMembershipHandler(membershipUsecase)
+
+	membershipRoutes := e.Group("/api/v1/membership")
+	membershipRoutes.POST("/plans", utils.BindAndValidateDecorator(membershipHandler.CreatePlan))
+	membershipRoutes.GET("/plans", membershipHandler.GetAllPlans)
+	membershipRoutes.GET("/plans/:id", membershipHandler.GetPlanByID)
+	membershipRoutes.PUT("/plans/:id", utils.BindAndValidateDecorator(membershipHandler.UpdatePlan))
+	membershipRoutes.DELETE("/plans/:id", membershipHandler.DeletePlan)
+	membershipRoutes.POST("/plans/:id/subscribe", membershipHandler.Subscribe)
+	membershipRoutes.GET("/subscriptions", membershipHandler.GetAllSubscriptions)
+	membershipRoutes.GET("/subscriptions/me", membershipHandler.GetMySubscription)
+	membershipRoutes.POST("/subscriptions/me/cancel", membershipHandler.CancelMySubscription)
+}
+
+func RegisterRefundRoutes(e *echo.Echo, db *gorm.DB, provider payment.Provider) {
+	refundRepo := repository.NewRefundRepository(db)
+	refundUsecase := usecase.NewRefundUsecase(refundRepo, repository.NewBookingRepository(db), provider)
+	refundHandler := handler.NewRefundHandler(refundUsecase)
+
+	refundRoutes := e.Group("/api/v1/refund")
+	refundRoutes.GET("", refundHandler.GetAllRefunds)
+	refundRoutes.GET("/rules", refundHandler.GetRules)
+	refundRoutes.POST("/rules", utils.BindAndValidateDecorator(refundHandler.CreateRule))
+	refundRoutes.PUT("/rules/:id", utils.BindAndValidateDecorator(refundHandler.UpdateRule))
+	refundRoutes.DELETE("/rules/:id", refundHandler.DeleteRule)
+	refundRoutes.GET("/:id", refundHandler.GetRefundByID)
+	refundRoutes.POST("/:id/approve", utils.BindAndValidateDecorator(refundHandler.ApproveRefund))
+	refundRoutes.POST("/:id/reject", utils.BindAndValidateDecorator(refundHandler.RejectRefund))
+}
+
+func RegisterReviewRoutes(e *echo.Echo, db *gorm.DB) {
+	reviewRepo := repository.NewReviewRepository(db)
+	reviewUsecase := usecase.NewReviewUsecase(reviewRepo, repository.NewBookingRepository(db))
+	reviewHandler := handler.NewReviewHandler(reviewUsecase)
+
+	e.POST("/api/v1/booking/:id/review", utils.BindAndValidateDecorator(reviewHandler.CreateReview))
+	e.GET("/api/v1/service/:id/reviews", reviewHandler.GetServiceReviews)
+
+	reviewRoutes := e.Group("/api/v1/review")
+	reviewRoutes.GET("", reviewHandler.GetAllReviews)
+	reviewRoutes.GET("/me", reviewHandler.GetMyReviews)
+	reviewRoutes.GET("/:id", reviewHandler.GetReviewByID)
+	reviewRoutes.POST("/:id/approve", utils.BindAndValidateDecorator(reviewHandler.ApproveReview))
+	reviewRoutes.POST("/:id/reject", utils.BindAndValidateDecorator(reviewHandler.RejectReview))
+	reviewRoutes.DELETE("/:id", reviewHandler.DeleteReview)
+}
+
+func RegisterBookingRoutes(e *echo.Echo, db *gorm.DB, provider payment.Provider) {
 	bookingRepo := repository.NewBookingRepository(db)
-	bookingUsecase := usecase.NewBookingUsecase(bookingRepo)
+	serviceRepo := repository.NewServiceRepository(db)
+	packageRepo := repository.NewPackageRepository(db)
+	giftCardRepo := repository.NewGiftCardRepository(db)
+	promotionUsecase := usecase.NewPromotionUsecase(repository.NewPromotionRepository(db))
+	loyaltyUsecase := usecase.NewLoyaltyUsecase(repository.NewLoyaltyRepository(db))
+	invoiceUsecase := usecase.NewInvoiceUsecase(repository.NewInvoiceRepository(db), bookingRepo,
+		repository.NewBranchRepository(db), repository.NewUserRepository(db))
+	taxRateUsecase := usecase.NewTaxRateUsecase(repository.NewTaxRateRepository(db))
+	pricingUsecase := usecase.NewPricingRuleUsecase(repository.NewPricingRuleRepository(db))
+	membershipRepo := repository.NewMembershipRepository(db)
+	refundUsecase := usecase.NewRefundUsecase(repository.NewRefundRepository(db), bookingRepo, provider)
+	inventoryUsecase := usecase.NewInventoryUsecase(repository.NewInventoryRepository(db))
+	bookingUsecase := usecase.NewBookingUsecase(bookingRepo, serviceRepo, packageRepo, giftCardRepo, membershipRepo,
+		promotionUsecase, loyaltyUsecase, invoiceUsecase, taxRateUsecase, pricingUsecase, refundUsecase, inventoryUsecase, provider)
 	bookingHandler := handler.NewBookingHandler(bookingUsecase)
+	invoiceHandler := handler.NewInvoiceHandler(invoiceUsecase)
 
 	bookingRoutes := e.Group("/api/v1/booking")
 	bookingRoutes.POST("", utils.BindAndValidateDecorator(bookingHandler.CreateBooking))
@@ -73,6 +372,12 @@ func RegisterBookingRoutes(e *echo.Echo, db *gorm.DB) {
 	bookingRoutes.GET("/slots", bookingHandler.GetAvailableSlots)
 	bookingRoutes.GET("/me", bookingHandler.GetUserBookings)
 	bookingRoutes.GET("/:id", bookingHandler.GetBookingByID)
+	bookingRoutes.GET("/:id/invoice", invoiceHandler.GetInvoice)
+	bookingRoutes.GET("/:id/invoice.pdf", invoiceHandler.GetInvoicePDF)
+	bookingRoutes.POST("/:id/pay", utils.BindAndValidateDecorator(bookingHandler.PayBooking))
+	bookingRoutes.GET("/:id/payments", bookingHandler.GetBookingPayments)
+	bookingRoutes.GET("/:id/book-again", bookingHandler.BookAgain)
 	bookingRoutes.PUT("/:id", utils.BindAndValidateDecorator(bookingHandler.UpdateBooking))
+	bookingRoutes.POST("/:id/cancel", bookingHandler.CancelBooking)
 	bookingRoutes.DELETE("/:id", bookingHandler.DeleteBooking)
 }
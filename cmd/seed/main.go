@@ -2,8 +2,8 @@ package main
 
 import (
 	"KaungHtetHein116/IVY-backend/config"
+	"KaungHtetHein116/IVY-backend/internal/db/migration"
 	"KaungHtetHein116/IVY-backend/internal/db/seeder"
-	"KaungHtetHein116/IVY-backend/internal/entity"
 	"log"
 	"os"
 
@@ -15,13 +15,9 @@ func main() {
 	godotenv.Load(".env.development")
 	db := config.ConnectDB()
 
-	db.AutoMigrate(
-		&entity.Booking{},
-		&entity.Branch{},
-		&entity.Category{},
-		&entity.Service{},
-		&entity.User{},
-	)
+	if err := migration.Migrate(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
 
 	// Create and run seeder
 	dbSeeder := seeder.NewSeeder(db)
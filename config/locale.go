@@ -0,0 +1,15 @@
+package config
+
+import (
+	"KaungHtetHein116/IVY-backend/pkg/locale"
+	"os"
+)
+
+// DefaultLocale is the language the catalog's own columns are written in and
+// the fallback when a request asks for none of the supported locales.
+func DefaultLocale() string {
+	if code := os.Getenv("DEFAULT_LOCALE"); locale.IsSupported(code) {
+		return code
+	}
+	return locale.English
+}
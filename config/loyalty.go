@@ -0,0 +1,24 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// LoyaltyPointValue is the discount, in minor units, one loyalty point is worth.
+func LoyaltyPointValue() int64 {
+	return envInt64("LOYALTY_POINT_VALUE", 100)
+}
+
+// LoyaltyPointsValidityDays is how long earned points stay redeemable.
+func LoyaltyPointsValidityDays() int {
+	return int(envInt64("LOYALTY_POINTS_VALIDITY_DAYS", 365))
+}
+
+func envInt64(key string, def int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil || value <= 0 {
+		return def
+	}
+	return value
+}
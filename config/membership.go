@@ -0,0 +1,7 @@
+package config
+
+// MembershipGraceDays is how long a renewal that failed to charge keeps being
+// retried before the subscription expires.
+func MembershipGraceDays() int {
+	return int(envInt64("MEMBERSHIP_GRACE_DAYS", 3))
+}
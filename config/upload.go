@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+// UploadMaxBytes is the largest image file accepted for upload.
+func UploadMaxBytes() int64 {
+	return envInt64("UPLOAD_MAX_BYTES", 5<<20)
+}
+
+// OrphanImageGrace is how long an uploaded image may stay unattached before
+// the cleanup job removes it.
+func OrphanImageGrace() time.Duration {
+	return time.Duration(envInt64("ORPHAN_IMAGE_GRACE_HOURS", 24)) * time.Hour
+}
@@ -0,0 +1,275 @@
+// Package migration keeps the database schema in sync with the entities.
+// GORM's AutoMigrate handles additive changes; anything that has to rewrite
+// existing data is expressed as a named migration that runs exactly once.
+package migration
+
+import (
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type schemaMigration struct {
+	ID        string    `gorm:"type:varchar(100);primary_key"`
+	AppliedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type dataMigration struct {
+	id string
+	// beforeAutoMigrate migrations run while the old column types are still
+	// in place, e.g. when a column has to be converted with a USING clause.
+	beforeAutoMigrate bool
+	up                func(tx *gorm.DB) error
+}
+
+// dataMigrations are applied in order and recorded in schema_migrations.
+var dataMigrations = []dataMigration{
+	{
+		id: "20261019_service_price_minor_units",
+		up: func(tx *gorm.DB) error {
+			// Prices used to be whole MMK in a smallint column.
+			return tx.Exec("UPDATE services SET price = price * 100").Error
+		},
+	},
+	{
+		id: "20261021_booking_subtotal",
+		up: func(tx *gorm.DB) error {
+			// Bookings made before tax rates existed were charged without tax.
+			return tx.Exec("UPDATE bookings SET subtotal = total_price WHERE subtotal = 0").Error
+		},
+	},
+	{
+		id: "20261022_booking_base_price",
+		up: func(tx *gorm.DB) error {
+			// Bookings made before pricing rules were charged the base price.
+			return tx.Exec("UPDATE bookings SET base_price = service_price WHERE base_price = 0").Error
+		},
+	},
+	{
+		id: "20261023_booking_payments",
+		up: func(tx *gorm.DB) error {
+			// Bookings marked as paid before payments were recorded were
+			// paid in full at the counter.
+			if err := tx.Exec(`INSERT INTO booking_payments (id, booking_id, amount, currency, method, created_at)
+				SELECT uuid_generate_v4(), id, amount_due, currency, 'COUNTER', COALESCE(paid_at, updated_at)
+				FROM bookings WHERE payment_status = 'PAID' AND amount_due > 0`).Error; err != nil {
+				return err
+			}
+			return tx.Exec("UPDATE bookings SET amount_paid = amount_due WHERE payment_status = 'PAID'").Error
+		},
+	},
+	{
+		id: "20261024_booking_duration",
+		up: func(tx *gorm.DB) error {
+			// Bookings made before variants took the duration of the service.
+			return tx.Exec(`UPDATE bookings SET duration_minute = services.duration_minute
+				FROM services WHERE services.id = bookings.service_id AND bookings.duration_minute = 0`).Error
+		},
+	},
+	{
+		id: "20261025_service_search",
+		up: func(tx *gorm.DB) error {
+			// Full-text and trigram indexes behind /service/search. The
+			// document expression must match serviceDocumentSQL.
+			statements := []string{
+				"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+				`CREATE INDEX IF NOT EXISTS idx_services_search ON services USING GIN
+					(to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(description, '')))`,
+				"CREATE INDEX IF NOT EXISTS idx_services_name_trgm ON services USING GIN (name gin_trgm_ops)",
+				"CREATE INDEX IF NOT EXISTS idx_categories_name_trgm ON categories USING GIN (name gin_trgm_ops)",
+			}
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		id: "20261026_translation_search",
+		up: func(tx *gorm.DB) error {
+			// Let /service/search find services by their translated names.
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_service_translations_name_trgm ON service_translations USING GIN (name gin_trgm_ops)").Error
+		},
+	},
+	{
+		id: "20261027_service_versions",
+		up: func(tx *gorm.DB) error {
+			// Services start their history at the catalog entry they have
+			// now, and bookings made before snapshots are linked to it.
+			if err := tx.Exec(`INSERT INTO service_versions (id, service_id, version, name, duration_minute, price, currency, category_id, created_at)
+				SELECT uuid_generate_v4(), id, 1, name, duration_minute, price, currency, category_id, created_at
+				FROM services`).Error; err != nil {
+				return err
+			}
+			return tx.Exec(`UPDATE bookings SET service_version_id = service_versions.id, service_name = service_versions.name
+				FROM service_versions WHERE service_versions.service_id = bookings.service_id AND bookings.service_version_id IS NULL`).Error
+		},
+	},
+	{
+		id:                "20261028_branch_coordinates",
+		beforeAutoMigrate: true,
+		up: func(tx *gorm.DB) error {
+			// Coordinates used to be free text. Anything that is not a
+			// number in range becomes NULL, leaving the branch out of
+			// /branch/nearby until it is given real coordinates.
+			if !tx.Migrator().HasTable(&entity.Branch{}) {
+				return nil
+			}
+			columns := []struct {
+				name  string
+				limit int
+			}{{"latitude", 90}, {"longitude", 180}}
+			for _, column := range columns {
+				statement := fmt.Sprintf(`ALTER TABLE branches ALTER COLUMN %[1]s TYPE double precision USING
+					CASE WHEN trim(%[1]s) ~ '^[-+]?[0-9]+(\.[0-9]+)?$' THEN
+						CASE WHEN abs(trim(%[1]s)::double precision) <= %[2]d THEN trim(%[1]s)::double precision END
+					END`, column.name, column.limit)
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		id:                "20261029_branch_roles",
+		beforeAutoMigrate: true,
+		up: func(tx *gorm.DB) error {
+			// AutoMigrate keeps an existing check constraint as it is, so
+			// the role check is dropped to be recreated with the new roles.
+			if !tx.Migrator().HasTable(&entity.User{}) {
+				return nil
+			}
+			return tx.Exec("ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_role").Error
+		},
+	},
+	{
+		id:                "20261030_refund_processing",
+		beforeAutoMigrate: true,
+		up: func(tx *gorm.DB) error {
+			// Recreate the status check with PROCESSING.
+			if !tx.Migrator().HasTable(&entity.Refund{}) {
+				return nil
+			}
+			return tx.Exec("ALTER TABLE refunds DROP CONSTRAINT IF EXISTS chk_refunds_status").Error
+		},
+	},
+}
+
+// Models lists every entity managed by AutoMigrate.
+func Models() []interface{} {
+	return []interface{}{
+		&entity.Booking{},
+		&entity.Branch{},
+		&entity.Category{},
+		&entity.Service{},
+		&entity.User{},
+		&entity.BranchService{},
+		&entity.Promotion{},
+		&entity.PromotionRedemption{},
+		&entity.Package{},
+		&entity.PackageItem{},
+		&entity.CustomerPackage{},
+		&entity.CustomerPackageCredit{},
+		&entity.GiftCard{},
+		&entity.LedgerEntry{},
+		&entity.LoyaltyRule{},
+		&entity.PointsEntry{},
+		&entity.Invoice{},
+		&entity.InvoiceLine{},
+		&entity.InvoiceSequence{},
+		&entity.TaxRate{},
+		&entity.PricingRule{},
+		&entity.MembershipPlan{},
+		&entity.MembershipBenefit{},
+		&entity.Subscription{},
+		&entity.SubscriptionUsage{},
+		&entity.SubscriptionPayment{},
+		&entity.BookingPayment{},
+		&entity.RefundRule{},
+		&entity.Refund{},
+		&entity.RefundAllocation{},
+		&entity.ServiceVariant{},
+		&entity.ServiceAddOn{},
+		&entity.BookingAddOn{},
+		&entity.Image{},
+		&entity.ImageThumbnail{},
+		&entity.ServiceTranslation{},
+		&entity.CategoryTranslation{},
+		&entity.BranchTranslation{},
+		&entity.Notification{},
+		&entity.Product{},
+		&entity.BranchStock{},
+		&entity.StockMovement{},
+		&entity.ServiceConsumable{},
+		&entity.Review{},
+		&entity.ServiceVersion{},
+		&entity.FavoriteService{},
+		&entity.FavoriteBranch{},
+		&entity.BranchMember{},
+	}
+}
+
+// Migrate registers custom join tables, runs AutoMigrate and applies any
+// pending data migrations.
+func Migrate(db *gorm.DB) error {
+	if err := setupJoinTables(db); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return err
+	}
+
+	if err := runDataMigrations(db, true); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(Models()...); err != nil {
+		return err
+	}
+
+	return runDataMigrations(db, false)
+}
+
+func setupJoinTables(db *gorm.DB) error {
+	if err := db.SetupJoinTable(&entity.Service{}, "Branches", &entity.BranchService{}); err != nil {
+		return err
+	}
+	return db.SetupJoinTable(&entity.Branch{}, "Service", &entity.BranchService{})
+}
+
+func runDataMigrations(db *gorm.DB, beforeAutoMigrate bool) error {
+	for _, m := range dataMigrations {
+		if m.beforeAutoMigrate != beforeAutoMigrate {
+			continue
+		}
+
+		var count int64
+		if err := db.Model(&schemaMigration{}).Where("id = ?", m.id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{ID: m.id}).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package seeder
 
 import (
 	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/pkg/money"
 	"fmt"
 	"log"
 	"time"
@@ -109,12 +110,14 @@ func (s *DBSeeder) seedBranches() ([]entity.Branch, error) {
 
 	// Create 11 branches
 	for i := 1; i <= 11; i++ {
+		latitude := 40.7128 + float64(i)*0.01
+		longitude := -74.0060 + float64(i)*0.01
 		branch := entity.Branch{
 			ID:          uuid.New(),
 			Name:        fmt.Sprintf("Branch %d", i),
 			Location:    fmt.Sprintf("%d Main Street", i*100),
-			Longitude:   fmt.Sprintf("%f", 40.7128+float64(i)*0.01),
-			Latitude:    fmt.Sprintf("%f", -74.0060+float64(i)*0.01),
+			Latitude:    &latitude,
+			Longitude:   &longitude,
 			PhoneNumber: fmt.Sprintf("555-%04d", i),
 		}
 		branches = append(branches, branch)
@@ -132,7 +135,7 @@ func (s *DBSeeder) seedServices(categories []entity.Category, branches []entity.
 
 	// Create 20 services
 	for i := 1; i <= 20; i++ {
-		// Assign random price between 25 and 200
+		// Assign random price between 25,000 and 200,000 MMK
 		price := 25 + (i*8)%175
 		// Assign to random category
 		categoryIndex := (i - 1) % len(categories)
@@ -145,7 +148,8 @@ func (s *DBSeeder) seedServices(categories []entity.Category, branches []entity.
 			Name:           fmt.Sprintf("Service %d", i),
 			Description:    fmt.Sprintf("This is service number %d", i),
 			DurationMinute: 30,
-			Price:          price,
+			Price:          money.FromMajor(int64(price)*1000, money.DefaultCurrency),
+			Currency:       money.DefaultCurrency,
 			CategoryID:     categories[categoryIndex].ID,
 			Image:          fmt.Sprintf("service_%d.jpg", i),
 			IsActive:       true,
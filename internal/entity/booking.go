@@ -19,4 +19,48 @@ type Booking struct {
 	Service    Service   `json:"service" gorm:"foreignKey:ServiceID"`
 	Branch     Branch    `json:"branch" gorm:"foreignKey:BranchID"`
 	Note       *string   `json:"note" gorm:"type:text;default:''"`
+
+	// the variant and add-ons chosen, and the duration they add up to
+	VariantID      *uuid.UUID     `json:"variant_id" gorm:"type:uuid"`
+	VariantName    string         `json:"variant_name" gorm:"type:varchar(255)"`
+	AddOns         []BookingAddOn `json:"add_ons" gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE"`
+	DurationMinute int            `json:"duration_minute" gorm:"type:smallint;not null;default:0"`
+
+	// the catalog entry the booking was made against, kept so later changes
+	// to the service do not rewrite what the customer booked
+	ServiceVersionID *uuid.UUID      `json:"service_version_id" gorm:"type:uuid"`
+	ServiceVersion   *ServiceVersion `json:"service_version,omitempty" gorm:"foreignKey:ServiceVersionID"`
+	ServiceName      string          `json:"service_name" gorm:"type:varchar(255);not null;default:''"`
+
+	// price snapshot taken when the booking is made
+	Currency       string     `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	BasePrice      int64      `json:"base_price" gorm:"type:bigint;not null;default:0"`
+	PricingRuleID  *uuid.UUID `json:"pricing_rule_id" gorm:"type:uuid"`
+	ServicePrice   int64      `json:"service_price" gorm:"type:bigint;not null;default:0"`
+	AddOnsPrice    int64      `json:"add_ons_price" gorm:"type:bigint;not null;default:0"`
+	DiscountAmount int64      `json:"discount_amount" gorm:"type:bigint;not null;default:0"`
+	TotalPrice     int64      `json:"total_price" gorm:"type:bigint;not null;default:0"`
+	PromotionID    *uuid.UUID `json:"promotion_id" gorm:"type:uuid"`
+	PromoCode      *string    `json:"promo_code" gorm:"type:varchar(50)"`
+	SubscriptionID *uuid.UUID `json:"subscription_id" gorm:"type:uuid"`
+	MemberDiscount int64      `json:"member_discount" gorm:"type:bigint;not null;default:0"`
+	PointsRedeemed int64      `json:"points_redeemed" gorm:"type:bigint;not null;default:0"`
+	PointsDiscount int64      `json:"points_discount" gorm:"type:bigint;not null;default:0"`
+	Subtotal       int64      `json:"subtotal" gorm:"type:bigint;not null;default:0"`
+	TaxAmount      int64      `json:"tax_amount" gorm:"type:bigint;not null;default:0"`
+	TaxRate        int        `json:"tax_rate" gorm:"type:integer;not null;default:0"`
+	TaxInclusive   bool       `json:"tax_inclusive" gorm:"not null;default:false"`
+
+	// prepaid value redeemed on the booking
+	PackageCreditID *uuid.UUID `json:"package_credit_id" gorm:"type:uuid"`
+	// SubscriptionUsageID is set when the session is one included in a membership
+	SubscriptionUsageID *uuid.UUID `json:"subscription_usage_id" gorm:"type:uuid"`
+	GiftCardID          *uuid.UUID `json:"gift_card_id" gorm:"type:uuid"`
+	GiftCardAmount      int64      `json:"gift_card_amount" gorm:"type:bigint;not null;default:0"`
+	AmountDue           int64      `json:"amount_due" gorm:"type:bigint;not null;default:0"`
+
+	PaymentStatus  string     `json:"payment_status" gorm:"type:varchar(20);not null;default:UNPAID;check:payment_status IN ('UNPAID', 'PAID')"`
+	PaidAt         *time.Time `json:"paid_at"`
+	AmountPaid     int64      `json:"amount_paid" gorm:"type:bigint;not null;default:0"`
+	AmountRefunded int64      `json:"amount_refunded" gorm:"type:bigint;not null;default:0"`
 }
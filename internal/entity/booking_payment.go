@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PaymentMethodCard    = "CARD"
+	PaymentMethodCounter = "COUNTER"
+)
+
+// BookingPayment is money taken for a booking, either charged online through
+// the payment provider or taken at the counter. A deposit is a payment of
+// less than the amount due.
+type BookingPayment struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BookingID uuid.UUID `json:"booking_id" gorm:"type:uuid;not null;index"`
+	Amount    int64     `json:"amount" gorm:"type:bigint;not null"`
+	Currency  string    `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	Method    string    `json:"method" gorm:"type:varchar(20);not null;check:method IN ('CARD', 'COUNTER')"`
+	// ChargeID is the provider's reference of a CARD payment
+	ChargeID       *string   `json:"charge_id" gorm:"type:varchar(100);uniqueIndex"`
+	RefundedAmount int64     `json:"refunded_amount" gorm:"type:bigint;not null;default:0"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
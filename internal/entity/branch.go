@@ -4,17 +4,28 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Branch struct {
-	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	Name        string    `json:"name" gorm:"type:varchar(255);not null"`
-	Location    string    `json:"location" gorm:"type:varchar(50);not null"`
-	Longitude   string    `json:"longitude" gorm:"type:varchar(50)"`
-	Latitude    string    `json:"latitude" gorm:"type:varchar(50)"`
-	PhoneNumber string    `json:"phone_number" gorm:"type:varchar(20)"`
-	Service     []Service `json:"-" gorm:"many2many:branch_service;"`
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	IsActive    bool      `json:"is_active" gorm:"default:true"`
+	ID           uuid.UUID           `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name         string              `json:"name" gorm:"type:varchar(255);not null"`
+	Location     string              `json:"location" gorm:"type:varchar(50);not null"`
+	Longitude    *float64            `json:"longitude" gorm:"type:double precision"`
+	Latitude     *float64            `json:"latitude" gorm:"type:double precision"`
+	PhoneNumber  string              `json:"phone_number" gorm:"type:varchar(20)"`
+	Image        string              `json:"image" gorm:"type:text"`
+	ImageID      *uuid.UUID          `json:"image_id" gorm:"type:uuid"`
+	ImageFile    *Image              `json:"image_file,omitempty" gorm:"foreignKey:ImageID;constraint:OnDelete:SET NULL"`
+	Service      []Service           `json:"-" gorm:"many2many:branch_service;"`
+	Translations []BranchTranslation `json:"-" gorm:"foreignKey:BranchID;constraint:OnDelete:CASCADE"`
+	CreatedAt    time.Time           `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time           `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt      `json:"deleted_at" gorm:"index"`
+	IsActive     bool                `json:"is_active" gorm:"default:true"`
+
+	// Rating summarises the approved reviews of services done at the branch.
+	Rating *RatingSummary `json:"rating,omitempty" gorm:"-"`
+	// DistanceKm is how far the branch is from the point of a nearby search.
+	DistanceKm *float64 `json:"distance_km,omitempty" gorm:"-"`
 }
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BranchMember assigns a STAFF or BRANCH_MANAGER user to a branch they work
+// at. What they see and manage is limited to their branches.
+type BranchMember struct {
+	BranchID  uuid.UUID `json:"branch_id" gorm:"type:uuid;primaryKey"`
+	Branch    *Branch   `json:"branch,omitempty" gorm:"foreignKey:BranchID;constraint:OnDelete:CASCADE"`
+	UserID    string    `json:"user_id" gorm:"type:varchar(36);primaryKey;index"`
+	User      *User     `json:"user,omitempty" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
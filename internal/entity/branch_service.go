@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BranchService is how a branch offers a service: whether it can be booked
+// there and the price, duration and capacity that apply at the branch. The
+// overrides replace the service's own price and duration, and shift those of
+// every variant by the same amount, see VariantPrice and VariantDuration.
+type BranchService struct {
+	BranchID         uuid.UUID `json:"branch_id" gorm:"type:uuid;primaryKey"`
+	ServiceID        uuid.UUID `json:"service_id" gorm:"type:uuid;primaryKey"`
+	IsActive         bool      `json:"is_active" gorm:"not null;default:true"`
+	PriceOverride    *int64    `json:"price_override" gorm:"type:bigint"`
+	DurationOverride *int      `json:"duration_override" gorm:"type:smallint"`
+	// Capacity is how many bookings of the service the branch takes in one
+	// slot. Without it only the branch-wide slot limit applies.
+	Capacity  *int      `json:"capacity" gorm:"type:smallint"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (BranchService) TableName() string {
+	return "branch_service"
+}
+
+// EffectivePrice returns the override when set, otherwise the service price.
+func (bs BranchService) EffectivePrice(servicePrice int64) int64 {
+	if bs.PriceOverride != nil {
+		return *bs.PriceOverride
+	}
+	return servicePrice
+}
+
+// EffectiveDuration returns the override when set, otherwise the service
+// duration.
+func (bs BranchService) EffectiveDuration(serviceDuration int) int {
+	if bs.DurationOverride != nil {
+		return *bs.DurationOverride
+	}
+	return serviceDuration
+}
+
+// VariantPrice returns the price of a variant at the branch: the variant
+// price moved by as much as the override moves the service price, never
+// below zero.
+func (bs BranchService) VariantPrice(servicePrice, variantPrice int64) int64 {
+	return max(variantPrice+bs.EffectivePrice(servicePrice)-servicePrice, 0)
+}
+
+// VariantDuration returns the duration of a variant at the branch: the
+// variant duration moved by as much as the override moves the service
+// duration, never below a minute.
+func (bs BranchService) VariantDuration(serviceDuration, variantDuration int) int {
+	return max(variantDuration+bs.EffectiveDuration(serviceDuration)-serviceDuration, 1)
+}
+
+// IsFull reports whether the slot already holds as many bookings of the
+// service as the branch takes.
+func (bs BranchService) IsFull(booked int64) bool {
+	return bs.Capacity != nil && booked >= int64(*bs.Capacity)
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "testing"
+
+func TestBranchServiceVariants(t *testing.T) {
+	price := func(p int64) *int64 { return &p }
+	minutes := func(m int) *int { return &m }
+
+	tests := []struct {
+		name            string
+		branch          BranchService
+		price, variant  int64
+		minutes, length int
+		wantPrice       int64
+		wantMinutes     int
+	}{
+		{"no overrides", BranchService{}, 20000, 25000, 60, 90, 25000, 90},
+		{"dearer and longer", BranchService{PriceOverride: price(23000), DurationOverride: minutes(75)}, 20000, 25000, 60, 90, 28000, 105},
+		{"cheaper and shorter", BranchService{PriceOverride: price(15000), DurationOverride: minutes(45)}, 20000, 25000, 60, 90, 20000, 75},
+		{"cut below zero", BranchService{PriceOverride: price(0), DurationOverride: minutes(10)}, 20000, 8000, 60, 30, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.branch.VariantPrice(tt.price, tt.variant); got != tt.wantPrice {
+				t.Errorf("VariantPrice(%d, %d) = %d, want %d", tt.price, tt.variant, got, tt.wantPrice)
+			}
+			if got := tt.branch.VariantDuration(tt.minutes, tt.length); got != tt.wantMinutes {
+				t.Errorf("VariantDuration(%d, %d) = %d, want %d", tt.minutes, tt.length, got, tt.wantMinutes)
+			}
+		})
+	}
+}
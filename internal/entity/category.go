@@ -4,11 +4,23 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// Category groups services into a menu. Categories nest under a parent,
+// e.g. Hair > Coloring > Balayage, and are shown in SortOrder among their
+// siblings. A hidden category is left out of the menu with everything under it.
 type Category struct {
-	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	Name      string    `json:"name" gorm:"type:varchar(255);not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID           uuid.UUID             `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name         string                `json:"name" gorm:"type:varchar(255);not null"`
+	ParentID     *uuid.UUID            `json:"parent_id" gorm:"type:uuid;index"`
+	SortOrder    int                   `json:"sort_order" gorm:"not null;default:0"`
+	Icon         string                `json:"icon" gorm:"type:varchar(255)"`
+	Image        string                `json:"image" gorm:"type:text"`
+	IsHidden     bool                  `json:"is_hidden" gorm:"not null;default:false"`
+	Children     []Category            `json:"children,omitempty" gorm:"foreignKey:ParentID;constraint:OnDelete:RESTRICT"`
+	Translations []CategoryTranslation `json:"-" gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE"`
+	CreatedAt    time.Time             `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time             `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt        `json:"deleted_at" gorm:"index"`
 }
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FavoriteService is a service a customer saved to book again.
+type FavoriteService struct {
+	UserID    string    `json:"user_id" gorm:"type:varchar(36);primaryKey"`
+	ServiceID uuid.UUID `json:"service_id" gorm:"type:uuid;primaryKey"`
+	Service   Service   `json:"service" gorm:"foreignKey:ServiceID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// FavoriteBranch is a branch a customer saved to book at again.
+type FavoriteBranch struct {
+	UserID    string    `json:"user_id" gorm:"type:varchar(36);primaryKey"`
+	BranchID  uuid.UUID `json:"branch_id" gorm:"type:uuid;primaryKey"`
+	Branch    Branch    `json:"branch" gorm:"foreignKey:BranchID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Favorites are a customer's saved services and branches, most recently
+// saved first.
+type Favorites struct {
+	Services []Service `json:"services"`
+	Branches []Branch  `json:"branches"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GiftCard holds a stored balance in minor units that can pay for bookings.
+type GiftCard struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Code            string     `json:"code" gorm:"type:varchar(20);uniqueIndex;not null"`
+	InitialBalance  int64      `json:"initial_balance" gorm:"type:bigint;not null"`
+	Balance         int64      `json:"balance" gorm:"type:bigint;not null"`
+	Currency        string     `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	PurchaserUserID *string    `json:"purchaser_user_id" gorm:"type:varchar(36)"`
+	RecipientName   string     `json:"recipient_name" gorm:"type:varchar(255)"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+	IsActive        bool       `json:"is_active" gorm:"default:true"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
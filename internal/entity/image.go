@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Image is an uploaded picture kept in storage with its thumbnails. Services
+// and branches point at the image they display.
+type Image struct {
+	ID          uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Key         string           `json:"-" gorm:"type:varchar(255);not null;uniqueIndex"`
+	URL         string           `json:"url" gorm:"type:text;not null"`
+	ContentType string           `json:"content_type" gorm:"type:varchar(50);not null"`
+	Size        int64            `json:"size" gorm:"not null"`
+	Width       int              `json:"width" gorm:"not null"`
+	Height      int              `json:"height" gorm:"not null"`
+	Thumbnails  []ImageThumbnail `json:"thumbnails" gorm:"foreignKey:ImageID;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time        `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// ImageThumbnail is a scaled-down copy of an image, e.g. "small".
+type ImageThumbnail struct {
+	ID      uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ImageID uuid.UUID `json:"image_id" gorm:"type:uuid;not null;index"`
+	Name    string    `json:"name" gorm:"type:varchar(20);not null"`
+	Key     string    `json:"-" gorm:"type:varchar(255);not null"`
+	URL     string    `json:"url" gorm:"type:text;not null"`
+	Width   int       `json:"width" gorm:"not null"`
+	Height  int       `json:"height" gorm:"not null"`
+}
+
+// Keys lists every stored object of the image.
+func (i *Image) Keys() []string {
+	keys := []string{i.Key}
+	for _, thumbnail := range i.Thumbnails {
+		keys = append(keys, thumbnail.Key)
+	}
+	return keys
+}
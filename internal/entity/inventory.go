@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StockMovementRestock     = "RESTOCK"
+	StockMovementSale        = "SALE"
+	StockMovementConsumption = "CONSUMPTION"
+	StockMovementAdjustment  = "ADJUSTMENT"
+)
+
+// Product is an item the branches stock, sold over the counter when
+// IsRetail, used up by services, or both. Stock is counted in Unit, e.g.
+// ml or pieces.
+type Product struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name        string    `json:"name" gorm:"type:varchar(255);not null"`
+	SKU         *string   `json:"sku" gorm:"type:varchar(64);uniqueIndex"`
+	Description string    `json:"description" gorm:"type:text"`
+	Unit        string    `json:"unit" gorm:"type:varchar(20);not null;default:piece"`
+	Price       int64     `json:"price" gorm:"type:bigint;not null;default:0"`
+	Currency    string    `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	IsRetail    bool      `json:"is_retail" gorm:"not null;default:false"`
+	IsActive    bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BranchStock is how much of a product a branch holds. It appears in the
+// low-stock report once Quantity falls to LowStockLevel; a level of 0
+// leaves it out.
+type BranchStock struct {
+	BranchID      uuid.UUID `json:"branch_id" gorm:"type:uuid;primaryKey"`
+	Branch        *Branch   `json:"branch,omitempty" gorm:"foreignKey:BranchID"`
+	ProductID     uuid.UUID `json:"product_id" gorm:"type:uuid;primaryKey"`
+	Product       *Product  `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	Quantity      int64     `json:"quantity" gorm:"type:bigint;not null;default:0"`
+	LowStockLevel int64     `json:"low_stock_level" gorm:"type:bigint;not null;default:0"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// StockMovement is one change to a branch's stock of a product: positive for
+// stock coming in, negative for stock sold or used. A booking consumes each
+// product at most once.
+type StockMovement struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BranchID      uuid.UUID  `json:"branch_id" gorm:"type:uuid;not null;index"`
+	ProductID     uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_stock_booking_movement"`
+	Product       *Product   `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	MovementType  string     `json:"movement_type" gorm:"type:varchar(20);not null;uniqueIndex:idx_stock_booking_movement;check:movement_type IN ('RESTOCK', 'SALE', 'CONSUMPTION', 'ADJUSTMENT')"`
+	Quantity      int64      `json:"quantity" gorm:"type:bigint;not null"`
+	QuantityAfter int64      `json:"quantity_after" gorm:"type:bigint;not null"`
+	BookingID     *uuid.UUID `json:"booking_id" gorm:"type:uuid;uniqueIndex:idx_stock_booking_movement"`
+	Note          string     `json:"note" gorm:"type:text"`
+	CreatedBy     *string    `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// ServiceConsumable is how much of a product one session of a service uses.
+type ServiceConsumable struct {
+	ServiceID uuid.UUID `json:"service_id" gorm:"type:uuid;primaryKey"`
+	ProductID uuid.UUID `json:"product_id" gorm:"type:uuid;primaryKey"`
+	Product   *Product  `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	Quantity  int64     `json:"quantity" gorm:"type:bigint;not null"`
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	InvoiceLineService  = "SERVICE"
+	InvoiceLineDiscount = "DISCOUNT"
+)
+
+// What covered the prepaid part of an invoice.
+const (
+	InvoicePrepaidPackage    = "PACKAGE"
+	InvoicePrepaidMembership = "MEMBERSHIP"
+)
+
+// Invoice is the receipt issued for a completed or paid booking. Branch and
+// customer details are copied so the invoice does not change afterwards.
+type Invoice struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Number         string    `json:"number" gorm:"type:varchar(30);uniqueIndex;not null"`
+	BranchID       uuid.UUID `json:"branch_id" gorm:"type:uuid;not null;uniqueIndex:idx_invoice_branch_sequence"`
+	Sequence       int64     `json:"sequence" gorm:"type:bigint;not null;uniqueIndex:idx_invoice_branch_sequence"`
+	BookingID      uuid.UUID `json:"booking_id" gorm:"type:uuid;uniqueIndex;not null"`
+	UserID         string    `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	BranchName     string    `json:"branch_name" gorm:"type:varchar(255);not null"`
+	BranchLocation string    `json:"branch_location" gorm:"type:varchar(255)"`
+	BranchPhone    string    `json:"branch_phone" gorm:"type:varchar(20)"`
+	CustomerName   string    `json:"customer_name" gorm:"type:varchar(255)"`
+	CustomerEmail  string    `json:"customer_email" gorm:"type:varchar(255)"`
+	Currency       string    `json:"currency" gorm:"type:char(3);not null"`
+	Subtotal       int64     `json:"subtotal" gorm:"type:bigint;not null"`
+	DiscountAmount int64     `json:"discount_amount" gorm:"type:bigint;not null;default:0"`
+	TaxAmount      int64     `json:"tax_amount" gorm:"type:bigint;not null;default:0"`
+	TaxRate        int       `json:"tax_rate" gorm:"type:integer;not null;default:0"`
+	TaxInclusive   bool      `json:"tax_inclusive" gorm:"not null;default:false"`
+	Total          int64     `json:"total" gorm:"type:bigint;not null"`
+	GiftCardAmount int64     `json:"gift_card_amount" gorm:"type:bigint;not null;default:0"`
+	// PrepaidAmount is the part of the total covered by a package credit or
+	// a session included in a membership, as PrepaidBy says
+	PrepaidAmount int64  `json:"prepaid_amount" gorm:"type:bigint;not null;default:0"`
+	PrepaidBy     string `json:"prepaid_by,omitempty" gorm:"type:varchar(20)"`
+	AmountPaid    int64  `json:"amount_paid" gorm:"type:bigint;not null;default:0"`
+	// AmountDue is what is left to pay after the gift card, the prepaid
+	// value and the payments
+	AmountDue int64         `json:"amount_due" gorm:"type:bigint;not null;default:0"`
+	IssuedAt  time.Time     `json:"issued_at" gorm:"not null"`
+	Lines     []InvoiceLine `json:"lines" gorm:"foreignKey:InvoiceID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time     `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type InvoiceLine struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	InvoiceID   uuid.UUID `json:"invoice_id" gorm:"type:uuid;not null;index"`
+	Position    int       `json:"position" gorm:"type:smallint;not null"`
+	LineType    string    `json:"line_type" gorm:"type:varchar(20);not null;check:line_type IN ('SERVICE', 'DISCOUNT')"`
+	Description string    `json:"description" gorm:"type:varchar(255);not null"`
+	Quantity    int       `json:"quantity" gorm:"type:smallint;not null;default:1"`
+	UnitPrice   int64     `json:"unit_price" gorm:"type:bigint;not null"`
+	Amount      int64     `json:"amount" gorm:"type:bigint;not null"`
+}
+
+// InvoiceSequence holds the last invoice number issued by a branch. The row
+// is locked while an invoice is created so numbers are gap-free.
+type InvoiceSequence struct {
+	BranchID   uuid.UUID `gorm:"type:uuid;primary_key"`
+	LastNumber int64     `gorm:"type:bigint;not null;default:0"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LedgerAccountPackageCredit = "PACKAGE_CREDIT"
+	LedgerAccountGiftCard      = "GIFT_CARD"
+
+	LedgerEntryPurchase = "PURCHASE"
+	LedgerEntryRedeem   = "REDEEM"
+	LedgerEntryRestore  = "RESTORE"
+	LedgerEntryExpire   = "EXPIRE"
+)
+
+// LedgerEntry records every movement of package credits (in sessions) and
+// gift card balances (in minor units). Amount is signed.
+type LedgerEntry struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	AccountType  string     `json:"account_type" gorm:"type:varchar(20);not null;index:idx_ledger_account;check:account_type IN ('PACKAGE_CREDIT', 'GIFT_CARD')"`
+	AccountID    uuid.UUID  `json:"account_id" gorm:"type:uuid;not null;index:idx_ledger_account"`
+	UserID       *string    `json:"user_id" gorm:"type:varchar(36);index"`
+	BookingID    *uuid.UUID `json:"booking_id" gorm:"type:uuid;index"`
+	EntryType    string     `json:"entry_type" gorm:"type:varchar(20);not null;check:entry_type IN ('PURCHASE', 'REDEEM', 'RESTORE', 'EXPIRE')"`
+	Amount       int64      `json:"amount" gorm:"type:bigint;not null"`
+	BalanceAfter int64      `json:"balance_after" gorm:"type:bigint;not null"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
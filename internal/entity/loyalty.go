@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PointsEntryEarn    = "EARN"
+	PointsEntryRedeem  = "REDEEM"
+	PointsEntryRestore = "RESTORE"
+	PointsEntryExpire  = "EXPIRE"
+	PointsEntryAdjust  = "ADJUST"
+)
+
+// LoyaltyRule sets how many points a completed booking earns: Points for
+// every SpendAmount minor units paid. A rule without a category is the default.
+type LoyaltyRule struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	CategoryID  *uuid.UUID `json:"category_id" gorm:"type:uuid;uniqueIndex"`
+	Category    *Category  `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	Points      int64      `json:"points" gorm:"type:bigint;not null"`
+	SpendAmount int64      `json:"spend_amount" gorm:"type:bigint;not null"`
+	IsActive    bool       `json:"is_active" gorm:"default:true"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// PointsEntry is one movement in a user's points ledger. Positive entries
+// are lots that expire on ExpiresAt; Remaining tracks how much of the lot is
+// still unspent so redemptions and expiry can consume lots oldest first.
+type PointsEntry struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID       string     `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	EntryType    string     `json:"entry_type" gorm:"type:varchar(20);not null;uniqueIndex:idx_points_booking_entry;check:entry_type IN ('EARN', 'REDEEM', 'RESTORE', 'EXPIRE', 'ADJUST')"`
+	Points       int64      `json:"points" gorm:"type:bigint;not null"`
+	Remaining    int64      `json:"-" gorm:"type:bigint;not null;default:0"`
+	BalanceAfter int64      `json:"balance_after" gorm:"type:bigint;not null"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	BookingID    *uuid.UUID `json:"booking_id" gorm:"type:uuid;uniqueIndex:idx_points_booking_entry"`
+	Reason       string     `json:"reason" gorm:"type:text"`
+	CreatedBy    *string    `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SubscriptionActive    = "ACTIVE"
+	SubscriptionPastDue   = "PAST_DUE"
+	SubscriptionCancelled = "CANCELLED"
+	SubscriptionExpired   = "EXPIRED"
+
+	SubscriptionPaymentSucceeded = "SUCCEEDED"
+	SubscriptionPaymentFailed    = "FAILED"
+)
+
+// MembershipPlan is a recurring membership, e.g. "Gold: 10% off and one
+// blow-dry a month".
+type MembershipPlan struct {
+	ID                  uuid.UUID           `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name                string              `json:"name" gorm:"type:varchar(255);not null"`
+	Description         string              `json:"description" gorm:"type:text"`
+	Price               int64               `json:"price" gorm:"type:bigint;not null"`
+	Currency            string              `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	BillingPeriodMonths int                 `json:"billing_period_months" gorm:"not null;default:1"`
+	DiscountPercent     int                 `json:"discount_percent" gorm:"not null;default:0"`
+	IsActive            bool                `json:"is_active" gorm:"default:true"`
+	Benefits            []MembershipBenefit `json:"benefits" gorm:"foreignKey:PlanID;constraint:OnDelete:CASCADE"`
+	CreatedAt           time.Time           `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time           `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// MembershipBenefit is the number of sessions of one service included in
+// every billing period of a plan.
+type MembershipBenefit struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PlanID    uuid.UUID `json:"plan_id" gorm:"type:uuid;not null;index"`
+	ServiceID uuid.UUID `json:"service_id" gorm:"type:uuid;not null"`
+	Service   Service   `json:"service" gorm:"foreignKey:ServiceID"`
+	Quantity  int       `json:"quantity" gorm:"not null"`
+}
+
+// Subscription is a user's membership of a plan. CurrentPeriodEnd is the
+// renewal date.
+type Subscription struct {
+	ID                 uuid.UUID           `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID             string              `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	PlanID             uuid.UUID           `json:"plan_id" gorm:"type:uuid;not null"`
+	Plan               MembershipPlan      `json:"plan" gorm:"foreignKey:PlanID"`
+	Status             string              `json:"status" gorm:"type:varchar(20);not null;default:ACTIVE;check:status IN ('ACTIVE', 'PAST_DUE', 'CANCELLED', 'EXPIRED')"`
+	CurrentPeriodStart time.Time           `json:"current_period_start" gorm:"not null"`
+	CurrentPeriodEnd   time.Time           `json:"current_period_end" gorm:"not null;index"`
+	CancelAtPeriodEnd  bool                `json:"cancel_at_period_end" gorm:"not null;default:false"`
+	LastChargeID       string              `json:"last_charge_id" gorm:"type:varchar(100)"`
+	Usages             []SubscriptionUsage `json:"usages" gorm:"foreignKey:SubscriptionID"`
+	CreatedAt          time.Time           `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time           `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// IsCurrent reports whether the subscription grants its benefits at now.
+func (s *Subscription) IsCurrent(now time.Time) bool {
+	return s.Status == SubscriptionActive && !now.Before(s.CurrentPeriodStart) && now.Before(s.CurrentPeriodEnd)
+}
+
+// SubscriptionUsage counts the included sessions of one service used in one
+// billing period.
+type SubscriptionUsage struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	SubscriptionID uuid.UUID `json:"subscription_id" gorm:"type:uuid;not null;uniqueIndex:idx_subscription_usage_period"`
+	ServiceID      uuid.UUID `json:"service_id" gorm:"type:uuid;not null;uniqueIndex:idx_subscription_usage_period"`
+	PeriodStart    time.Time `json:"period_start" gorm:"not null;uniqueIndex:idx_subscription_usage_period"`
+	Included       int       `json:"included" gorm:"not null"`
+	Used           int       `json:"used" gorm:"not null;default:0"`
+}
+
+// SubscriptionPayment is one charge attempt for a billing period.
+type SubscriptionPayment struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	SubscriptionID uuid.UUID `json:"subscription_id" gorm:"type:uuid;not null;index"`
+	Amount         int64     `json:"amount" gorm:"type:bigint;not null"`
+	Currency       string    `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	PeriodStart    time.Time `json:"period_start" gorm:"not null"`
+	PeriodEnd      time.Time `json:"period_end" gorm:"not null"`
+	Status         string    `json:"status" gorm:"type:varchar(20);not null;check:status IN ('SUCCEEDED', 'FAILED')"`
+	ChargeID       string    `json:"charge_id" gorm:"type:varchar(100)"`
+	FailureReason  string    `json:"failure_reason" gorm:"type:text"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationBookingCancelled = "BOOKING_CANCELLED"
+)
+
+// Notification is a message for a customer shown in the app, e.g. that the
+// salon cancelled one of their bookings.
+type Notification struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID    string     `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	Type      string     `json:"type" gorm:"type:varchar(30);not null"`
+	BookingID *uuid.UUID `json:"booking_id" gorm:"type:uuid"`
+	Message   string     `json:"message" gorm:"type:text;not null"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CustomerPackageActive  = "ACTIVE"
+	CustomerPackageExpired = "EXPIRED"
+)
+
+// Package is a prepaid bundle of sessions, e.g. "5 facials".
+type Package struct {
+	ID           uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name         string        `json:"name" gorm:"type:varchar(255);not null"`
+	Description  string        `json:"description" gorm:"type:text"`
+	Price        int64         `json:"price" gorm:"type:bigint;not null"`
+	Currency     string        `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	ValidityDays int           `json:"validity_days" gorm:"not null"`
+	IsActive     bool          `json:"is_active" gorm:"default:true"`
+	Items        []PackageItem `json:"items" gorm:"foreignKey:PackageID;constraint:OnDelete:CASCADE"`
+	CreatedAt    time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// PackageItem is the number of sessions of one service a package holds.
+type PackageItem struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PackageID uuid.UUID `json:"package_id" gorm:"type:uuid;not null;index"`
+	ServiceID uuid.UUID `json:"service_id" gorm:"type:uuid;not null"`
+	Service   Service   `json:"service" gorm:"foreignKey:ServiceID"`
+	Sessions  int       `json:"sessions" gorm:"not null"`
+}
+
+// CustomerPackage is a package sold to a user, holding its session credits.
+type CustomerPackage struct {
+	ID          uuid.UUID               `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID      string                  `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	PackageID   uuid.UUID               `json:"package_id" gorm:"type:uuid;not null"`
+	PackageName string                  `json:"package_name" gorm:"type:varchar(255);not null"`
+	PricePaid   int64                   `json:"price_paid" gorm:"type:bigint;not null"`
+	Currency    string                  `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	Status      string                  `json:"status" gorm:"type:varchar(20);default:ACTIVE;check:status IN ('ACTIVE', 'EXPIRED')"`
+	ExpiresAt   time.Time               `json:"expires_at" gorm:"not null"`
+	Credits     []CustomerPackageCredit `json:"credits" gorm:"foreignKey:CustomerPackageID"`
+	CreatedAt   time.Time               `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time               `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// CustomerPackageCredit is the remaining sessions of one service in a
+// purchased package.
+type CustomerPackageCredit struct {
+	ID                uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	CustomerPackageID uuid.UUID `json:"customer_package_id" gorm:"type:uuid;not null;uniqueIndex:idx_customer_package_service"`
+	ServiceID         uuid.UUID `json:"service_id" gorm:"type:uuid;not null;uniqueIndex:idx_customer_package_service"`
+	Total             int       `json:"total" gorm:"not null"`
+	Remaining         int       `json:"remaining" gorm:"not null"`
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PricingRule adjusts the price of a booking by when it takes place and how
+// far ahead it is made. Empty scopes match everything. AdjustmentValue is a
+// whole percentage for PERCENTAGE rules and minor units for FIXED ones;
+// negative values lower the price.
+type PricingRule struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name            string     `json:"name" gorm:"type:varchar(255);not null"`
+	ServiceID       *uuid.UUID `json:"service_id" gorm:"type:uuid;index"`
+	CategoryID      *uuid.UUID `json:"category_id" gorm:"type:uuid;index"`
+	BranchID        *uuid.UUID `json:"branch_id" gorm:"type:uuid;index"`
+	DaysOfWeek      string     `json:"days_of_week" gorm:"type:varchar(30)"`
+	StartTime       string     `json:"start_time" gorm:"type:varchar(5)"`
+	EndTime         string     `json:"end_time" gorm:"type:varchar(5)"`
+	MinLeadHours    *int       `json:"min_lead_hours"`
+	MaxLeadHours    *int       `json:"max_lead_hours"`
+	AdjustmentType  string     `json:"adjustment_type" gorm:"type:varchar(20);not null;check:adjustment_type IN ('PERCENTAGE', 'FIXED')"`
+	AdjustmentValue int64      `json:"adjustment_value" gorm:"type:bigint;not null"`
+	Priority        int        `json:"priority" gorm:"not null;default:0"`
+	IsActive        bool       `json:"is_active" gorm:"default:true"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Matches reports whether the rule applies to the service at the branch for
+// an appointment at `at`, booked at `now`.
+func (r PricingRule) Matches(serviceID, categoryID, branchID uuid.UUID, at, now time.Time) bool {
+	if r.ServiceID != nil && *r.ServiceID != serviceID {
+		return false
+	}
+	if r.CategoryID != nil && *r.CategoryID != categoryID {
+		return false
+	}
+	if r.BranchID != nil && *r.BranchID != branchID {
+		return false
+	}
+
+	if r.DaysOfWeek != "" {
+		day := strings.ToUpper(at.Weekday().String()[:3])
+		if !strings.Contains(","+r.DaysOfWeek+",", ","+day+",") {
+			return false
+		}
+	}
+
+	clock := at.Format("15:04")
+	if r.StartTime != "" && clock < r.StartTime {
+		return false
+	}
+	if r.EndTime != "" && clock >= r.EndTime {
+		return false
+	}
+
+	lead := at.Sub(now).Hours()
+	if r.MinLeadHours != nil && lead < float64(*r.MinLeadHours) {
+		return false
+	}
+	if r.MaxLeadHours != nil && lead > float64(*r.MaxLeadHours) {
+		return false
+	}
+
+	return true
+}
+
+// Apply returns price adjusted by the rule, never below zero.
+func (r PricingRule) Apply(price int64) int64 {
+	switch r.AdjustmentType {
+	case DiscountTypePercentage:
+		price += price * r.AdjustmentValue / 100
+	case DiscountTypeFixed:
+		price += r.AdjustmentValue
+	}
+
+	if price < 0 {
+		return 0
+	}
+	return price
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPricingRuleMatches(t *testing.T) {
+	serviceID, categoryID, branchID := uuid.New(), uuid.New(), uuid.New()
+	other := uuid.New()
+	hours := func(h int) *int { return &h }
+
+	// Monday 19 October 2026, booked two days ahead
+	at := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+	now := at.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name string
+		rule PricingRule
+		at   time.Time
+		want bool
+	}{
+		{"no conditions", PricingRule{}, at, true},
+		{"same service", PricingRule{ServiceID: &serviceID}, at, true},
+		{"other service", PricingRule{ServiceID: &other}, at, false},
+		{"other category", PricingRule{CategoryID: &other}, at, false},
+		{"other branch", PricingRule{BranchID: &other}, at, false},
+		{"all scopes", PricingRule{ServiceID: &serviceID, CategoryID: &categoryID, BranchID: &branchID}, at, true},
+
+		{"listed weekday", PricingRule{DaysOfWeek: "SAT,MON"}, at, true},
+		{"unlisted weekday", PricingRule{DaysOfWeek: "TUE,WED"}, at, false},
+		{"weekday at midnight", PricingRule{DaysOfWeek: "MON"}, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), true},
+		{"next weekday at midnight", PricingRule{DaysOfWeek: "MON"}, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), false},
+
+		{"at window start", PricingRule{StartTime: "18:00", EndTime: "21:00"}, at, true},
+		{"before window start", PricingRule{StartTime: "18:01"}, at, false},
+		{"a minute before window end", PricingRule{EndTime: "18:01"}, at, true},
+		{"at window end", PricingRule{EndTime: "18:00"}, at, false},
+
+		{"exactly the minimum lead", PricingRule{MinLeadHours: hours(48)}, at, true},
+		{"under the minimum lead", PricingRule{MinLeadHours: hours(49)}, at, false},
+		{"exactly the maximum lead", PricingRule{MaxLeadHours: hours(48)}, at, true},
+		{"over the maximum lead", PricingRule{MaxLeadHours: hours(47)}, at, false},
+		{"within the lead bounds", PricingRule{MinLeadHours: hours(24), MaxLeadHours: hours(72)}, at, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(serviceID, categoryID, branchID, tt.at, now); got != tt.want {
+				t.Errorf("Matches at %s = %v, want %v", tt.at.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPricingRuleApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  PricingRule
+		price int64
+		want  int64
+	}{
+		{"percentage surcharge", PricingRule{AdjustmentType: DiscountTypePercentage, AdjustmentValue: 20}, 10000, 12000},
+		{"percentage discount", PricingRule{AdjustmentType: DiscountTypePercentage, AdjustmentValue: -15}, 10000, 8500},
+		{"percentage rounds toward zero", PricingRule{AdjustmentType: DiscountTypePercentage, AdjustmentValue: 10}, 999, 1098},
+		{"whole price off", PricingRule{AdjustmentType: DiscountTypePercentage, AdjustmentValue: -100}, 10000, 0},
+		{"fixed surcharge", PricingRule{AdjustmentType: DiscountTypeFixed, AdjustmentValue: 2500}, 10000, 12500},
+		{"fixed discount", PricingRule{AdjustmentType: DiscountTypeFixed, AdjustmentValue: -2500}, 10000, 7500},
+		{"fixed discount floors at zero", PricingRule{AdjustmentType: DiscountTypeFixed, AdjustmentValue: -12000}, 10000, 0},
+		{"percentage discount floors at zero", PricingRule{AdjustmentType: DiscountTypePercentage, AdjustmentValue: -150}, 10000, 0},
+		{"unknown type", PricingRule{AdjustmentType: "OTHER", AdjustmentValue: 500}, 10000, 10000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Apply(tt.price); got != tt.want {
+				t.Errorf("Apply(%d) = %d, want %d", tt.price, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DiscountTypePercentage = "PERCENTAGE"
+	DiscountTypeFixed      = "FIXED"
+)
+
+// Promotion is a promo code with a discount rule. DiscountValue is a whole
+// percentage for PERCENTAGE promotions and minor units for FIXED ones.
+// Empty Services, Categories and Branches mean the promotion is unrestricted.
+type Promotion struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Code           string     `json:"code" gorm:"type:varchar(50);uniqueIndex;not null"`
+	Name           string     `json:"name" gorm:"type:varchar(255);not null"`
+	Description    string     `json:"description" gorm:"type:text"`
+	DiscountType   string     `json:"discount_type" gorm:"type:varchar(20);not null;check:discount_type IN ('PERCENTAGE', 'FIXED')"`
+	DiscountValue  int64      `json:"discount_value" gorm:"type:bigint;not null"`
+	MaxDiscount    *int64     `json:"max_discount" gorm:"type:bigint"`
+	Currency       string     `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	MaxUses        *int       `json:"max_uses"`
+	MaxUsesPerUser *int       `json:"max_uses_per_user"`
+	UsedCount      int        `json:"used_count" gorm:"not null;default:0"`
+	IsActive       bool       `json:"is_active" gorm:"default:true"`
+	Services       []Service  `json:"services" gorm:"many2many:promotion_service;"`
+	Categories     []Category `json:"categories" gorm:"many2many:promotion_category;"`
+	Branches       []Branch   `json:"branches" gorm:"many2many:promotion_branch;"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// PromotionRedemption records a promo code used on a booking.
+type PromotionRedemption struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	PromotionID    uuid.UUID `json:"promotion_id" gorm:"type:uuid;not null;index"`
+	UserID         string    `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	BookingID      uuid.UUID `json:"booking_id" gorm:"type:uuid;not null;uniqueIndex"`
+	DiscountAmount int64     `json:"discount_amount" gorm:"type:bigint;not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RefundPending = "PENDING"
+	// RefundProcessing is an approved refund being paid out
+	RefundProcessing = "PROCESSING"
+	RefundSucceeded  = "SUCCEEDED"
+	RefundFailed     = "FAILED"
+	RefundRejected   = "REJECTED"
+)
+
+// RefundRule is the share of what was paid that is refunded when a booking
+// is cancelled at least MinNoticeHours before the appointment.
+type RefundRule struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
+	MinNoticeHours int       `json:"min_notice_hours" gorm:"not null;default:0"`
+	Percent        int       `json:"percent" gorm:"not null"`
+	IsActive       bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Refund is the money owed back for a cancelled booking. It is opened with
+// the amount the rules allow and paid out once an admin approves it.
+type Refund struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BookingID uuid.UUID `json:"booking_id" gorm:"type:uuid;not null;uniqueIndex"`
+	UserID    string    `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	Currency  string    `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	// PaidAmount is what had been paid when the booking was cancelled
+	PaidAmount int64 `json:"paid_amount" gorm:"type:bigint;not null"`
+	// NoticeHours is how long before the appointment it was cancelled
+	NoticeHours      int        `json:"notice_hours" gorm:"not null"`
+	RefundRuleID     *uuid.UUID `json:"refund_rule_id" gorm:"type:uuid"`
+	RefundableAmount int64      `json:"refundable_amount" gorm:"type:bigint;not null"`
+	// Amount is the refund approved by an admin, paid out in allocations
+	Amount         int64              `json:"amount" gorm:"type:bigint;not null;default:0"`
+	RefundedAmount int64              `json:"refunded_amount" gorm:"type:bigint;not null;default:0"`
+	Status         string             `json:"status" gorm:"type:varchar(20);not null;default:PENDING;check:status IN ('PENDING', 'PROCESSING', 'SUCCEEDED', 'FAILED', 'REJECTED')"`
+	Note           string             `json:"note" gorm:"type:text"`
+	FailureReason  string             `json:"failure_reason" gorm:"type:text"`
+	ReviewedBy     *string            `json:"reviewed_by" gorm:"type:varchar(36)"`
+	ReviewedAt     *time.Time         `json:"reviewed_at"`
+	Allocations    []RefundAllocation `json:"allocations" gorm:"foreignKey:RefundID"`
+	CreatedAt      time.Time          `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time          `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// RefundAllocation is the part of a refund paid back against one payment.
+// Counter payments are refunded in cash and have no provider reference.
+type RefundAllocation struct {
+	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	RefundID         uuid.UUID `json:"refund_id" gorm:"type:uuid;not null;index"`
+	PaymentID        uuid.UUID `json:"payment_id" gorm:"type:uuid;not null"`
+	Amount           int64     `json:"amount" gorm:"type:bigint;not null"`
+	ProviderRefundID *string   `json:"provider_refund_id" gorm:"type:varchar(100)"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReviewPending  = "PENDING"
+	ReviewApproved = "APPROVED"
+	ReviewRejected = "REJECTED"
+)
+
+// Review is a customer's rating of a completed booking's service, and
+// optionally of the staff member who did it. Only approved reviews are shown
+// and counted in ratings.
+type Review struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BookingID      uuid.UUID  `json:"booking_id" gorm:"type:uuid;not null;uniqueIndex"`
+	UserID         string     `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	User           *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	ServiceID      uuid.UUID  `json:"service_id" gorm:"type:uuid;not null;index"`
+	BranchID       uuid.UUID  `json:"branch_id" gorm:"type:uuid;not null;index"`
+	Rating         int        `json:"rating" gorm:"type:smallint;not null;check:rating BETWEEN 1 AND 5"`
+	Comment        string     `json:"comment" gorm:"type:text"`
+	StaffID        *string    `json:"staff_id" gorm:"type:varchar(36);index"`
+	StaffRating    *int       `json:"staff_rating" gorm:"type:smallint;check:staff_rating BETWEEN 1 AND 5"`
+	Status         string     `json:"status" gorm:"type:varchar(20);not null;default:PENDING;index;check:status IN ('PENDING', 'APPROVED', 'REJECTED')"`
+	ModerationNote string     `json:"moderation_note,omitempty" gorm:"type:text"`
+	ModeratedBy    *string    `json:"moderated_by,omitempty" gorm:"type:varchar(36)"`
+	ModeratedAt    *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// RatingSummary is the average of the approved reviews of a service or
+// branch. It is not persisted.
+type RatingSummary struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
@@ -1,22 +1,53 @@
 package entity
 
 import (
+	"KaungHtetHein116/IVY-backend/pkg/tax"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Service struct {
-	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
-	Description    string    `json:"description" gorm:"type:text"`
-	DurationMinute int       `json:"duration_minute" gorm:"type:smallint;not null"`
-	Price          int       `json:"price" gorm:"type:smallint;not null"`
-	CategoryID     uuid.UUID `json:"category_id" gorm:"type:uuid;not null"`
-	Category       Category  `json:"category" gorm:"foreignKey:CategoryID"`
-	Image          string    `json:"image" gorm:"type:varchar(255)"`
-	IsActive       bool      `json:"is_active" gorm:"default:true"`
-	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	Branches       []Branch  `json:"branches" gorm:"many2many:branch_service;"`
+	ID             uuid.UUID            `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name           string               `json:"name" gorm:"type:varchar(255);not null"`
+	Description    string               `json:"description" gorm:"type:text"`
+	DurationMinute int                  `json:"duration_minute" gorm:"type:smallint;not null"`
+	Price          int64                `json:"price" gorm:"type:bigint;not null"`
+	Currency       string               `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	CategoryID     uuid.UUID            `json:"category_id" gorm:"type:uuid;not null"`
+	Category       Category             `json:"category" gorm:"foreignKey:CategoryID"`
+	Image          string               `json:"image" gorm:"type:text"`
+	ImageID        *uuid.UUID           `json:"image_id" gorm:"type:uuid"`
+	ImageFile      *Image               `json:"image_file,omitempty" gorm:"foreignKey:ImageID;constraint:OnDelete:SET NULL"`
+	IsActive       bool                 `json:"is_active" gorm:"default:true"`
+	CreatedAt      time.Time            `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time            `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt       `json:"deleted_at" gorm:"index"`
+	Branches       []Branch             `json:"branches" gorm:"many2many:branch_service;"`
+	Variants       []ServiceVariant     `json:"variants,omitempty" gorm:"foreignKey:ServiceID;constraint:OnDelete:CASCADE"`
+	AddOns         []ServiceAddOn       `json:"add_ons,omitempty" gorm:"foreignKey:ServiceID;constraint:OnDelete:CASCADE"`
+	Translations   []ServiceTranslation `json:"-" gorm:"foreignKey:ServiceID;constraint:OnDelete:CASCADE"`
+
+	// EffectivePrice is the price at the branch requested by the caller,
+	// taking the branch_service override into account. It is not persisted.
+	EffectivePrice *int64 `json:"effective_price,omitempty" gorm:"-"`
+	// EffectiveDurationMinute is the duration at that branch.
+	EffectiveDurationMinute *int `json:"effective_duration_minute,omitempty" gorm:"-"`
+	// BranchSettings is how the branch listing the service offers it.
+	BranchSettings *BranchService `json:"branch_settings,omitempty" gorm:"-"`
+	// PriceBreakdown splits the (effective) price into subtotal, tax and total.
+	PriceBreakdown *tax.Breakdown `json:"price_breakdown,omitempty" gorm:"-"`
+	// SearchRank is how well the service matched a search, higher is better.
+	SearchRank *float64 `json:"search_rank,omitempty" gorm:"-"`
+	// Rating summarises the approved reviews of the service.
+	Rating *RatingSummary `json:"rating,omitempty" gorm:"-"`
+}
+
+// ServiceSuggestion is an autocomplete entry for a service search.
+type ServiceSuggestion struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	CategoryID   uuid.UUID `json:"category_id"`
+	CategoryName string    `json:"category_name"`
 }
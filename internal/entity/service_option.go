@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceVariant is one way a service can be booked, e.g. "Long hair" for a
+// haircut, with its own duration and price in place of the service's.
+type ServiceVariant struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ServiceID      uuid.UUID `json:"service_id" gorm:"type:uuid;not null;index"`
+	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
+	DurationMinute int       `json:"duration_minute" gorm:"type:smallint;not null"`
+	Price          int64     `json:"price" gorm:"type:bigint;not null"`
+	SortOrder      int       `json:"sort_order" gorm:"not null;default:0"`
+	IsActive       bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ServiceAddOn is an optional extra booked with a service, e.g. a hair mask,
+// adding its duration and price to the booking.
+type ServiceAddOn struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ServiceID      uuid.UUID `json:"service_id" gorm:"type:uuid;not null;index"`
+	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
+	DurationMinute int       `json:"duration_minute" gorm:"type:smallint;not null;default:0"`
+	Price          int64     `json:"price" gorm:"type:bigint;not null"`
+	SortOrder      int       `json:"sort_order" gorm:"not null;default:0"`
+	IsActive       bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BookingAddOn is the snapshot of an add-on chosen for a booking.
+type BookingAddOn struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BookingID      uuid.UUID `json:"booking_id" gorm:"type:uuid;not null;index"`
+	AddOnID        uuid.UUID `json:"add_on_id" gorm:"type:uuid;not null"`
+	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
+	DurationMinute int       `json:"duration_minute" gorm:"type:smallint;not null"`
+	Price          int64     `json:"price" gorm:"type:bigint;not null"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceVersion is the catalog entry of a service as it was between two
+// changes to its name, price, duration, currency or category. Bookings point
+// at the version they were made against.
+type ServiceVersion struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ServiceID      uuid.UUID `json:"service_id" gorm:"type:uuid;not null;uniqueIndex:idx_service_version"`
+	Version        int       `json:"version" gorm:"not null;uniqueIndex:idx_service_version"`
+	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
+	DurationMinute int       `json:"duration_minute" gorm:"type:smallint;not null"`
+	Price          int64     `json:"price" gorm:"type:bigint;not null"`
+	Currency       string    `json:"currency" gorm:"type:char(3);not null;default:MMK"`
+	CategoryID     uuid.UUID `json:"category_id" gorm:"type:uuid;not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Matches reports whether the version still describes the service.
+func (v *ServiceVersion) Matches(service *Service) bool {
+	return v.Name == service.Name &&
+		v.DurationMinute == service.DurationMinute &&
+		v.Price == service.Price &&
+		v.Currency == service.Currency &&
+		v.CategoryID == service.CategoryID
+}
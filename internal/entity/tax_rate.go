@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaxRate applies to bookings at a branch, of a category, or both. Rates
+// without a branch or category act as defaults. Rate is in basis points.
+type TaxRate struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name       string     `json:"name" gorm:"type:varchar(100);not null"`
+	BranchID   *uuid.UUID `json:"branch_id" gorm:"type:uuid;index"`
+	Branch     *Branch    `json:"branch,omitempty" gorm:"foreignKey:BranchID"`
+	CategoryID *uuid.UUID `json:"category_id" gorm:"type:uuid;index"`
+	Category   *Category  `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	Rate       int        `json:"rate" gorm:"type:integer;not null;check:rate >= 0 AND rate <= 10000"`
+	Inclusive  bool       `json:"inclusive" gorm:"not null;default:false"`
+	IsActive   bool       `json:"is_active" gorm:"default:true"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Matches reports how specifically the rate applies to a booking at the
+// branch for the category: 3 for both, 2 for the category, 1 for the
+// branch, 0 for a default rate and -1 if it does not apply.
+func (t TaxRate) Matches(branchID, categoryID uuid.UUID) int {
+	if t.BranchID != nil && *t.BranchID != branchID {
+		return -1
+	}
+	if t.CategoryID != nil && *t.CategoryID != categoryID {
+		return -1
+	}
+
+	score := 0
+	if t.CategoryID != nil {
+		score += 2
+	}
+	if t.BranchID != nil {
+		score++
+	}
+	return score
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceTranslation holds a service's name and description in a locale
+// other than the default one the service itself is written in. Empty fields
+// fall back to the service's own.
+type ServiceTranslation struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ServiceID   uuid.UUID `json:"service_id" gorm:"type:uuid;not null;uniqueIndex:idx_service_translation_locale"`
+	Locale      string    `json:"locale" gorm:"type:varchar(10);not null;uniqueIndex:idx_service_translation_locale"`
+	Name        string    `json:"name" gorm:"type:varchar(255);not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// CategoryTranslation holds a category's name in a non-default locale.
+type CategoryTranslation struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	CategoryID uuid.UUID `json:"category_id" gorm:"type:uuid;not null;uniqueIndex:idx_category_translation_locale"`
+	Locale     string    `json:"locale" gorm:"type:varchar(10);not null;uniqueIndex:idx_category_translation_locale"`
+	Name       string    `json:"name" gorm:"type:varchar(255);not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BranchTranslation holds a branch's name and location in a non-default
+// locale. An empty location falls back to the branch's own.
+type BranchTranslation struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BranchID  uuid.UUID `json:"branch_id" gorm:"type:uuid;not null;uniqueIndex:idx_branch_translation_locale"`
+	Locale    string    `json:"locale" gorm:"type:varchar(10);not null;uniqueIndex:idx_branch_translation_locale"`
+	Name      string    `json:"name" gorm:"type:varchar(255);not null"`
+	Location  string    `json:"location" gorm:"type:varchar(255)"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -4,6 +4,20 @@ import (
 	"time"
 )
 
+// User roles. Staff and branch managers work at the branches they are
+// members of.
+const (
+	RoleUser          = "USER"
+	RoleAdmin         = "ADMIN"
+	RoleStaff         = "STAFF"
+	RoleBranchManager = "BRANCH_MANAGER"
+)
+
+// IsBranchRole reports whether the role works at branches.
+func IsBranchRole(role string) bool {
+	return role == RoleStaff || role == RoleBranchManager
+}
+
 type User struct {
 	ID        string `json:"id" gorm:"type:varchar(36);primary_key"`
 	FirstName string `json:"first_name" gorm:"type:varchar(255)"`
@@ -12,7 +26,7 @@ type User struct {
 	Verified  bool   `json:"verified" gorm:"type:boolean;default:false"`
 
 	// custom fields
-	Role        *string `json:"role" gorm:"type:varchar(20);default:USER;check:role IN ('USER', 'ADMIN')"`
+	Role        *string `json:"role" gorm:"type:varchar(20);default:USER;check:role IN ('USER', 'ADMIN', 'STAFF', 'BRANCH_MANAGER')"`
 	PhoneNumber *string `json:"phone_number" gorm:"type:varchar(20)"`
 	Gender      *string `json:"gender" gorm:"type:varchar(20);default:unknown"`
 	Birthday    *string `json:"birthday" gorm:"type:varchar(255)"`
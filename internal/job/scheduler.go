@@ -0,0 +1,50 @@
+// Package job runs periodic background work such as expiring balances.
+package job
+
+import (
+	"context"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Task is a unit of periodic work.
+type Task func(ctx context.Context) error
+
+// Every runs task once per interval until ctx is cancelled. Errors are
+// logged and do not stop the schedule.
+func Every(ctx context.Context, name string, interval time.Duration, task Task) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := task(ctx); err != nil {
+				log.WithError(err).Errorf("job %s failed", name)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// IntervalFromEnv reads a duration such as "30m" from the environment,
+// falling back to def when the variable is unset or invalid.
+func IntervalFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		log.Warnf("invalid %s %q, using %s", key, value, def)
+		return def
+	}
+	return interval
+}
@@ -0,0 +1,81 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// FakeProvider approves every charge and refund in memory. Set Decline to
+// simulate a card being declined and DeclineRefunds to simulate the
+// processor refusing a refund.
+type FakeProvider struct {
+	mu             sync.Mutex
+	Decline        bool
+	DeclineRefunds bool
+	charges        map[string]*Charge
+	keys           map[string]string
+	refunds        map[string]*Refund
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		charges: make(map[string]*Charge),
+		keys:    make(map[string]string),
+		refunds: make(map[string]*Refund),
+	}
+}
+
+func (p *FakeProvider) Charge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id, ok := p.keys[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return p.charges[id], nil
+	}
+	if p.Decline {
+		return nil, ErrChargeDeclined
+	}
+
+	charge := &Charge{
+		ID:       "ch_fake_" + uuid.NewString(),
+		Amount:   req.Amount,
+		Currency: req.Currency,
+		Status:   StatusSucceeded,
+	}
+	p.charges[charge.ID] = charge
+	if req.IdempotencyKey != "" {
+		p.keys[req.IdempotencyKey] = charge.ID
+	}
+	return charge, nil
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if refund, ok := p.refunds[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return refund, nil
+	}
+	if p.DeclineRefunds {
+		return nil, ErrRefundDeclined
+	}
+
+	// Charges made before a restart are unknown to the fake, accept them
+	if charge, ok := p.charges[req.ChargeID]; ok && req.Amount > charge.Amount {
+		return nil, fmt.Errorf("refund of %d exceeds charge of %d", req.Amount, charge.Amount)
+	}
+
+	refund := &Refund{
+		ID:       "re_fake_" + uuid.NewString(),
+		ChargeID: req.ChargeID,
+		Amount:   req.Amount,
+		Status:   StatusRefunded,
+	}
+	if req.IdempotencyKey != "" {
+		p.refunds[req.IdempotencyKey] = refund
+	}
+	return refund, nil
+}
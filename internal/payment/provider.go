@@ -0,0 +1,76 @@
+// Package payment abstracts the card processor used for online charges such
+// as membership renewals, so the processor can be swapped or faked.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrChargeDeclined  = errors.New("payment was declined")
+	ErrRefundDeclined  = errors.New("refund was declined")
+	ErrUnknownProvider = errors.New("unknown payment provider")
+	ErrNoProvider      = errors.New("PAYMENT_PROVIDER is not set")
+)
+
+const (
+	StatusSucceeded = "SUCCEEDED"
+	StatusRefunded  = "REFUNDED"
+)
+
+type ChargeRequest struct {
+	// CustomerID identifies the paying user at the provider
+	CustomerID  string
+	Amount      int64
+	Currency    string
+	Description string
+	// IdempotencyKey makes retries of the same charge safe
+	IdempotencyKey string
+}
+
+type Charge struct {
+	ID       string
+	Amount   int64
+	Currency string
+	Status   string
+}
+
+type RefundRequest struct {
+	ChargeID       string
+	Amount         int64
+	Reason         string
+	IdempotencyKey string
+}
+
+type Refund struct {
+	ID       string
+	ChargeID string
+	Amount   int64
+	Status   string
+}
+
+// Provider takes and gives back money. Amounts are in minor units.
+type Provider interface {
+	Charge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	Refund(ctx context.Context, req RefundRequest) (*Refund, error)
+}
+
+// NewProviderFromEnv builds the provider named by PAYMENT_PROVIDER. Only the
+// fake provider is available until a processor is integrated, and it has to
+// be asked for by name so that a missing setting can't silently approve
+// every charge.
+func NewProviderFromEnv() (Provider, error) {
+	name := strings.ToLower(strings.TrimSpace(os.Getenv("PAYMENT_PROVIDER")))
+	switch name {
+	case "":
+		return nil, ErrNoProvider
+	case "fake":
+		return NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+}
@@ -0,0 +1,31 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewProviderFromEnv(t *testing.T) {
+	tests := []struct {
+		env string
+		err error
+	}{
+		{"", ErrNoProvider},
+		{"  ", ErrNoProvider},
+		{"fake", nil},
+		{"FAKE", nil},
+		{"stripe", ErrUnknownProvider},
+	}
+	for _, tt := range tests {
+		t.Setenv("PAYMENT_PROVIDER", tt.env)
+		provider, err := NewProviderFromEnv()
+		if !errors.Is(err, tt.err) {
+			t.Errorf("PAYMENT_PROVIDER=%q: error = %v, want %v", tt.env, err, tt.err)
+		}
+		if tt.err == nil {
+			if _, ok := provider.(*FakeProvider); !ok {
+				t.Errorf("PAYMENT_PROVIDER=%q: provider = %T, want *FakeProvider", tt.env, provider)
+			}
+		}
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"KaungHtetHein116/IVY-backend/internal/entity"
 	"KaungHtetHein116/IVY-backend/utils"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BookingRepository interface {
@@ -18,8 +21,14 @@ type BookingRepository interface {
 	GetByUserID(ctx context.Context, userID string) ([]entity.Booking, error)
 	Update(ctx context.Context, id uuid.UUID, updates interface{}) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Cancel(ctx context.Context, id uuid.UUID, refundFor RefundFor) error
+	GetOpenByServiceIDs(ctx context.Context, serviceIDs []uuid.UUID) ([]entity.Booking, error)
+	GetOpenByBranchID(ctx context.Context, branchID uuid.UUID) ([]entity.Booking, error)
+	RecordPayment(ctx context.Context, payment *entity.BookingPayment) error
+	GetPayments(ctx context.Context, bookingID uuid.UUID) ([]entity.BookingPayment, error)
 	CheckSameUserBooking(ctx context.Context, userID string, bookedDate string, bookedTime string) error
 	GetBookingTimeSlotByDateAndBranch(ctx context.Context, branchID uuid.UUID, bookedDate string) []string
+	CountServiceBookingsBySlot(ctx context.Context, branchID, serviceID uuid.UUID, bookedDate string) (map[string]int64, error)
 	BuildQuery(ctx context.Context, params *params.BookingQueryParams, preloads ...string) *gorm.DB
 }
 
@@ -47,12 +56,106 @@ func (r *bookingRepository) Create(ctx context.Context, booking *entity.Booking)
 		return utils.ErrUserNotFound
 	}
 
-	return r.db.WithContext(ctx).Create(booking).Error
+	// Start a transaction
+	tx := r.db.WithContext(ctx).Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if booking.PromotionID != nil {
+		if err := redeemPromotion(tx, booking); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if booking.PointsRedeemed > 0 {
+		if err := redeemPoints(tx, booking); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if booking.PackageCreditID != nil {
+		if err := redeemPackageCredit(tx, booking); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if booking.SubscriptionUsageID != nil {
+		if err := redeemSubscriptionUsage(tx, booking); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if booking.GiftCardID != nil {
+		if err := redeemGiftCard(tx, booking); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Omit("Service", "Branch").Create(booking).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// redeemPromotion locks the promotion, enforces its usage limits and records
+// the redemption for the booking.
+func redeemPromotion(tx *gorm.DB, booking *entity.Booking) error {
+	var promotion entity.Promotion
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&promotion, "id = ?", booking.PromotionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrPromotionNotFound
+		}
+		return err
+	}
+
+	if promotion.MaxUses != nil && promotion.UsedCount >= *promotion.MaxUses {
+		return utils.ErrPromotionUsageLimitReached
+	}
+
+	if promotion.MaxUsesPerUser != nil {
+		var used int64
+		if err := tx.Model(&entity.PromotionRedemption{}).
+			Where("promotion_id = ? AND user_id = ?", promotion.ID, booking.UserID).
+			Count(&used).Error; err != nil {
+			return err
+		}
+		if used >= int64(*promotion.MaxUsesPerUser) {
+			return utils.ErrPromotionUsageLimitReached
+		}
+	}
+
+	if err := tx.Model(&promotion).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1")).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&entity.PromotionRedemption{
+		PromotionID:    promotion.ID,
+		UserID:         booking.UserID,
+		BookingID:      booking.ID,
+		DiscountAmount: booking.DiscountAmount,
+	}).Error
 }
 
 func (r *bookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
 	var booking entity.Booking
-	err := r.db.WithContext(ctx).
+	err := scopeBookings(ctx, r.db.WithContext(ctx)).
+		Preload("AddOns").
+		Preload("ServiceVersion").
 		First(&booking, "id = ?", id).Error
 	if err != nil {
 		return nil, err
@@ -63,7 +166,9 @@ func (r *bookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 func (r *bookingRepository) GetAll(ctx context.Context, params *params.BookingQueryParams) ([]entity.Booking, *transport.PaginationResponse, error) {
 	var bookings []entity.Booking
 
-	query := r.BuildQuery(ctx, params, "Service", "Branch")
+	query := scopeBookings(ctx, r.BuildQuery(ctx, params, "AddOns", "ServiceVersion")).
+		Preload("Service", withDeleted).
+		Preload("Branch", withDeleted)
 
 	// Calculate pagination using the reusable utility
 	pagination, err := utils.CountAndPaginate(ctx, query, &entity.Booking{}, params.Limit, params.Offset)
@@ -82,23 +187,398 @@ func (r *bookingRepository) GetAll(ctx context.Context, params *params.BookingQu
 func (r *bookingRepository) GetByUserID(ctx context.Context, userID string) ([]entity.Booking, error) {
 	var bookings []entity.Booking
 	err := r.db.WithContext(ctx).
+		Preload("AddOns").
 		Where("user_id = ?", userID).
 		Find(&bookings).Error
 	return bookings, err
 }
 
+// GetOpenByServiceIDs returns the PENDING and CONFIRMED bookings for any of
+// the services that are still ahead.
+func (r *bookingRepository) GetOpenByServiceIDs(ctx context.Context, serviceIDs []uuid.UUID) ([]entity.Booking, error) {
+	if len(serviceIDs) == 0 {
+		return []entity.Booking{}, nil
+	}
+	return openBookings(r.db.WithContext(ctx), "service_id IN ?", serviceIDs)
+}
+
+// GetOpenByBranchID returns the PENDING and CONFIRMED bookings at the branch
+// that are still ahead.
+func (r *bookingRepository) GetOpenByBranchID(ctx context.Context, branchID uuid.UUID) ([]entity.Booking, error) {
+	return openBookings(r.db.WithContext(ctx), "branch_id = ?", branchID)
+}
+
+// openBookings returns the PENDING and CONFIRMED bookings matching query
+// whose appointment has not passed. One whose date cannot be read is kept,
+// so it is never left behind unnoticed.
+func openBookings(db *gorm.DB, query string, args ...interface{}) ([]entity.Booking, error) {
+	bookings := []entity.Booking{}
+	if err := db.
+		Preload("Service", withDeleted).
+		Where(query, args...).
+		Where("status IN ?", []string{"PENDING", "CONFIRMED"}).
+		Find(&bookings).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	upcoming := bookings[:0]
+	for _, booking := range bookings {
+		if at, err := utils.ParseBookingTime(booking.BookedDate, booking.BookedTime); err == nil && at.Before(now) {
+			continue
+		}
+		upcoming = append(upcoming, booking)
+	}
+	return upcoming, nil
+}
+
+// RefundFor works out the refund owed for a booking the salon cancels, or
+// nil when nothing was paid.
+type RefundFor func(booking *entity.Booking) *entity.Refund
+
+// cancelOpenBookings cancels the upcoming open bookings matching query
+// within the transaction of the delete that strands them, giving back what
+// each redeemed and recording the refund refundFor works out. A nil
+// refundFor refuses with utils.ErrDeleteBlocked when there are any, so a
+// booking made since the impact was checked is never stranded.
+func cancelOpenBookings(tx *gorm.DB, refundFor RefundFor, query string, args ...interface{}) ([]entity.Booking, error) {
+	bookings, err := openBookings(tx.Clauses(clause.Locking{Strength: "UPDATE"}), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(bookings) > 0 && refundFor == nil {
+		return nil, utils.ErrDeleteBlocked
+	}
+
+	for i := range bookings {
+		booking := &bookings[i]
+		if err := tx.Model(booking).Update("status", "CANCELLED").Error; err != nil {
+			return nil, err
+		}
+		if err := restoreRedemptions(tx, booking); err != nil {
+			return nil, err
+		}
+
+		if err := openRefund(tx, refundFor, booking); err != nil {
+			return nil, err
+		}
+	}
+	return bookings, nil
+}
+
+// openRefund records what refundFor says is owed back for the cancelled
+// booking, if anything. A refund already opened for it is kept.
+func openRefund(tx *gorm.DB, refundFor RefundFor, booking *entity.Booking) error {
+	refund := refundFor(booking)
+	if refund == nil {
+		return nil
+	}
+	return tx.
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "booking_id"}}, DoNothing: true}).
+		Omit("Allocations").
+		Create(refund).Error
+}
+
 func (r *bookingRepository) Update(ctx context.Context, id uuid.UUID, updates interface{}) error {
-	return r.db.WithContext(ctx).Model(&entity.Booking{}).Where("id = ?", id).Updates(updates).Error
+	return scopeBookings(ctx, r.db.WithContext(ctx).Model(&entity.Booking{})).Where("id = ?", id).Updates(updates).Error
 }
 
 func (r *bookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&entity.Booking{}, "id = ?", id)
-	if result.Error != nil {
-		return result.Error
+	// Start a transaction
+	tx := r.db.WithContext(ctx).Begin()
+	if err := tx.Error; err != nil {
+		return err
 	}
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var booking entity.Booking
+	if err := scopeBookings(ctx, tx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&booking, "id = ?", id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Money taken for the booking is given back through a cancellation and
+	// its refund, which a deleted booking would leave nothing to attach to
+	if booking.Status == "COMPLETED" {
+		tx.Rollback()
+		return utils.ErrBookingCompleted
+	}
+	if booking.AmountPaid > 0 || booking.PaymentStatus == "PAID" {
+		tx.Rollback()
+		return utils.ErrBookingHasPayments
 	}
+
+	// A cancelled booking has already given back what it redeemed
+	if booking.Status != "CANCELLED" {
+		if err := restoreRedemptions(tx, &booking); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Delete(&booking).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Cancel marks the booking as CANCELLED and gives back any promo code use,
+// loyalty points, package credit, included membership session and gift card
+// balance it redeemed, and in the same transaction opens the refund refundFor
+// works out. Cancelling twice is a no-op, and a completed booking cannot be
+// cancelled since what it redeemed has been used.
+func (r *bookingRepository) Cancel(ctx context.Context, id uuid.UUID, refundFor RefundFor) error {
+	// Start a transaction
+	tx := r.db.WithContext(ctx).Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var booking entity.Booking
+	if err := scopeBookings(ctx, tx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&booking, "id = ?", id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if booking.Status == "CANCELLED" {
+		return tx.Commit().Error
+	}
+	if booking.Status == "COMPLETED" {
+		tx.Rollback()
+		return utils.ErrBookingCompleted
+	}
+
+	if err := tx.Model(&booking).Update("status", "CANCELLED").Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := restoreRedemptions(tx, &booking); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := openRefund(tx, refundFor, &booking); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// RecordPayment stores a payment against the booking and marks the booking
+// PAID once the amount due is covered. A charge that was already recorded is
+// ignored.
+func (r *bookingRepository) RecordPayment(ctx context.Context, payment *entity.BookingPayment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var booking entity.Booking
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&booking, "id = ?", payment.BookingID).Error; err != nil {
+			return err
+		}
+
+		if payment.ChargeID != nil {
+			var count int64
+			if err := tx.Model(&entity.BookingPayment{}).Where("charge_id = ?", payment.ChargeID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+		}
+
+		if payment.Amount > 0 {
+			if err := tx.Create(payment).Error; err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]interface{}{"amount_paid": booking.AmountPaid + payment.Amount}
+		if booking.PaymentStatus != "PAID" && booking.AmountPaid+payment.Amount >= booking.AmountDue {
+			updates["payment_status"] = "PAID"
+			updates["paid_at"] = time.Now()
+		}
+		return tx.Model(&booking).Updates(updates).Error
+	})
+}
+
+func (r *bookingRepository) GetPayments(ctx context.Context, bookingID uuid.UUID) ([]entity.BookingPayment, error) {
+	var payments []entity.BookingPayment
+	err := r.db.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("created_at asc").
+		Find(&payments).Error
+	return payments, err
+}
+
+// redeemPackageCredit uses one session of the booked service from the
+// customer's package.
+func redeemPackageCredit(tx *gorm.DB, booking *entity.Booking) error {
+	var credit entity.CustomerPackageCredit
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&credit, "id = ?", booking.PackageCreditID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrPackageCreditNotFound
+		}
+		return err
+	}
+
+	var customerPackage entity.CustomerPackage
+	if err := tx.First(&customerPackage, "id = ?", credit.CustomerPackageID).Error; err != nil {
+		return err
+	}
+
+	if customerPackage.UserID != booking.UserID || credit.ServiceID != booking.ServiceID {
+		return utils.ErrPackageCreditNotFound
+	}
+	if customerPackage.Status != entity.CustomerPackageActive ||
+		!customerPackage.ExpiresAt.After(time.Now()) || credit.Remaining <= 0 {
+		return utils.ErrPackageCreditExhausted
+	}
+
+	credit.Remaining--
+	if err := tx.Model(&credit).Update("remaining", credit.Remaining).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&entity.LedgerEntry{
+		AccountType:  entity.LedgerAccountPackageCredit,
+		AccountID:    credit.ID,
+		UserID:       &booking.UserID,
+		BookingID:    &booking.ID,
+		EntryType:    entity.LedgerEntryRedeem,
+		Amount:       -1,
+		BalanceAfter: int64(credit.Remaining),
+	}).Error
+}
+
+// redeemGiftCard pays as much of the amount due as the gift card balance allows.
+func redeemGiftCard(tx *gorm.DB, booking *entity.Booking) error {
+	var giftCard entity.GiftCard
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&giftCard, "id = ?", booking.GiftCardID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrGiftCardNotFound
+		}
+		return err
+	}
+
+	if !giftCard.IsActive || giftCard.Balance <= 0 || giftCard.Currency != booking.Currency ||
+		(giftCard.ExpiresAt != nil && !giftCard.ExpiresAt.After(time.Now())) {
+		return utils.ErrGiftCardUnusable
+	}
+
+	amount := booking.AmountDue
+	if giftCard.Balance < amount {
+		amount = giftCard.Balance
+	}
+	booking.GiftCardAmount = amount
+	booking.AmountDue -= amount
+
+	giftCard.Balance -= amount
+	if err := tx.Model(&giftCard).Update("balance", giftCard.Balance).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&entity.LedgerEntry{
+		AccountType:  entity.LedgerAccountGiftCard,
+		AccountID:    giftCard.ID,
+		UserID:       &booking.UserID,
+		BookingID:    &booking.ID,
+		EntryType:    entity.LedgerEntryRedeem,
+		Amount:       -amount,
+		BalanceAfter: giftCard.Balance,
+	}).Error
+}
+
+// restoreRedemptions reverses everything redeemed by the booking.
+func restoreRedemptions(tx *gorm.DB, booking *entity.Booking) error {
+	if booking.PromotionID != nil {
+		result := tx.Where("booking_id = ?", booking.ID).Delete(&entity.PromotionRedemption{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			if err := tx.Model(&entity.Promotion{}).
+				Where("id = ? AND used_count > 0", booking.PromotionID).
+				UpdateColumn("used_count", gorm.Expr("used_count - 1")).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if booking.PointsRedeemed > 0 {
+		if err := restorePoints(tx, booking); err != nil {
+			return err
+		}
+	}
+
+	if booking.PackageCreditID != nil {
+		var credit entity.CustomerPackageCredit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&credit, "id = ?", booking.PackageCreditID).Error; err != nil {
+			return err
+		}
+
+		credit.Remaining++
+		if err := tx.Model(&credit).Update("remaining", credit.Remaining).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&entity.LedgerEntry{
+			AccountType:  entity.LedgerAccountPackageCredit,
+			AccountID:    credit.ID,
+			UserID:       &booking.UserID,
+			BookingID:    &booking.ID,
+			EntryType:    entity.LedgerEntryRestore,
+			Amount:       1,
+			BalanceAfter: int64(credit.Remaining),
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	if booking.SubscriptionUsageID != nil {
+		if err := restoreSubscriptionUsage(tx, booking); err != nil {
+			return err
+		}
+	}
+
+	if booking.GiftCardID != nil && booking.GiftCardAmount > 0 {
+		var giftCard entity.GiftCard
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&giftCard, "id = ?", booking.GiftCardID).Error; err != nil {
+			return err
+		}
+
+		giftCard.Balance += booking.GiftCardAmount
+		if err := tx.Model(&giftCard).Update("balance", giftCard.Balance).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&entity.LedgerEntry{
+			AccountType:  entity.LedgerAccountGiftCard,
+			AccountID:    giftCard.ID,
+			UserID:       &booking.UserID,
+			BookingID:    &booking.ID,
+			EntryType:    entity.LedgerEntryRestore,
+			Amount:       booking.GiftCardAmount,
+			BalanceAfter: giftCard.Balance,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -142,6 +622,31 @@ func (r *bookingRepository) GetBookingTimeSlotByDateAndBranch(ctx context.Contex
 	return timeSlots
 }
 
+// CountServiceBookingsBySlot counts the pending and confirmed bookings of the
+// service at the branch on the date, by booked time.
+func (r *bookingRepository) CountServiceBookingsBySlot(ctx context.Context, branchID, serviceID uuid.UUID, bookedDate string) (map[string]int64, error) {
+	var rows []struct {
+		BookedTime string
+		Count      int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&entity.Booking{}).
+		Select("booked_time, COUNT(*) AS count").
+		Where("branch_id = ? AND service_id = ? AND booked_date = ? AND status IN ?",
+			branchID, serviceID, bookedDate, []string{"PENDING", "CONFIRMED"}).
+		Group("booked_time").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.BookedTime] = row.Count
+	}
+	return counts, nil
+}
+
 func (r *bookingRepository) BuildQuery(ctx context.Context, params *params.BookingQueryParams, preloads ...string) *gorm.DB {
 	builder := utils.NewQueryBuilder(r.db, ctx)
 
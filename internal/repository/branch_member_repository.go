@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BranchMemberRepository stores which branches staff and branch managers
+// work at.
+type BranchMemberRepository interface {
+	GetMembers(ctx context.Context, branchID uuid.UUID) ([]entity.BranchMember, error)
+	GetBranches(ctx context.Context, userID string) ([]entity.BranchMember, error)
+	GetBranchIDs(ctx context.Context, userID string) ([]uuid.UUID, error)
+	Add(ctx context.Context, member *entity.BranchMember) error
+	Remove(ctx context.Context, branchID uuid.UUID, userID string) error
+}
+
+type branchMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewBranchMemberRepository(db *gorm.DB) BranchMemberRepository {
+	return &branchMemberRepository{db: db}
+}
+
+func (r *branchMemberRepository) GetMembers(ctx context.Context, branchID uuid.UUID) ([]entity.BranchMember, error) {
+	members := []entity.BranchMember{}
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("branch_id = ?", branchID).
+		Order("created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+// GetBranches returns the user's memberships with their branches. Branches in
+// the trash are left out.
+func (r *branchMemberRepository) GetBranches(ctx context.Context, userID string) ([]entity.BranchMember, error) {
+	members := []entity.BranchMember{}
+	err := r.db.WithContext(ctx).
+		Joins("Branch").
+		Where("branch_members.user_id = ?", userID).
+		Order("branch_members.created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *branchMemberRepository) GetBranchIDs(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	branchIDs := []uuid.UUID{}
+	err := r.db.WithContext(ctx).Model(&entity.BranchMember{}).
+		Where("user_id = ?", userID).
+		Pluck("branch_id", &branchIDs).Error
+	return branchIDs, err
+}
+
+// Add makes the user a member of the branch. Adding them again keeps the
+// first membership.
+func (r *branchMemberRepository) Add(ctx context.Context, member *entity.BranchMember) error {
+	return r.db.WithContext(ctx).
+		Omit("Branch", "User").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(member).Error
+}
+
+func (r *branchMemberRepository) Remove(ctx context.Context, branchID uuid.UUID, userID string) error {
+	result := r.db.WithContext(ctx).
+		Delete(&entity.BranchMember{}, "branch_id = ? AND user_id = ?", branchID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
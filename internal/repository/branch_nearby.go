@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// branchDistanceSQL is the great-circle distance in km from the point @lat,
+// @lng to the branch. The cosine is clamped so rounding cannot push it out of
+// acos's domain when the points coincide.
+const branchDistanceSQL = `6371 * acos(least(1, greatest(-1,
+	cos(radians(@lat)) * cos(radians(branches.latitude)) * cos(radians(branches.longitude) - radians(@lng))
+	+ sin(radians(@lat)) * sin(radians(branches.latitude)))))`
+
+// GetNearby returns the branches within the radius of the point, nearest
+// first, with DistanceKm set. Branches without coordinates are left out.
+func (r *branchRepository) GetNearby(ctx context.Context, filter *params.BranchNearbyQueryParams) ([]entity.Branch, *transport.PaginationResponse, error) {
+	args := map[string]interface{}{"lat": *filter.Lat, "lng": *filter.Lng, "radius": filter.RadiusKm}
+
+	var total int64
+	if err := r.nearbyQuery(ctx, filter, args).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var rows []struct {
+		ID       uuid.UUID
+		Distance float64
+	}
+	err := r.nearbyQuery(ctx, filter, args).
+		Select("branches.id, ("+branchDistanceSQL+") AS distance", args).
+		Order("distance ASC, branches.name ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var found []entity.Branch
+	if len(ids) > 0 {
+		if err := r.db.WithContext(ctx).Preload("ImageFile.Thumbnails").Where("id IN ?", ids).Find(&found).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Put the branches back in distance order
+	byID := make(map[uuid.UUID]entity.Branch, len(found))
+	for _, branch := range found {
+		byID[branch.ID] = branch
+	}
+	branches := make([]entity.Branch, 0, len(rows))
+	for _, row := range rows {
+		if branch, ok := byID[row.ID]; ok {
+			distance := row.Distance
+			branch.DistanceKm = &distance
+			branches = append(branches, branch)
+		}
+	}
+	if err := loadBranchRatings(r.db.WithContext(ctx), branches); err != nil {
+		return nil, nil, err
+	}
+
+	return branches, utils.CalculatePagination(total, filter.Limit, filter.Offset), nil
+}
+
+func (r *branchRepository) nearbyQuery(ctx context.Context, filter *params.BranchNearbyQueryParams, args map[string]interface{}) *gorm.DB {
+	query := utils.NewQueryBuilder(r.db, ctx).
+		ApplyCondition("branches.latitude IS NOT NULL AND branches.longitude IS NOT NULL").
+		ApplyCondition("("+branchDistanceSQL+") <= @radius", args)
+
+	if filter.IsActive != nil {
+		query.ApplyCondition("branches.is_active = ?", *filter.IsActive)
+	}
+	if serviceID, err := uuid.Parse(filter.ServiceID); err == nil {
+		query.ApplyCondition("EXISTS (SELECT 1 FROM branch_service WHERE branch_service.branch_id = branches.id AND branch_service.service_id = ? AND branch_service.is_active)", serviceID)
+	}
+
+	return query.Build().Model(&entity.Branch{})
+}
@@ -4,6 +4,7 @@ import (
 	"KaungHtetHein116/IVY-backend/api/transport"
 	"KaungHtetHein116/IVY-backend/api/v1/params"
 	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/pkg/scope"
 	"KaungHtetHein116/IVY-backend/utils"
 	"context"
 
@@ -15,8 +16,12 @@ type BranchRepository interface {
 	Create(ctx context.Context, branch *entity.Branch) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Branch, error)
 	GetAll(ctx context.Context, filter *params.BranchQueryParams) ([]entity.Branch, *transport.PaginationResponse, error)
+	GetNearby(ctx context.Context, filter *params.BranchNearbyQueryParams) ([]entity.Branch, *transport.PaginationResponse, error)
 	Update(ctx context.Context, id uuid.UUID, updates interface{}) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, refundFor RefundFor) ([]entity.Booking, error)
+	GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Branch, error)
+	GetDeleted(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Branch, *transport.PaginationResponse, error)
+	Restore(ctx context.Context, id uuid.UUID) error
 	BuildQuery(ctx context.Context, params *params.BranchQueryParams, preloads ...string) *gorm.DB
 }
 
@@ -34,11 +39,16 @@ func (r *branchRepository) Create(ctx context.Context, branch *entity.Branch) er
 
 func (r *branchRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Branch, error) {
 	var branch entity.Branch
-	err := r.db.WithContext(ctx).First(&branch, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("ImageFile.Thumbnails").First(&branch, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
-	return &branch, nil
+
+	branches := []entity.Branch{branch}
+	if err := loadBranchRatings(r.db.WithContext(ctx), branches); err != nil {
+		return nil, err
+	}
+	return &branches[0], nil
 }
 
 func (r *branchRepository) GetAll(ctx context.Context, params *params.BranchQueryParams) ([]entity.Branch, *transport.PaginationResponse, error) {
@@ -54,12 +64,15 @@ func (r *branchRepository) GetAll(ctx context.Context, params *params.BranchQuer
 
 		err = query.
 			Joins("JOIN branch_service ON branches.id = branch_service.branch_id").
-			Where("branch_service.service_id = ?", serviceIDUUID).
+			Where("branch_service.service_id = ? AND branch_service.is_active = ?", serviceIDUUID, true).
 			Find(&branches).Error
 
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := loadBranchRatings(r.db.WithContext(ctx), branches); err != nil {
+			return nil, nil, err
+		}
 		return branches, nil, nil
 	}
 
@@ -73,23 +86,67 @@ func (r *branchRepository) GetAll(ctx context.Context, params *params.BranchQuer
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := loadBranchRatings(r.db.WithContext(ctx), branches); err != nil {
+		return nil, nil, err
+	}
 
 	return branches, pagination, nil
 }
 
+// Update changes the branch's details. Branch managers can only change their
+// own branches; any other is not found.
 func (r *branchRepository) Update(ctx context.Context, id uuid.UUID, updates interface{}) error {
+	if !scope.FromContext(ctx).HasBranch(id) {
+		return gorm.ErrRecordNotFound
+	}
 	return r.db.WithContext(ctx).Model(&entity.Branch{}).Where("id = ?", id).Updates(updates).Error
 }
 
-func (r *branchRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&entity.Branch{}, "id = ?", id)
-	if result.Error != nil {
-		return result.Error
+// Delete moves the branch to the trash. Bookings made at it keep resolving it.
+// In the same transaction it cancels the branch's upcoming bookings, see
+// cancelOpenBookings, and returns them.
+func (r *branchRepository) Delete(ctx context.Context, id uuid.UUID, refundFor RefundFor) ([]entity.Booking, error) {
+	var cancelled []entity.Booking
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entity.Branch{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		bookings, err := cancelOpenBookings(tx, refundFor, "branch_id = ?", id)
+		cancelled = bookings
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+	return cancelled, nil
+}
+
+// GetByIDWithDeleted finds the branch even if it is in the trash, for
+// bookings and invoices made before it was deleted.
+func (r *branchRepository) GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Branch, error) {
+	var branch entity.Branch
+	if err := r.db.WithContext(ctx).Unscoped().First(&branch, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
+
+func (r *branchRepository) GetDeleted(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Branch, *transport.PaginationResponse, error) {
+	branches := []entity.Branch{}
+	pagination, err := listDeleted(ctx, r.db, &entity.Branch{}, &branches, filter)
+	if err != nil {
+		return nil, nil, err
 	}
-	return nil
+	return branches, pagination, nil
+}
+
+func (r *branchRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return restoreDeleted(ctx, r.db, &entity.Branch{}, id)
 }
 
 func (r *branchRepository) BuildQuery(ctx context.Context, params *params.BranchQueryParams, preloads ...string) *gorm.DB {
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/pkg/scope"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BranchServiceRepository stores how each branch offers its services.
+type BranchServiceRepository interface {
+	GetServices(ctx context.Context, branchID uuid.UUID) ([]entity.Service, error)
+	Get(ctx context.Context, branchID, serviceID uuid.UUID) (*entity.BranchService, error)
+	Save(ctx context.Context, branchService *entity.BranchService) error
+	Delete(ctx context.Context, branchID, serviceID uuid.UUID) error
+}
+
+type branchServiceRepository struct {
+	db *gorm.DB
+}
+
+func NewBranchServiceRepository(db *gorm.DB) BranchServiceRepository {
+	return &branchServiceRepository{db: db}
+}
+
+// GetServices returns every service the branch offers, paused ones included,
+// with BranchSettings set.
+func (r *branchServiceRepository) GetServices(ctx context.Context, branchID uuid.UUID) ([]entity.Service, error) {
+	if !scope.FromContext(ctx).HasBranch(branchID) {
+		return []entity.Service{}, nil
+	}
+
+	var rows []entity.BranchService
+	if err := r.db.WithContext(ctx).Where("branch_id = ?", branchID).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	settings := make(map[uuid.UUID]entity.BranchService, len(rows))
+	serviceIDs := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		settings[row.ServiceID] = row
+		serviceIDs[i] = row.ServiceID
+	}
+
+	services := []entity.Service{}
+	if len(serviceIDs) == 0 {
+		return services, nil
+	}
+	err := r.db.WithContext(ctx).
+		Preload("Category").
+		Where("id IN ?", serviceIDs).
+		Order("name ASC").
+		Find(&services).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range services {
+		row := settings[services[i].ID]
+		services[i].BranchSettings = &row
+	}
+	return services, nil
+}
+
+func (r *branchServiceRepository) Get(ctx context.Context, branchID, serviceID uuid.UUID) (*entity.BranchService, error) {
+	var branchService entity.BranchService
+	err := r.db.WithContext(ctx).
+		First(&branchService, "branch_id = ? AND service_id = ?", branchID, serviceID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &branchService, nil
+}
+
+// Save offers the service at the branch with the given settings, replacing
+// the ones it had.
+func (r *branchServiceRepository) Save(ctx context.Context, branchService *entity.BranchService) error {
+	if !scope.FromContext(ctx).HasBranch(branchService.BranchID) {
+		return utils.ErrBranchNotFound
+	}
+	if err := r.db.WithContext(ctx).First(&entity.Branch{}, "id = ?", branchService.BranchID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.ErrBranchNotFound
+		}
+		return err
+	}
+	if err := r.db.WithContext(ctx).First(&entity.Service{}, "id = ?", branchService.ServiceID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.ErrServiceNotFound
+		}
+		return err
+	}
+
+	// Select all columns so a paused service is not saved as the default
+	return r.db.WithContext(ctx).
+		Select("*").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "branch_id"}, {Name: "service_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"is_active", "price_override", "duration_override", "capacity", "updated_at"}),
+		}).
+		Create(branchService).Error
+}
+
+// Delete stops offering the service at the branch. Bookings already made
+// there are kept.
+func (r *branchServiceRepository) Delete(ctx context.Context, branchID, serviceID uuid.UUID) error {
+	if !scope.FromContext(ctx).HasBranch(branchID) {
+		return gorm.ErrRecordNotFound
+	}
+	result := r.db.WithContext(ctx).
+		Delete(&entity.BranchService{}, "branch_id = ? AND service_id = ?", branchID, serviceID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
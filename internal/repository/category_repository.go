@@ -12,12 +12,29 @@ import (
 	"gorm.io/gorm"
 )
 
+// categoryDescendantsSQL selects the category and every category under it
+// that is not in the trash.
+const categoryDescendantsSQL = `WITH RECURSIVE tree AS (
+	SELECT id FROM categories WHERE id = ?
+	UNION ALL
+	SELECT c.id FROM categories c JOIN tree ON c.parent_id = tree.id WHERE c.deleted_at IS NULL
+) SELECT id FROM tree`
+
 type CategoryRepository interface {
 	Create(ctx context.Context, category *entity.Category) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Category, error)
 	GetAll(ctx context.Context, params *params.CategoryQueryParams) ([]entity.Category, *transport.PaginationResponse, error)
-	Update(ctx context.Context, category *entity.Category) error
+	GetTree(ctx context.Context, includeHidden bool) ([]entity.Category, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	Reorder(ctx context.Context, parentID *uuid.UUID, ids []uuid.UUID) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteReassigning(ctx context.Context, id, targetID uuid.UUID) error
+	DeleteWithServices(ctx context.Context, id uuid.UUID, refundFor RefundFor) ([]entity.Booking, error)
+	CountChildren(ctx context.Context, id uuid.UUID) (int64, error)
+	GetServiceIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	GetDeleted(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Category, *transport.PaginationResponse, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	IsDescendant(ctx context.Context, id, ancestorID uuid.UUID) (bool, error)
 	BuildQuery(ctx context.Context, params *params.CategoryQueryParams, preloads ...string) *gorm.DB
 }
 
@@ -29,9 +46,18 @@ func NewCategoryRepository(db *gorm.DB) CategoryRepository {
 	return &categoryRepository{db: db}
 }
 
+// Create adds the category. Names are unique among the children of a parent.
 func (r *categoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	if category.ParentID != nil {
+		if err := r.checkParent(ctx, *category.ParentID); err != nil {
+			return err
+		}
+	}
+
 	var existing entity.Category
-	err := r.db.WithContext(ctx).Where("name = ?", category.Name).First(&existing).Error
+	err := r.db.WithContext(ctx).
+		Where("name = ? AND parent_id IS NOT DISTINCT FROM ?", category.Name, category.ParentID).
+		First(&existing).Error
 	if err == nil {
 		return gorm.ErrDuplicatedKey
 	}
@@ -40,7 +66,7 @@ func (r *categoryRepository) Create(ctx context.Context, category *entity.Catego
 		return err
 	}
 
-	return r.db.WithContext(ctx).Create(category).Error
+	return r.db.WithContext(ctx).Omit("Children").Create(category).Error
 }
 
 func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
@@ -70,6 +96,17 @@ func (r *categoryRepository) GetAll(ctx context.Context, params *params.Category
 	return categories, pagination, nil
 }
 
+// GetTree returns every category in display order, flat; the caller nests them.
+func (r *categoryRepository) GetTree(ctx context.Context, includeHidden bool) ([]entity.Category, error) {
+	var categories []entity.Category
+	query := r.db.WithContext(ctx)
+	if !includeHidden {
+		query = query.Where("is_hidden = ?", false)
+	}
+	err := query.Order("sort_order asc, name asc").Find(&categories).Error
+	return categories, err
+}
+
 func (r *categoryRepository) BuildQuery(ctx context.Context, params *params.CategoryQueryParams, preloads ...string) *gorm.DB {
 	builder := utils.NewQueryBuilder(r.db, ctx)
 
@@ -77,23 +114,124 @@ func (r *categoryRepository) BuildQuery(ctx context.Context, params *params.Cate
 	if params.SortBy != "" {
 		builder.ApplySorting(params.SortBy, params.SortOrder)
 	} else {
-		builder.ApplySorting("updated_at", "desc")
+		builder.ApplySorting("sort_order", "asc")
 	}
 
 	// Apply string filters
 	builder.ApplyStringFilters(map[string]string{
 		"name": params.Name,
 	})
+	builder.ApplyUUIDFilter("parent_id", params.ParentID)
+	if !params.IncludeHidden {
+		builder.ApplyCondition("is_hidden = ?", false)
+	}
 
 	return builder.Build()
 }
 
-func (r *categoryRepository) Update(ctx context.Context, category *entity.Category) error {
-	return r.db.WithContext(ctx).Updates(category).Error
+// Update applies the changes. Moving the category to another parent keeps
+// names unique among its new siblings.
+func (r *categoryRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category entity.Category
+		if err := tx.First(&category, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		name := category.Name
+		if n, ok := updates["name"].(string); ok {
+			name = n
+		}
+		parentID := category.ParentID
+		if p, ok := updates["parent_id"]; ok {
+			parentID, _ = p.(*uuid.UUID)
+		}
+
+		var count int64
+		if err := tx.Model(&entity.Category{}).
+			Where("name = ? AND parent_id IS NOT DISTINCT FROM ? AND id <> ?", name, parentID, id).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return gorm.ErrDuplicatedKey
+		}
+
+		return tx.Model(&entity.Category{}).Where("id = ?", id).Updates(updates).Error
+	})
+}
+
+// Reorder sets the sort order of the children of parentID to the order of ids.
+func (r *categoryRepository) Reorder(ctx context.Context, parentID *uuid.UUID, ids []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.Category{}).
+			Where("id IN ? AND parent_id IS NOT DISTINCT FROM ?", ids, parentID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(ids)) {
+			return utils.ErrCategoryNotSibling
+		}
+
+		for i, id := range ids {
+			if err := tx.Model(&entity.Category{}).Where("id = ?", id).Update("sort_order", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&entity.Category{}, "id = ?", id)
+	return deleteCategory(r.db.WithContext(ctx), id)
+}
+
+// DeleteReassigning moves the category's services to targetID and then
+// deletes the category, both or neither.
+func (r *categoryRepository) DeleteReassigning(ctx context.Context, id, targetID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.Service{}).Where("category_id = ?", id).Update("category_id", targetID).Error; err != nil {
+			return err
+		}
+		return deleteCategory(tx, id)
+	})
+}
+
+// DeleteWithServices moves the category to the trash together with its
+// services, cancelling their upcoming bookings in the same transaction, see
+// cancelOpenBookings. It returns the cancelled bookings.
+func (r *categoryRepository) DeleteWithServices(ctx context.Context, id uuid.UUID, refundFor RefundFor) ([]entity.Booking, error) {
+	var cancelled []entity.Booking
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bookings, err := cancelOpenBookings(tx, refundFor, "service_id IN (?)",
+			tx.Model(&entity.Service{}).Select("id").Where("category_id = ?", id))
+		if err != nil {
+			return err
+		}
+		cancelled = bookings
+
+		if err := tx.Where("category_id = ?", id).Delete(&entity.Service{}).Error; err != nil {
+			return err
+		}
+		return deleteCategory(tx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cancelled, nil
+}
+
+func deleteCategory(db *gorm.DB, id uuid.UUID) error {
+	var children int64
+	if err := db.Model(&entity.Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+		return err
+	}
+	if children > 0 {
+		return utils.ErrCategoryHasChildren
+	}
+
+	result := db.Delete(&entity.Category{}, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -102,3 +240,66 @@ func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+func (r *categoryRepository) CountChildren(ctx context.Context, id uuid.UUID) (int64, error) {
+	var children int64
+	err := r.db.WithContext(ctx).Model(&entity.Category{}).Where("parent_id = ?", id).Count(&children).Error
+	return children, err
+}
+
+// GetServiceIDs returns the services filed directly under the category.
+func (r *categoryRepository) GetServiceIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := r.db.WithContext(ctx).Model(&entity.Service{}).Where("category_id = ?", id).Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *categoryRepository) GetDeleted(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Category, *transport.PaginationResponse, error) {
+	categories := []entity.Category{}
+	pagination, err := listDeleted(ctx, r.db, &entity.Category{}, &categories, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return categories, pagination, nil
+}
+
+// Restore takes the category out of the trash. Its parent has to be
+// restored first.
+func (r *categoryRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	var category entity.Category
+	if err := r.db.WithContext(ctx).Unscoped().First(&category, "id = ? AND deleted_at IS NOT NULL", id).Error; err != nil {
+		return err
+	}
+
+	if category.ParentID != nil {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&entity.Category{}).Where("id = ?", *category.ParentID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return utils.ErrParentCategoryInTrash
+		}
+	}
+
+	return restoreDeleted(ctx, r.db, &entity.Category{}, id)
+}
+
+// IsDescendant reports whether id is ancestorID or lies under it.
+func (r *categoryRepository) IsDescendant(ctx context.Context, id, ancestorID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.Category{}).
+		Where("id = ? AND id IN (?)", id, gorm.Expr(categoryDescendantsSQL, ancestorID)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *categoryRepository) checkParent(ctx context.Context, parentID uuid.UUID) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Category{}).Where("id = ?", parentID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return utils.ErrParentCategoryNotFound
+	}
+	return nil
+}
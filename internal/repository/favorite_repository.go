@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FavoriteRepository stores the services and branches customers saved.
+type FavoriteRepository interface {
+	GetServices(ctx context.Context, userID string) ([]entity.Service, error)
+	GetBranches(ctx context.Context, userID string) ([]entity.Branch, error)
+	AddService(ctx context.Context, userID string, serviceID uuid.UUID) error
+	RemoveService(ctx context.Context, userID string, serviceID uuid.UUID) error
+	AddBranch(ctx context.Context, userID string, branchID uuid.UUID) error
+	RemoveBranch(ctx context.Context, userID string, branchID uuid.UUID) error
+}
+
+type favoriteRepository struct {
+	db *gorm.DB
+}
+
+func NewFavoriteRepository(db *gorm.DB) FavoriteRepository {
+	return &favoriteRepository{db: db}
+}
+
+// GetServices returns the user's favorite services, most recently saved
+// first. Services in the trash are left out until they are restored.
+func (r *favoriteRepository) GetServices(ctx context.Context, userID string) ([]entity.Service, error) {
+	services := []entity.Service{}
+	err := r.db.WithContext(ctx).
+		Preload("Category").
+		Joins("JOIN favorite_services ON favorite_services.service_id = services.id").
+		Where("favorite_services.user_id = ?", userID).
+		Order("favorite_services.created_at DESC").
+		Find(&services).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := loadServiceRatings(r.db.WithContext(ctx), services); err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+// GetBranches returns the user's favorite branches, most recently saved
+// first. Branches in the trash are left out until they are restored.
+func (r *favoriteRepository) GetBranches(ctx context.Context, userID string) ([]entity.Branch, error) {
+	branches := []entity.Branch{}
+	err := r.db.WithContext(ctx).
+		Preload("ImageFile.Thumbnails").
+		Joins("JOIN favorite_branches ON favorite_branches.branch_id = branches.id").
+		Where("favorite_branches.user_id = ?", userID).
+		Order("favorite_branches.created_at DESC").
+		Find(&branches).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := loadBranchRatings(r.db.WithContext(ctx), branches); err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+// AddService saves the service as a favorite. Saving it again keeps the
+// first one.
+func (r *favoriteRepository) AddService(ctx context.Context, userID string, serviceID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).First(&entity.Service{}, "id = ?", serviceID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.ErrServiceNotFound
+		}
+		return err
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.FavoriteService{UserID: userID, ServiceID: serviceID}).Error
+}
+
+func (r *favoriteRepository) RemoveService(ctx context.Context, userID string, serviceID uuid.UUID) error {
+	return r.remove(ctx, &entity.FavoriteService{}, "user_id = ? AND service_id = ?", userID, serviceID)
+}
+
+// AddBranch saves the branch as a favorite. Saving it again keeps the first
+// one.
+func (r *favoriteRepository) AddBranch(ctx context.Context, userID string, branchID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).First(&entity.Branch{}, "id = ?", branchID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.ErrBranchNotFound
+		}
+		return err
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.FavoriteBranch{UserID: userID, BranchID: branchID}).Error
+}
+
+func (r *favoriteRepository) RemoveBranch(ctx context.Context, userID string, branchID uuid.UUID) error {
+	return r.remove(ctx, &entity.FavoriteBranch{}, "user_id = ? AND branch_id = ?", userID, branchID)
+}
+
+func (r *favoriteRepository) remove(ctx context.Context, model interface{}, query string, args ...interface{}) error {
+	result := r.db.WithContext(ctx).Where(query, args...).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
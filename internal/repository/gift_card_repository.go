@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GiftCardRepository interface {
+	Create(ctx context.Context, giftCard *entity.GiftCard) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.GiftCard, error)
+	GetByCode(ctx context.Context, code string) (*entity.GiftCard, error)
+	GetAll(ctx context.Context, params *params.GiftCardQueryParams) ([]entity.GiftCard, *transport.PaginationResponse, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	ExpireGiftCards(ctx context.Context, now time.Time) (int, error)
+	BuildQuery(ctx context.Context, params *params.GiftCardQueryParams, preloads ...string) *gorm.DB
+}
+
+type giftCardRepository struct {
+	db *gorm.DB
+}
+
+func NewGiftCardRepository(db *gorm.DB) GiftCardRepository {
+	return &giftCardRepository{db: db}
+}
+
+// Create issues the gift card and records its opening balance in the ledger.
+func (r *giftCardRepository) Create(ctx context.Context, giftCard *entity.GiftCard) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(giftCard).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&entity.LedgerEntry{
+			AccountType:  entity.LedgerAccountGiftCard,
+			AccountID:    giftCard.ID,
+			UserID:       giftCard.PurchaserUserID,
+			EntryType:    entity.LedgerEntryPurchase,
+			Amount:       giftCard.InitialBalance,
+			BalanceAfter: giftCard.Balance,
+		}).Error
+	})
+}
+
+func (r *giftCardRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.GiftCard, error) {
+	var giftCard entity.GiftCard
+	err := r.db.WithContext(ctx).First(&giftCard, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &giftCard, nil
+}
+
+func (r *giftCardRepository) GetByCode(ctx context.Context, code string) (*entity.GiftCard, error) {
+	var giftCard entity.GiftCard
+	err := r.db.WithContext(ctx).
+		First(&giftCard, "code = ?", strings.ToUpper(strings.TrimSpace(code))).Error
+	if err != nil {
+		return nil, err
+	}
+	return &giftCard, nil
+}
+
+func (r *giftCardRepository) GetAll(ctx context.Context, params *params.GiftCardQueryParams) ([]entity.GiftCard, *transport.PaginationResponse, error) {
+	var giftCards []entity.GiftCard
+
+	query := r.BuildQuery(ctx, params)
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.GiftCard{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&giftCards).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return giftCards, pagination, nil
+}
+
+func (r *giftCardRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.GiftCard{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ExpireGiftCards writes off the balance of gift cards past their expiry
+// date. It returns the number of gift cards expired.
+func (r *giftCardRepository) ExpireGiftCards(ctx context.Context, now time.Time) (int, error) {
+	var giftCards []entity.GiftCard
+	err := r.db.WithContext(ctx).
+		Where("expires_at IS NOT NULL AND expires_at <= ? AND balance > 0", now).
+		Find(&giftCards).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, giftCard := range giftCards {
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&entity.GiftCard{}).
+				Where("id = ?", giftCard.ID).
+				Update("balance", 0).Error; err != nil {
+				return err
+			}
+			return tx.Create(&entity.LedgerEntry{
+				AccountType:  entity.LedgerAccountGiftCard,
+				AccountID:    giftCard.ID,
+				EntryType:    entity.LedgerEntryExpire,
+				Amount:       -giftCard.Balance,
+				BalanceAfter: 0,
+			}).Error
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(giftCards), nil
+}
+
+func (r *giftCardRepository) BuildQuery(ctx context.Context, params *params.GiftCardQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	stringFilters := map[string]string{
+		"code":              strings.ToUpper(params.Code),
+		"purchaser_user_id": params.PurchaserUserID,
+	}
+	if params.IsActive != nil {
+		stringFilters["is_active"] = utils.ParseBoolToString(params.IsActive)
+	}
+	builder.ApplyStringFilters(stringFilters)
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("updated_at", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
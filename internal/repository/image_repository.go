@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ImageRepository interface {
+	Create(ctx context.Context, image *entity.Image) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Image, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetOrphans(ctx context.Context, createdBefore time.Time, limit int) ([]entity.Image, error)
+}
+
+type imageRepository struct {
+	db *gorm.DB
+}
+
+func NewImageRepository(db *gorm.DB) ImageRepository {
+	return &imageRepository{db: db}
+}
+
+func (r *imageRepository) Create(ctx context.Context, image *entity.Image) error {
+	return r.db.WithContext(ctx).Create(image).Error
+}
+
+func (r *imageRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Image, error) {
+	var image entity.Image
+	err := r.db.WithContext(ctx).Preload("Thumbnails").First(&image, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+func (r *imageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.Image{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetOrphans returns images created before createdBefore that no service or
+// branch points at, such as uploads whose request failed half way.
+func (r *imageRepository) GetOrphans(ctx context.Context, createdBefore time.Time, limit int) ([]entity.Image, error) {
+	var images []entity.Image
+	err := r.db.WithContext(ctx).
+		Preload("Thumbnails").
+		Where("created_at < ?", createdBefore).
+		Where("NOT EXISTS (SELECT 1 FROM services WHERE services.image_id = images.id)").
+		Where("NOT EXISTS (SELECT 1 FROM branches WHERE branches.image_id = images.id)").
+		Order("created_at asc").
+		Limit(limit).
+		Find(&images).Error
+	return images, err
+}
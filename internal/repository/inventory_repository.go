@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InventoryRepository interface {
+	CreateProduct(ctx context.Context, product *entity.Product) error
+	GetProductByID(ctx context.Context, id uuid.UUID) (*entity.Product, error)
+	GetProducts(ctx context.Context, params *params.ProductQueryParams) ([]entity.Product, *transport.PaginationResponse, error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	GetBranchStock(ctx context.Context, branchID uuid.UUID) ([]entity.BranchStock, error)
+	SetLowStockLevel(ctx context.Context, branchID, productID uuid.UUID, level int64) (*entity.BranchStock, error)
+	GetLowStock(ctx context.Context, branchID *uuid.UUID) ([]entity.BranchStock, error)
+	RecordMovement(ctx context.Context, movement *entity.StockMovement) error
+	GetMovements(ctx context.Context, params *params.StockMovementQueryParams) ([]entity.StockMovement, *transport.PaginationResponse, error)
+	GetConsumables(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceConsumable, error)
+	SetConsumables(ctx context.Context, serviceID uuid.UUID, consumables []entity.ServiceConsumable) error
+	ConsumeForBooking(ctx context.Context, booking *entity.Booking) error
+	BuildQuery(ctx context.Context, params *params.ProductQueryParams, preloads ...string) *gorm.DB
+}
+
+type inventoryRepository struct {
+	db *gorm.DB
+}
+
+func NewInventoryRepository(db *gorm.DB) InventoryRepository {
+	return &inventoryRepository{db: db}
+}
+
+func (r *inventoryRepository) CreateProduct(ctx context.Context, product *entity.Product) error {
+	return r.db.WithContext(ctx).Create(product).Error
+}
+
+func (r *inventoryRepository) GetProductByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	var product entity.Product
+	err := r.db.WithContext(ctx).First(&product, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *inventoryRepository) GetProducts(ctx context.Context, params *params.ProductQueryParams) ([]entity.Product, *transport.PaginationResponse, error) {
+	var products []entity.Product
+
+	query := r.BuildQuery(ctx, params)
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.Product{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&products).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return products, pagination, nil
+}
+
+func (r *inventoryRepository) UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.Product{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteProduct removes a product that has never moved stock, along with
+// its stock rows and its place in service consumables.
+func (r *inventoryRepository) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.StockMovement{}).Where("product_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return utils.ErrProductInUse
+		}
+
+		if err := tx.Where("product_id = ?", id).Delete(&entity.BranchStock{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", id).Delete(&entity.ServiceConsumable{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&entity.Product{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *inventoryRepository) GetBranchStock(ctx context.Context, branchID uuid.UUID) ([]entity.BranchStock, error) {
+	stock := []entity.BranchStock{}
+	err := r.db.WithContext(ctx).
+		Joins("Product").
+		Where("branch_stocks.branch_id = ?", branchID).
+		Order(`"Product"."name"`).
+		Find(&stock).Error
+	return stock, err
+}
+
+// SetLowStockLevel sets the level at which the branch's stock of the product
+// is reported as low, starting the branch at no stock if it had none.
+func (r *inventoryRepository) SetLowStockLevel(ctx context.Context, branchID, productID uuid.UUID, level int64) (*entity.BranchStock, error) {
+	if err := r.checkStockRefs(r.db.WithContext(ctx), branchID, productID); err != nil {
+		return nil, err
+	}
+
+	stock := entity.BranchStock{BranchID: branchID, ProductID: productID, LowStockLevel: level}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "branch_id"}, {Name: "product_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"low_stock_level", "updated_at"}),
+	}).Create(&stock).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.WithContext(ctx).Preload("Product").
+		First(&stock, "branch_id = ? AND product_id = ?", branchID, productID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stock, nil
+}
+
+// GetLowStock returns the active products at or below their low-stock level,
+// at one branch or, without branchID, at every branch.
+func (r *inventoryRepository) GetLowStock(ctx context.Context, branchID *uuid.UUID) ([]entity.BranchStock, error) {
+	query := r.db.WithContext(ctx).
+		Joins("Product").
+		Joins("Branch").
+		Where("branch_stocks.low_stock_level > 0 AND branch_stocks.quantity <= branch_stocks.low_stock_level").
+		Where(`"Product"."is_active" = ?`, true)
+	if branchID != nil {
+		query = query.Where("branch_stocks.branch_id = ?", *branchID)
+	}
+
+	stock := []entity.BranchStock{}
+	err := query.Order(`"Branch"."name", branch_stocks.quantity - branch_stocks.low_stock_level`).Find(&stock).Error
+	return stock, err
+}
+
+// RecordMovement applies a movement to the branch's stock and stores it with
+// the quantity left afterwards.
+func (r *inventoryRepository) RecordMovement(ctx context.Context, movement *entity.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.checkStockRefs(tx, movement.BranchID, movement.ProductID); err != nil {
+			return err
+		}
+		return applyStockMovement(tx, movement)
+	})
+}
+
+func (r *inventoryRepository) GetMovements(ctx context.Context, params *params.StockMovementQueryParams) ([]entity.StockMovement, *transport.PaginationResponse, error) {
+	var movements []entity.StockMovement
+
+	builder := utils.NewQueryBuilder(r.db, ctx)
+	builder.ApplyUUIDFilter("branch_id", params.BranchID).
+		ApplyUUIDFilter("product_id", params.ProductID).
+		ApplyInFilter("movement_type", params.MovementType)
+
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("created_at", "desc")
+	}
+	query := builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads("Product").
+		Build()
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.StockMovement{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&movements).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return movements, pagination, nil
+}
+
+func (r *inventoryRepository) GetConsumables(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceConsumable, error) {
+	consumables := []entity.ServiceConsumable{}
+	err := r.db.WithContext(ctx).Preload("Product").
+		Where("service_id = ?", serviceID).
+		Find(&consumables).Error
+	return consumables, err
+}
+
+// SetConsumables replaces the products one session of the service uses.
+func (r *inventoryRepository) SetConsumables(ctx context.Context, serviceID uuid.UUID, consumables []entity.ServiceConsumable) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.Service{}).Where("id = ?", serviceID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return utils.ErrServiceNotFound
+		}
+
+		productIDs := make([]uuid.UUID, len(consumables))
+		for i := range consumables {
+			productIDs[i] = consumables[i].ProductID
+		}
+		if len(productIDs) > 0 {
+			if err := tx.Model(&entity.Product{}).Where("id IN ?", productIDs).Count(&count).Error; err != nil {
+				return err
+			}
+			if int(count) != len(productIDs) {
+				return utils.ErrProductNotFound
+			}
+		}
+
+		if err := tx.Where("service_id = ?", serviceID).Delete(&entity.ServiceConsumable{}).Error; err != nil {
+			return err
+		}
+		if len(consumables) == 0 {
+			return nil
+		}
+		return tx.Omit("Product").Create(&consumables).Error
+	})
+}
+
+// ConsumeForBooking takes what the booked service uses out of the branch's
+// stock. Stock may go below zero, since the service has already been done;
+// consuming for the same booking again is a no-op.
+func (r *inventoryRepository) ConsumeForBooking(ctx context.Context, booking *entity.Booking) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.StockMovement{}).
+			Where("booking_id = ? AND movement_type = ?", booking.ID, entity.StockMovementConsumption).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		var consumables []entity.ServiceConsumable
+		if err := tx.Where("service_id = ?", booking.ServiceID).Find(&consumables).Error; err != nil {
+			return err
+		}
+
+		for _, consumable := range consumables {
+			if err := applyStockMovement(tx, &entity.StockMovement{
+				BranchID:     booking.BranchID,
+				ProductID:    consumable.ProductID,
+				MovementType: entity.StockMovementConsumption,
+				Quantity:     -consumable.Quantity,
+				BookingID:    &booking.ID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *inventoryRepository) checkStockRefs(db *gorm.DB, branchID, productID uuid.UUID) error {
+	var count int64
+	if err := db.Model(&entity.Branch{}).Where("id = ?", branchID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return utils.ErrBranchNotFound
+	}
+	if err := db.Model(&entity.Product{}).Where("id = ?", productID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return utils.ErrProductNotFound
+	}
+	return nil
+}
+
+// applyStockMovement locks the branch's stock row of the product, creating
+// it if needed, and moves its quantity. Only consumption may take stock
+// below zero.
+func applyStockMovement(tx *gorm.DB, movement *entity.StockMovement) error {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.BranchStock{
+		BranchID:  movement.BranchID,
+		ProductID: movement.ProductID,
+	}).Error; err != nil {
+		return err
+	}
+
+	var stock entity.BranchStock
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&stock, "branch_id = ? AND product_id = ?", movement.BranchID, movement.ProductID).Error; err != nil {
+		return err
+	}
+
+	after := stock.Quantity + movement.Quantity
+	if after < 0 && movement.Quantity < 0 && movement.MovementType != entity.StockMovementConsumption {
+		return utils.ErrInsufficientStock
+	}
+
+	if err := tx.Model(&entity.BranchStock{}).
+		Where("branch_id = ? AND product_id = ?", stock.BranchID, stock.ProductID).
+		Update("quantity", after).Error; err != nil {
+		return err
+	}
+
+	movement.QuantityAfter = after
+	return tx.Omit("Product").Create(movement).Error
+}
+
+func (r *inventoryRepository) BuildQuery(ctx context.Context, params *params.ProductQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	if params.Name != "" {
+		builder.ApplyCondition("name ILIKE ?", "%"+params.Name+"%")
+	}
+	if params.IsRetail != nil {
+		builder.ApplyStringFilters(map[string]string{
+			"is_retail": utils.ParseBoolToString(params.IsRetail),
+		})
+	}
+	if params.IsActive != nil {
+		builder.ApplyStringFilters(map[string]string{
+			"is_active": utils.ParseBoolToString(params.IsActive),
+		})
+	}
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("name", "asc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
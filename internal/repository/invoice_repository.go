@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvoiceRepository interface {
+	Create(ctx context.Context, invoice *entity.Invoice) error
+	GetByBookingID(ctx context.Context, bookingID uuid.UUID) (*entity.Invoice, error)
+}
+
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+// Create numbers and stores the invoice. The branch sequence row is locked
+// until the invoice is committed, so a failed insert never burns a number.
+// If the booking was invoiced concurrently the existing invoice is loaded
+// into invoice instead.
+func (r *invoiceRepository) Create(ctx context.Context, invoice *entity.Invoice) error {
+	// Start a transaction
+	tx := r.db.WithContext(ctx).Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.InvoiceSequence{BranchID: invoice.BranchID}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var sequence entity.InvoiceSequence
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&sequence, "branch_id = ?", invoice.BranchID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var existing entity.Invoice
+	err := tx.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).First(&existing, "booking_id = ?", invoice.BookingID).Error
+	if err == nil {
+		*invoice = existing
+		return tx.Commit().Error
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return err
+	}
+
+	sequence.LastNumber++
+	invoice.Sequence = sequence.LastNumber
+	invoice.Number = invoiceNumber(invoice.BranchID, sequence.LastNumber)
+
+	if err := tx.Model(&sequence).Update("last_number", sequence.LastNumber).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Create(invoice).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *invoiceRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) (*entity.Invoice, error) {
+	var invoice entity.Invoice
+	err := r.db.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		First(&invoice, "booking_id = ?", bookingID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// invoiceNumber prefixes the branch sequence with a short branch code,
+// e.g. INV-1A2B3C4D-000042.
+func invoiceNumber(branchID uuid.UUID, sequence int64) string {
+	code := strings.ToUpper(strings.ReplaceAll(branchID.String(), "-", "")[:8])
+	return fmt.Sprintf("INV-%s-%06d", code, sequence)
+}
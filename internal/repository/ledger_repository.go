@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type LedgerRepository interface {
+	GetAll(ctx context.Context, params *params.LedgerQueryParams) ([]entity.LedgerEntry, *transport.PaginationResponse, error)
+	BuildQuery(ctx context.Context, params *params.LedgerQueryParams, preloads ...string) *gorm.DB
+}
+
+type ledgerRepository struct {
+	db *gorm.DB
+}
+
+func NewLedgerRepository(db *gorm.DB) LedgerRepository {
+	return &ledgerRepository{db: db}
+}
+
+func (r *ledgerRepository) GetAll(ctx context.Context, params *params.LedgerQueryParams) ([]entity.LedgerEntry, *transport.PaginationResponse, error) {
+	var entries []entity.LedgerEntry
+
+	query := r.BuildQuery(ctx, params)
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.LedgerEntry{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return entries, pagination, nil
+}
+
+func (r *ledgerRepository) BuildQuery(ctx context.Context, params *params.LedgerQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	builder.ApplyUUIDFilter("account_id", params.AccountID).
+		ApplyUUIDFilter("booking_id", params.BookingID)
+
+	builder.ApplyStringFilters(map[string]string{
+		"account_type": params.AccountType,
+		"user_id":      params.UserID,
+	})
+
+	builder.ApplyInFilter("entry_type", params.EntryType)
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("created_at", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/config"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoyaltyRepository interface {
+	CreateRule(ctx context.Context, rule *entity.LoyaltyRule) error
+	GetRuleByID(ctx context.Context, id uuid.UUID) (*entity.LoyaltyRule, error)
+	GetRules(ctx context.Context) ([]entity.LoyaltyRule, error)
+	GetRuleForCategory(ctx context.Context, categoryID uuid.UUID) (*entity.LoyaltyRule, error)
+	UpdateRule(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	GetBalance(ctx context.Context, userID string) (int64, error)
+	GetHistory(ctx context.Context, params *params.PointsQueryParams) ([]entity.PointsEntry, *transport.PaginationResponse, error)
+	AddPoints(ctx context.Context, entry *entity.PointsEntry) error
+	DeductPoints(ctx context.Context, entry *entity.PointsEntry) error
+	ExpirePoints(ctx context.Context, now time.Time) (int, error)
+	BuildQuery(ctx context.Context, params *params.PointsQueryParams, preloads ...string) *gorm.DB
+}
+
+type loyaltyRepository struct {
+	db *gorm.DB
+}
+
+func NewLoyaltyRepository(db *gorm.DB) LoyaltyRepository {
+	return &loyaltyRepository{db: db}
+}
+
+func (r *loyaltyRepository) CreateRule(ctx context.Context, rule *entity.LoyaltyRule) error {
+	if rule.CategoryID != nil {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&entity.Category{}).
+			Where("id = ?", rule.CategoryID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return utils.ErrCategoryNotFound
+		}
+	}
+	return r.db.WithContext(ctx).Omit("Category").Create(rule).Error
+}
+
+func (r *loyaltyRepository) GetRuleByID(ctx context.Context, id uuid.UUID) (*entity.LoyaltyRule, error) {
+	var rule entity.LoyaltyRule
+	err := r.db.WithContext(ctx).Preload("Category").First(&rule, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *loyaltyRepository) GetRules(ctx context.Context) ([]entity.LoyaltyRule, error) {
+	var rules []entity.LoyaltyRule
+	err := r.db.WithContext(ctx).Preload("Category").
+		Order("category_id NULLS FIRST").Find(&rules).Error
+	return rules, err
+}
+
+// GetRuleForCategory returns the active rule for the category, falling back
+// to the active default rule.
+func (r *loyaltyRepository) GetRuleForCategory(ctx context.Context, categoryID uuid.UUID) (*entity.LoyaltyRule, error) {
+	var rule entity.LoyaltyRule
+	err := r.db.WithContext(ctx).
+		Where("is_active = ? AND (category_id = ? OR category_id IS NULL)", true, categoryID).
+		Order("category_id NULLS LAST").
+		First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *loyaltyRepository) UpdateRule(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.LoyaltyRule{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *loyaltyRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.LoyaltyRule{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *loyaltyRepository) GetBalance(ctx context.Context, userID string) (int64, error) {
+	return pointsBalance(r.db.WithContext(ctx), userID, time.Now())
+}
+
+func (r *loyaltyRepository) GetHistory(ctx context.Context, params *params.PointsQueryParams) ([]entity.PointsEntry, *transport.PaginationResponse, error) {
+	var entries []entity.PointsEntry
+
+	query := r.BuildQuery(ctx, params)
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.PointsEntry{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return entries, pagination, nil
+}
+
+// AddPoints credits a new lot of points to the user. Earning for a booking
+// that has already earned is a no-op.
+func (r *loyaltyRepository) AddPoints(ctx context.Context, entry *entity.PointsEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, entry.UserID); err != nil {
+			return err
+		}
+
+		if entry.BookingID != nil {
+			var count int64
+			if err := tx.Model(&entity.PointsEntry{}).
+				Where("booking_id = ? AND entry_type = ?", entry.BookingID, entry.EntryType).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+		}
+
+		return creditPoints(tx, entry)
+	})
+}
+
+// DeductPoints takes points from the user's oldest lots first.
+func (r *loyaltyRepository) DeductPoints(ctx context.Context, entry *entity.PointsEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, entry.UserID); err != nil {
+			return err
+		}
+		return debitPoints(tx, entry)
+	})
+}
+
+// ExpirePoints writes off the unspent part of lots past their expiry date.
+// It returns the number of lots expired.
+func (r *loyaltyRepository) ExpirePoints(ctx context.Context, now time.Time) (int, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).Model(&entity.PointsEntry{}).
+		Where("remaining > 0 AND expires_at IS NOT NULL AND expires_at <= ?", now).
+		Distinct().Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, userID := range userIDs {
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := lockUser(tx, userID); err != nil {
+				return err
+			}
+
+			var lots []entity.PointsEntry
+			if err := tx.Where("user_id = ? AND remaining > 0 AND expires_at IS NOT NULL AND expires_at <= ?", userID, now).
+				Order("expires_at").Find(&lots).Error; err != nil {
+				return err
+			}
+
+			balance, err := pointsBalance(tx, userID, now)
+			if err != nil {
+				return err
+			}
+
+			for _, lot := range lots {
+				if err := tx.Model(&lot).Update("remaining", 0).Error; err != nil {
+					return err
+				}
+				if err := tx.Create(&entity.PointsEntry{
+					UserID:       userID,
+					EntryType:    entity.PointsEntryExpire,
+					Points:       -lot.Remaining,
+					BalanceAfter: balance,
+					Reason:       "points expired",
+				}).Error; err != nil {
+					return err
+				}
+			}
+
+			expired += len(lots)
+			return nil
+		})
+		if err != nil {
+			return expired, err
+		}
+	}
+
+	return expired, nil
+}
+
+// redeemPoints spends the points the booking was discounted with.
+func redeemPoints(tx *gorm.DB, booking *entity.Booking) error {
+	if err := lockUser(tx, booking.UserID); err != nil {
+		return err
+	}
+	return debitPoints(tx, &entity.PointsEntry{
+		UserID:    booking.UserID,
+		EntryType: entity.PointsEntryRedeem,
+		Points:    -booking.PointsRedeemed,
+		BookingID: &booking.ID,
+	})
+}
+
+// restorePoints gives back the points a booking redeemed as a fresh lot.
+func restorePoints(tx *gorm.DB, booking *entity.Booking) error {
+	if err := lockUser(tx, booking.UserID); err != nil {
+		return err
+	}
+	return creditPoints(tx, &entity.PointsEntry{
+		UserID:    booking.UserID,
+		EntryType: entity.PointsEntryRestore,
+		Points:    booking.PointsRedeemed,
+		BookingID: &booking.ID,
+	})
+}
+
+// lockUser serialises changes to a user's points or membership on the user row.
+func lockUser(tx *gorm.DB, userID string) error {
+	var user entity.User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, "id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.ErrUserNotFound
+	}
+	return err
+}
+
+func pointsBalance(db *gorm.DB, userID string, now time.Time) (int64, error) {
+	var balance int64
+	err := db.Model(&entity.PointsEntry{}).
+		Where("user_id = ? AND remaining > 0 AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Select("COALESCE(SUM(remaining), 0)").Scan(&balance).Error
+	return balance, err
+}
+
+func creditPoints(tx *gorm.DB, entry *entity.PointsEntry) error {
+	now := time.Now()
+	if entry.ExpiresAt == nil {
+		expiresAt := now.AddDate(0, 0, config.LoyaltyPointsValidityDays())
+		entry.ExpiresAt = &expiresAt
+	}
+	entry.Remaining = entry.Points
+
+	balance, err := pointsBalance(tx, entry.UserID, now)
+	if err != nil {
+		return err
+	}
+	entry.BalanceAfter = balance + entry.Points
+
+	return tx.Create(entry).Error
+}
+
+func debitPoints(tx *gorm.DB, entry *entity.PointsEntry) error {
+	now := time.Now()
+	balance, err := pointsBalance(tx, entry.UserID, now)
+	if err != nil {
+		return err
+	}
+
+	owed := -entry.Points
+	if owed > balance {
+		return utils.ErrInsufficientPoints
+	}
+
+	var lots []entity.PointsEntry
+	if err := tx.Where("user_id = ? AND remaining > 0 AND (expires_at IS NULL OR expires_at > ?)", entry.UserID, now).
+		Order("expires_at, created_at").Find(&lots).Error; err != nil {
+		return err
+	}
+
+	for _, lot := range lots {
+		if owed == 0 {
+			break
+		}
+		used := lot.Remaining
+		if used > owed {
+			used = owed
+		}
+		if err := tx.Model(&lot).Update("remaining", lot.Remaining-used).Error; err != nil {
+			return err
+		}
+		owed -= used
+	}
+
+	entry.Remaining = 0
+	entry.ExpiresAt = nil
+	entry.BalanceAfter = balance + entry.Points
+
+	return tx.Create(entry).Error
+}
+
+func (r *loyaltyRepository) BuildQuery(ctx context.Context, params *params.PointsQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	builder.ApplyStringFilters(map[string]string{
+		"user_id": params.UserID,
+	})
+
+	builder.ApplyInFilter("entry_type", params.EntryType)
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("created_at", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
@@ -0,0 +1,373 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MembershipRepository interface {
+	CreatePlan(ctx context.Context, plan *entity.MembershipPlan) error
+	GetPlanByID(ctx context.Context, id uuid.UUID) (*entity.MembershipPlan, error)
+	GetPlans(ctx context.Context, params *params.MembershipPlanQueryParams) ([]entity.MembershipPlan, *transport.PaginationResponse, error)
+	UpdatePlan(ctx context.Context, plan *entity.MembershipPlan, replaceBenefits bool) error
+	DeletePlan(ctx context.Context, id uuid.UUID) error
+	CreateSubscription(ctx context.Context, subscription *entity.Subscription, payment *entity.SubscriptionPayment) error
+	GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*entity.Subscription, error)
+	GetUserSubscription(ctx context.Context, userID string) (*entity.Subscription, error)
+	GetSubscriptions(ctx context.Context, params *params.SubscriptionQueryParams) ([]entity.Subscription, *transport.PaginationResponse, error)
+	GetDueSubscriptions(ctx context.Context, now time.Time) ([]entity.Subscription, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	RenewSubscription(ctx context.Context, subscription *entity.Subscription, payment *entity.SubscriptionPayment) error
+	RecordPayment(ctx context.Context, payment *entity.SubscriptionPayment) error
+	BuildPlanQuery(ctx context.Context, params *params.MembershipPlanQueryParams, preloads ...string) *gorm.DB
+	BuildSubscriptionQuery(ctx context.Context, params *params.SubscriptionQueryParams, preloads ...string) *gorm.DB
+}
+
+type membershipRepository struct {
+	db *gorm.DB
+}
+
+func NewMembershipRepository(db *gorm.DB) MembershipRepository {
+	return &membershipRepository{db: db}
+}
+
+// openSubscriptionStatuses are the statuses that still bind a user to a plan.
+var openSubscriptionStatuses = []string{entity.SubscriptionActive, entity.SubscriptionPastDue}
+
+func (r *membershipRepository) CreatePlan(ctx context.Context, plan *entity.MembershipPlan) error {
+	if err := r.checkServicesExist(ctx, plan.Benefits); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Omit("Benefits.Service").Create(plan).Error
+}
+
+func (r *membershipRepository) GetPlanByID(ctx context.Context, id uuid.UUID) (*entity.MembershipPlan, error) {
+	var plan entity.MembershipPlan
+	err := r.db.WithContext(ctx).
+		Preload("Benefits.Service").
+		First(&plan, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *membershipRepository) GetPlans(ctx context.Context, params *params.MembershipPlanQueryParams) ([]entity.MembershipPlan, *transport.PaginationResponse, error) {
+	var plans []entity.MembershipPlan
+
+	query := r.BuildPlanQuery(ctx, params, "Benefits.Service")
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.MembershipPlan{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&plans).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return plans, pagination, nil
+}
+
+func (r *membershipRepository) UpdatePlan(ctx context.Context, plan *entity.MembershipPlan, replaceBenefits bool) error {
+	if replaceBenefits {
+		if err := r.checkServicesExist(ctx, plan.Benefits); err != nil {
+			return err
+		}
+	}
+
+	// Start a transaction
+	tx := r.db.WithContext(ctx).Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Omit("CreatedAt", "Benefits").Save(plan).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Usage counters are copied at the start of each period, so benefits
+	// can be replaced without touching current subscriptions
+	if replaceBenefits {
+		if err := tx.Where("plan_id = ?", plan.ID).Delete(&entity.MembershipBenefit{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if len(plan.Benefits) > 0 {
+			for i := range plan.Benefits {
+				plan.Benefits[i].PlanID = plan.ID
+			}
+			if err := tx.Omit("Service").Create(&plan.Benefits).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *membershipRepository) DeletePlan(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Subscription{}).
+		Where("plan_id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return utils.ErrMembershipPlanInUse
+	}
+
+	result := r.db.WithContext(ctx).Delete(&entity.MembershipPlan{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateSubscription stores a paid subscription with the usage counters of
+// its first period. A user has at most one open subscription.
+func (r *membershipRepository) CreateSubscription(ctx context.Context, subscription *entity.Subscription, payment *entity.SubscriptionPayment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, subscription.UserID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entity.Subscription{}).
+			Where("user_id = ? AND status IN ?", subscription.UserID, openSubscriptionStatuses).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return utils.ErrAlreadySubscribed
+		}
+
+		if err := tx.Omit("Plan").Create(subscription).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(payment).Error
+	})
+}
+
+func (r *membershipRepository) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*entity.Subscription, error) {
+	var subscription entity.Subscription
+	if err := r.db.WithContext(ctx).Preload("Plan").First(&subscription, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	if err := r.loadCurrentUsages(r.db.WithContext(ctx), &subscription); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetUserSubscription returns the user's open subscription with the usage
+// counters of its current period.
+func (r *membershipRepository) GetUserSubscription(ctx context.Context, userID string) (*entity.Subscription, error) {
+	var subscription entity.Subscription
+	err := r.db.WithContext(ctx).
+		Preload("Plan").
+		Where("user_id = ? AND status IN ?", userID, openSubscriptionStatuses).
+		First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadCurrentUsages(r.db.WithContext(ctx), &subscription); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *membershipRepository) loadCurrentUsages(db *gorm.DB, subscription *entity.Subscription) error {
+	return db.Where("subscription_id = ? AND period_start = ?", subscription.ID, subscription.CurrentPeriodStart).
+		Find(&subscription.Usages).Error
+}
+
+func (r *membershipRepository) GetSubscriptions(ctx context.Context, params *params.SubscriptionQueryParams) ([]entity.Subscription, *transport.PaginationResponse, error) {
+	var subscriptions []entity.Subscription
+
+	query := r.BuildSubscriptionQuery(ctx, params, "Plan")
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.Subscription{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&subscriptions).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return subscriptions, pagination, nil
+}
+
+// GetDueSubscriptions returns the open subscriptions whose period has ended.
+func (r *membershipRepository) GetDueSubscriptions(ctx context.Context, now time.Time) ([]entity.Subscription, error) {
+	var subscriptions []entity.Subscription
+	err := r.db.WithContext(ctx).
+		Preload("Plan.Benefits").
+		Where("status IN ? AND current_period_end <= ?", openSubscriptionStatuses, now).
+		Order("current_period_end asc").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *membershipRepository) UpdateSubscription(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.Subscription{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RenewSubscription moves the subscription to the period it was charged for
+// and opens the usage counters of that period.
+func (r *membershipRepository) RenewSubscription(ctx context.Context, subscription *entity.Subscription, payment *entity.SubscriptionPayment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.Subscription{}).Where("id = ?", subscription.ID).Updates(map[string]interface{}{
+			"status":               entity.SubscriptionActive,
+			"current_period_start": subscription.CurrentPeriodStart,
+			"current_period_end":   subscription.CurrentPeriodEnd,
+			"last_charge_id":       subscription.LastChargeID,
+		}).Error; err != nil {
+			return err
+		}
+
+		if len(subscription.Usages) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&subscription.Usages).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(payment).Error
+	})
+}
+
+func (r *membershipRepository) RecordPayment(ctx context.Context, payment *entity.SubscriptionPayment) error {
+	return r.db.WithContext(ctx).Create(payment).Error
+}
+
+// redeemSubscriptionUsage uses one included session of the booked service
+// from the current period of the user's membership.
+func redeemSubscriptionUsage(tx *gorm.DB, booking *entity.Booking) error {
+	var usage entity.SubscriptionUsage
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&usage, "id = ?", booking.SubscriptionUsageID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrSubscriptionNotFound
+		}
+		return err
+	}
+
+	var subscription entity.Subscription
+	if err := tx.First(&subscription, "id = ?", usage.SubscriptionID).Error; err != nil {
+		return err
+	}
+
+	if subscription.UserID != booking.UserID || usage.ServiceID != booking.ServiceID ||
+		!subscription.CurrentPeriodStart.Equal(usage.PeriodStart) || !subscription.IsCurrent(time.Now()) {
+		return utils.ErrSubscriptionNotFound
+	}
+	if usage.Used >= usage.Included {
+		return utils.ErrMembershipCreditsExhausted
+	}
+
+	return tx.Model(&usage).UpdateColumn("used", gorm.Expr("used + 1")).Error
+}
+
+// restoreSubscriptionUsage gives the included session back to its period.
+func restoreSubscriptionUsage(tx *gorm.DB, booking *entity.Booking) error {
+	return tx.Model(&entity.SubscriptionUsage{}).
+		Where("id = ? AND used > 0", booking.SubscriptionUsageID).
+		UpdateColumn("used", gorm.Expr("used - 1")).Error
+}
+
+func (r *membershipRepository) BuildPlanQuery(ctx context.Context, params *params.MembershipPlanQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	if params.IsActive != nil {
+		builder.ApplyStringFilters(map[string]string{
+			"is_active": utils.ParseBoolToString(params.IsActive),
+		})
+	}
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("price", "asc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
+
+func (r *membershipRepository) BuildSubscriptionQuery(ctx context.Context, params *params.SubscriptionQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	builder.ApplyStringFilters(map[string]string{
+		"user_id": params.UserID,
+	})
+	builder.ApplyUUIDFilter("plan_id", params.PlanID)
+
+	// Apply status filter (supports comma-separated values)
+	builder.ApplyInFilter("status", params.Status)
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("updated_at", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
+
+func (r *membershipRepository) checkServicesExist(ctx context.Context, benefits []entity.MembershipBenefit) error {
+	serviceIDs := make([]uuid.UUID, 0, len(benefits))
+	for _, benefit := range benefits {
+		serviceIDs = append(serviceIDs, benefit.ServiceID)
+	}
+	if len(serviceIDs) == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Service{}).
+		Where("id IN ?", serviceIDs).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(serviceIDs) {
+		return utils.ErrServiceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *entity.Notification) error
+	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]entity.Notification, *transport.PaginationResponse, error)
+	MarkRead(ctx context.Context, id uuid.UUID, userID string) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	return r.db.WithContext(ctx).Create(notification).Error
+}
+
+// GetByUserID returns the user's notifications, newest first.
+func (r *notificationRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]entity.Notification, *transport.PaginationResponse, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&entity.Notification{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	notifications := []entity.Notification{}
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return notifications, utils.CalculatePagination(total, limit, offset), nil
+}
+
+// MarkRead marks one of the user's notifications as read. Marking it again
+// keeps the first time it was read.
+func (r *notificationRepository) MarkRead(ctx context.Context, id uuid.UUID, userID string) error {
+	result := r.db.WithContext(ctx).Model(&entity.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now()))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PackageRepository interface {
+	Create(ctx context.Context, pkg *entity.Package) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Package, error)
+	GetAll(ctx context.Context, params *params.PackageQueryParams) ([]entity.Package, *transport.PaginationResponse, error)
+	Update(ctx context.Context, pkg *entity.Package, replaceItems bool) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Sell(ctx context.Context, customerPackage *entity.CustomerPackage) error
+	GetCustomerPackageByID(ctx context.Context, id uuid.UUID) (*entity.CustomerPackage, error)
+	GetCustomerPackagesByUserID(ctx context.Context, userID string) ([]entity.CustomerPackage, error)
+	ExpireCustomerPackages(ctx context.Context, now time.Time) (int, error)
+	BuildQuery(ctx context.Context, params *params.PackageQueryParams, preloads ...string) *gorm.DB
+}
+
+type packageRepository struct {
+	db *gorm.DB
+}
+
+func NewPackageRepository(db *gorm.DB) PackageRepository {
+	return &packageRepository{db: db}
+}
+
+func (r *packageRepository) Create(ctx context.Context, pkg *entity.Package) error {
+	if err := r.checkServicesExist(ctx, pkg.Items); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Omit("Items.Service").Create(pkg).Error
+}
+
+func (r *packageRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Package, error) {
+	var pkg entity.Package
+	err := r.db.WithContext(ctx).
+		Preload("Items.Service").
+		First(&pkg, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+func (r *packageRepository) GetAll(ctx context.Context, params *params.PackageQueryParams) ([]entity.Package, *transport.PaginationResponse, error) {
+	var packages []entity.Package
+
+	query := r.BuildQuery(ctx, params, "Items.Service")
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.Package{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&packages).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return packages, pagination, nil
+}
+
+func (r *packageRepository) Update(ctx context.Context, pkg *entity.Package, replaceItems bool) error {
+	if replaceItems {
+		if err := r.checkServicesExist(ctx, pkg.Items); err != nil {
+			return err
+		}
+	}
+
+	// Start a transaction
+	tx := r.db.WithContext(ctx).Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Omit("CreatedAt", "Items").Save(pkg).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Items only affect future sales, so they can be replaced freely
+	if replaceItems {
+		if err := tx.Where("package_id = ?", pkg.ID).Delete(&entity.PackageItem{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		for i := range pkg.Items {
+			pkg.Items[i].PackageID = pkg.ID
+		}
+		if err := tx.Omit("Service").Create(&pkg.Items).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *packageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.Package{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Sell stores the purchased package with its credits and records a PURCHASE
+// ledger entry for every credit.
+func (r *packageRepository) Sell(ctx context.Context, customerPackage *entity.CustomerPackage) error {
+	var user entity.User
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", customerPackage.UserID).Error; err != nil {
+		return utils.ErrUserNotFound
+	}
+
+	// Start a transaction
+	tx := r.db.WithContext(ctx).Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Create(customerPackage).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, credit := range customerPackage.Credits {
+		entry := &entity.LedgerEntry{
+			AccountType:  entity.LedgerAccountPackageCredit,
+			AccountID:    credit.ID,
+			UserID:       &customerPackage.UserID,
+			EntryType:    entity.LedgerEntryPurchase,
+			Amount:       int64(credit.Total),
+			BalanceAfter: int64(credit.Remaining),
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *packageRepository) GetCustomerPackageByID(ctx context.Context, id uuid.UUID) (*entity.CustomerPackage, error) {
+	var customerPackage entity.CustomerPackage
+	err := r.db.WithContext(ctx).
+		Preload("Credits").
+		First(&customerPackage, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &customerPackage, nil
+}
+
+func (r *packageRepository) GetCustomerPackagesByUserID(ctx context.Context, userID string) ([]entity.CustomerPackage, error) {
+	var customerPackages []entity.CustomerPackage
+	err := r.db.WithContext(ctx).
+		Preload("Credits").
+		Where("user_id = ?", userID).
+		Order("expires_at asc").
+		Find(&customerPackages).Error
+	return customerPackages, err
+}
+
+// ExpireCustomerPackages marks packages past their expiry date as EXPIRED and
+// writes off their remaining credits. It returns the number of packages expired.
+func (r *packageRepository) ExpireCustomerPackages(ctx context.Context, now time.Time) (int, error) {
+	var customerPackages []entity.CustomerPackage
+	err := r.db.WithContext(ctx).
+		Preload("Credits").
+		Where("status = ? AND expires_at <= ?", entity.CustomerPackageActive, now).
+		Find(&customerPackages).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, customerPackage := range customerPackages {
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, credit := range customerPackage.Credits {
+				if credit.Remaining == 0 {
+					continue
+				}
+				if err := tx.Model(&entity.CustomerPackageCredit{}).
+					Where("id = ?", credit.ID).
+					Update("remaining", 0).Error; err != nil {
+					return err
+				}
+				entry := &entity.LedgerEntry{
+					AccountType:  entity.LedgerAccountPackageCredit,
+					AccountID:    credit.ID,
+					UserID:       &customerPackage.UserID,
+					EntryType:    entity.LedgerEntryExpire,
+					Amount:       -int64(credit.Remaining),
+					BalanceAfter: 0,
+				}
+				if err := tx.Create(entry).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Model(&entity.CustomerPackage{}).
+				Where("id = ?", customerPackage.ID).
+				Update("status", entity.CustomerPackageExpired).Error
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(customerPackages), nil
+}
+
+func (r *packageRepository) BuildQuery(ctx context.Context, params *params.PackageQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	stringFilters := map[string]string{
+		"name": params.Name,
+	}
+	if params.IsActive != nil {
+		stringFilters["is_active"] = utils.ParseBoolToString(params.IsActive)
+	}
+	builder.ApplyStringFilters(stringFilters)
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("updated_at", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
+
+func (r *packageRepository) checkServicesExist(ctx context.Context, items []entity.PackageItem) error {
+	seen := make(map[uuid.UUID]bool, len(items))
+	serviceIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if !seen[item.ServiceID] {
+			seen[item.ServiceID] = true
+			serviceIDs = append(serviceIDs, item.ServiceID)
+		}
+	}
+	if len(serviceIDs) == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Service{}).
+		Where("id IN ?", serviceIDs).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(serviceIDs) {
+		return utils.ErrServiceNotFound
+	}
+	return nil
+}
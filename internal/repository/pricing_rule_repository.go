@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PricingRuleRepository interface {
+	Create(ctx context.Context, rule *entity.PricingRule) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.PricingRule, error)
+	GetAll(ctx context.Context, params *params.PricingRuleQueryParams) ([]entity.PricingRule, *transport.PaginationResponse, error)
+	GetActive(ctx context.Context) ([]entity.PricingRule, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	BuildQuery(ctx context.Context, params *params.PricingRuleQueryParams, preloads ...string) *gorm.DB
+}
+
+type pricingRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewPricingRuleRepository(db *gorm.DB) PricingRuleRepository {
+	return &pricingRuleRepository{db: db}
+}
+
+func (r *pricingRuleRepository) Create(ctx context.Context, rule *entity.PricingRule) error {
+	checks := []struct {
+		id    *uuid.UUID
+		model interface{}
+		err   error
+	}{
+		{rule.ServiceID, &entity.Service{}, utils.ErrServiceNotFound},
+		{rule.CategoryID, &entity.Category{}, utils.ErrCategoryNotFound},
+		{rule.BranchID, &entity.Branch{}, utils.ErrBranchNotFound},
+	}
+	for _, check := range checks {
+		if check.id == nil {
+			continue
+		}
+		var count int64
+		if err := r.db.WithContext(ctx).Model(check.model).Where("id = ?", check.id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return check.err
+		}
+	}
+
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *pricingRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.PricingRule, error) {
+	var rule entity.PricingRule
+	err := r.db.WithContext(ctx).First(&rule, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *pricingRuleRepository) GetAll(ctx context.Context, params *params.PricingRuleQueryParams) ([]entity.PricingRule, *transport.PaginationResponse, error) {
+	var rules []entity.PricingRule
+
+	query := r.BuildQuery(ctx, params)
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.PricingRule{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&rules).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return rules, pagination, nil
+}
+
+// GetActive returns the active rules, highest priority first.
+func (r *pricingRuleRepository) GetActive(ctx context.Context) ([]entity.PricingRule, error) {
+	var rules []entity.PricingRule
+	err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Order("priority desc, updated_at desc").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *pricingRuleRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.PricingRule{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *pricingRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.PricingRule{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *pricingRuleRepository) BuildQuery(ctx context.Context, params *params.PricingRuleQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	builder.ApplyUUIDFilter("service_id", params.ServiceID).
+		ApplyUUIDFilter("category_id", params.CategoryID).
+		ApplyUUIDFilter("branch_id", params.BranchID)
+
+	if params.IsActive != nil {
+		builder.ApplyStringFilters(map[string]string{
+			"is_active": utils.ParseBoolToString(params.IsActive),
+		})
+	}
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("priority", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *entity.Promotion) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error)
+	GetByCode(ctx context.Context, code string) (*entity.Promotion, error)
+	GetAll(ctx context.Context, params *params.PromotionQueryParams) ([]entity.Promotion, *transport.PaginationResponse, error)
+	Update(ctx context.Context, promotion *entity.Promotion, replaceRestrictions bool) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	CountUserRedemptions(ctx context.Context, promotionID uuid.UUID, userID string) (int64, error)
+	BuildQuery(ctx context.Context, params *params.PromotionQueryParams, preloads ...string) *gorm.DB
+}
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *entity.Promotion) error {
+	var existing entity.Promotion
+	err := r.db.WithContext(ctx).Where("code = ?", promotion.Code).First(&existing).Error
+	if err == nil {
+		return gorm.ErrDuplicatedKey
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := r.checkRestrictionsExist(ctx, promotion); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(promotion).Error
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	err := r.db.WithContext(ctx).
+		Preload("Services").
+		Preload("Categories").
+		Preload("Branches").
+		First(&promotion, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) GetByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	err := r.db.WithContext(ctx).
+		Preload("Services").
+		Preload("Categories").
+		Preload("Branches").
+		First(&promotion, "code = ?", strings.ToUpper(code)).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) GetAll(ctx context.Context, params *params.PromotionQueryParams) ([]entity.Promotion, *transport.PaginationResponse, error) {
+	var promotions []entity.Promotion
+
+	query := r.BuildQuery(ctx, params, "Services", "Categories", "Branches")
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.Promotion{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&promotions).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return promotions, pagination, nil
+}
+
+func (r *promotionRepository) Update(ctx context.Context, promotion *entity.Promotion, replaceRestrictions bool) error {
+	if replaceRestrictions {
+		if err := r.checkRestrictionsExist(ctx, promotion); err != nil {
+			return err
+		}
+	}
+
+	// Start a transaction
+	tx := r.db.WithContext(ctx).Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Save every column so cleared limits and windows are persisted as NULL.
+	// used_count is only ever changed by redemptions.
+	if err := tx.Omit("UsedCount", "CreatedAt", "Services", "Categories", "Branches").Save(promotion).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if replaceRestrictions {
+		if err := tx.Model(promotion).Association("Services").Replace(promotion.Services); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Model(promotion).Association("Categories").Replace(promotion.Categories); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Model(promotion).Association("Branches").Replace(promotion.Branches); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *promotionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	// Start a transaction
+	tx := r.db.WithContext(ctx).Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	promotion := &entity.Promotion{}
+	if err := tx.First(promotion, "id = ?", id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Clear the restriction join tables
+	for _, association := range []string{"Services", "Categories", "Branches"} {
+		if err := tx.Model(promotion).Association(association).Clear(); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Delete(promotion).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *promotionRepository) CountUserRedemptions(ctx context.Context, promotionID uuid.UUID, userID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.PromotionRedemption{}).
+		Where("promotion_id = ? AND user_id = ?", promotionID, userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *promotionRepository) BuildQuery(ctx context.Context, params *params.PromotionQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	stringFilters := map[string]string{
+		"code":          strings.ToUpper(params.Code),
+		"discount_type": params.DiscountType,
+	}
+	if params.IsActive != nil {
+		stringFilters["is_active"] = utils.ParseBoolToString(params.IsActive)
+	}
+	builder.ApplyStringFilters(stringFilters)
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("updated_at", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
+
+// checkRestrictionsExist makes sure every restricted service, category and
+// branch refers to an existing record.
+func (r *promotionRepository) checkRestrictionsExist(ctx context.Context, promotion *entity.Promotion) error {
+	serviceIDs := make([]uuid.UUID, len(promotion.Services))
+	for i, service := range promotion.Services {
+		serviceIDs[i] = service.ID
+	}
+	if err := r.checkIDsExist(ctx, &entity.Service{}, serviceIDs, utils.ErrServiceNotFound); err != nil {
+		return err
+	}
+
+	categoryIDs := make([]uuid.UUID, len(promotion.Categories))
+	for i, category := range promotion.Categories {
+		categoryIDs[i] = category.ID
+	}
+	if err := r.checkIDsExist(ctx, &entity.Category{}, categoryIDs, utils.ErrCategoryNotFound); err != nil {
+		return err
+	}
+
+	branchIDs := make([]uuid.UUID, len(promotion.Branches))
+	for i, branch := range promotion.Branches {
+		branchIDs[i] = branch.ID
+	}
+	return r.checkIDsExist(ctx, &entity.Branch{}, branchIDs, utils.ErrBranchNotFound)
+}
+
+func (r *promotionRepository) checkIDsExist(ctx context.Context, model interface{}, ids []uuid.UUID, notFound error) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(model).Where("id IN ?", ids).Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(ids) {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefundRepository interface {
+	CreateRule(ctx context.Context, rule *entity.RefundRule) error
+	GetRuleByID(ctx context.Context, id uuid.UUID) (*entity.RefundRule, error)
+	GetRules(ctx context.Context) ([]entity.RefundRule, error)
+	GetActiveRules(ctx context.Context) ([]entity.RefundRule, error)
+	UpdateRule(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Refund, error)
+	GetByBookingID(ctx context.Context, bookingID uuid.UUID) (*entity.Refund, error)
+	GetAll(ctx context.Context, params *params.RefundQueryParams) ([]entity.Refund, *transport.PaginationResponse, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	UpdateIfUnchanged(ctx context.Context, refund *entity.Refund, updates map[string]interface{}) error
+	Allocate(ctx context.Context, allocation *entity.RefundAllocation) error
+	BuildQuery(ctx context.Context, params *params.RefundQueryParams, preloads ...string) *gorm.DB
+}
+
+type refundRepository struct {
+	db *gorm.DB
+}
+
+func NewRefundRepository(db *gorm.DB) RefundRepository {
+	return &refundRepository{db: db}
+}
+
+func (r *refundRepository) CreateRule(ctx context.Context, rule *entity.RefundRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *refundRepository) GetRuleByID(ctx context.Context, id uuid.UUID) (*entity.RefundRule, error) {
+	var rule entity.RefundRule
+	if err := r.db.WithContext(ctx).First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *refundRepository) GetRules(ctx context.Context) ([]entity.RefundRule, error) {
+	var rules []entity.RefundRule
+	err := r.db.WithContext(ctx).Order("min_notice_hours desc").Find(&rules).Error
+	return rules, err
+}
+
+// GetActiveRules returns the active rules, longest notice first.
+func (r *refundRepository) GetActiveRules(ctx context.Context) ([]entity.RefundRule, error) {
+	var rules []entity.RefundRule
+	err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Order("min_notice_hours desc").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *refundRepository) UpdateRule(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.RefundRule{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *refundRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.RefundRule{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *refundRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Refund, error) {
+	var refund entity.Refund
+	err := r.db.WithContext(ctx).
+		Preload("Allocations", func(db *gorm.DB) *gorm.DB { return db.Order("created_at asc") }).
+		First(&refund, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func (r *refundRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) (*entity.Refund, error) {
+	var refund entity.Refund
+	err := r.db.WithContext(ctx).
+		Preload("Allocations", func(db *gorm.DB) *gorm.DB { return db.Order("created_at asc") }).
+		First(&refund, "booking_id = ?", bookingID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func (r *refundRepository) GetAll(ctx context.Context, params *params.RefundQueryParams) ([]entity.Refund, *transport.PaginationResponse, error) {
+	var refunds []entity.Refund
+
+	query := r.BuildQuery(ctx, params, "Allocations")
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.Refund{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&refunds).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return refunds, pagination, nil
+}
+
+func (r *refundRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.Refund{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateIfUnchanged applies the updates only while the refund still has the
+// status and refunded amount it was read with, so that two reviews of the
+// same refund can't both go ahead. It returns utils.ErrRefundProcessed when
+// the refund has changed since.
+func (r *refundRepository) UpdateIfUnchanged(ctx context.Context, refund *entity.Refund, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.Refund{}).
+		Where("id = ? AND status = ? AND refunded_amount = ?", refund.ID, refund.Status, refund.RefundedAmount).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrRefundProcessed
+	}
+	return nil
+}
+
+// Allocate records money paid back against one payment and adds it to the
+// refunded totals of the payment, the refund and the booking. The refund
+// must be PROCESSING and the allocation must fit in its approved amount.
+func (r *refundRepository) Allocate(ctx context.Context, allocation *entity.RefundAllocation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var payment entity.BookingPayment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&payment, "id = ?", allocation.PaymentID).Error; err != nil {
+			return err
+		}
+		if payment.RefundedAmount+allocation.Amount > payment.Amount {
+			return utils.ErrInvalidRefundAmount
+		}
+
+		var refund entity.Refund
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&refund, "id = ?", allocation.RefundID).Error; err != nil {
+			return err
+		}
+		if refund.Status != entity.RefundProcessing {
+			return utils.ErrRefundProcessed
+		}
+		if refund.RefundedAmount+allocation.Amount > refund.Amount {
+			return utils.ErrInvalidRefundAmount
+		}
+
+		if err := tx.Create(allocation).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&payment).
+			UpdateColumn("refunded_amount", gorm.Expr("refunded_amount + ?", allocation.Amount)).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&refund).
+			UpdateColumn("refunded_amount", gorm.Expr("refunded_amount + ?", allocation.Amount)).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.Booking{}).Where("id = ?", refund.BookingID).
+			UpdateColumn("amount_refunded", gorm.Expr("amount_refunded + ?", allocation.Amount)).Error
+	})
+}
+
+func (r *refundRepository) BuildQuery(ctx context.Context, params *params.RefundQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	builder.ApplyStringFilters(map[string]string{
+		"user_id": params.UserID,
+	})
+	builder.ApplyUUIDFilter("booking_id", params.BookingID)
+
+	// Apply status filter (supports comma-separated values)
+	builder.ApplyInFilter("status", params.Status)
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("created_at", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
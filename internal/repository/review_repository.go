@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *entity.Review) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Review, error)
+	GetAll(ctx context.Context, params *params.ReviewQueryParams) ([]entity.Review, *transport.PaginationResponse, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	BuildQuery(ctx context.Context, params *params.ReviewQueryParams, preloads ...string) *gorm.DB
+}
+
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+// Create stores the review of a booking, which can be reviewed once. The
+// staff member rated must work at the booking's branch.
+func (r *reviewRepository) Create(ctx context.Context, review *entity.Review) error {
+	if review.StaffID != nil {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&entity.User{}).
+			Joins("JOIN branch_members ON branch_members.user_id = users.id").
+			Where("users.id = ? AND users.role IN ? AND branch_members.branch_id = ?",
+				*review.StaffID, []string{entity.RoleStaff, entity.RoleBranchManager}, review.BranchID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return utils.ErrNotBranchStaff
+		}
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Review{}).
+		Where("booking_id = ?", review.BookingID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return utils.ErrReviewExists
+	}
+
+	err := r.db.WithContext(ctx).Omit("User").Create(review).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return utils.ErrReviewExists
+	}
+	return err
+}
+
+func (r *reviewRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Review, error) {
+	var review entity.Review
+	err := r.db.WithContext(ctx).Preload("User").First(&review, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepository) GetAll(ctx context.Context, params *params.ReviewQueryParams) ([]entity.Review, *transport.PaginationResponse, error) {
+	var reviews []entity.Review
+
+	query := r.BuildQuery(ctx, params, "User")
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.Review{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&reviews).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return reviews, pagination, nil
+}
+
+func (r *reviewRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.Review{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.Review{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *reviewRepository) BuildQuery(ctx context.Context, params *params.ReviewQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	builder.ApplyUUIDFilter("service_id", params.ServiceID).
+		ApplyUUIDFilter("branch_id", params.BranchID).
+		ApplyStringFilters(map[string]string{
+			"user_id":  params.UserID,
+			"staff_id": params.StaffID,
+		}).
+		ApplyInFilter("status", params.Status)
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("created_at", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
+
+// serviceRatingSQL orders services by their approved reviews, unrated last.
+const serviceRatingSQL = `(SELECT COALESCE(AVG(reviews.rating), 0) FROM reviews
+	WHERE reviews.service_id = services.id AND reviews.status = 'APPROVED')`
+
+// loadServiceRatings fills in the rating of each service from its approved
+// reviews. Services nobody has reviewed yet keep a nil rating.
+func loadServiceRatings(db *gorm.DB, services []entity.Service) error {
+	ids := make([]uuid.UUID, len(services))
+	for i := range services {
+		ids[i] = services[i].ID
+	}
+	ratings, err := ratingSummaries(db, "service_id", ids)
+	if err != nil {
+		return err
+	}
+	for i := range services {
+		if rating, ok := ratings[services[i].ID]; ok {
+			services[i].Rating = &rating
+		}
+	}
+	return nil
+}
+
+// loadBranchRatings fills in the rating of each branch from the approved
+// reviews of bookings made there.
+func loadBranchRatings(db *gorm.DB, branches []entity.Branch) error {
+	ids := make([]uuid.UUID, len(branches))
+	for i := range branches {
+		ids[i] = branches[i].ID
+	}
+	ratings, err := ratingSummaries(db, "branch_id", ids)
+	if err != nil {
+		return err
+	}
+	for i := range branches {
+		if rating, ok := ratings[branches[i].ID]; ok {
+			branches[i].Rating = &rating
+		}
+	}
+	return nil
+}
+
+func ratingSummaries(db *gorm.DB, column string, ids []uuid.UUID) (map[uuid.UUID]entity.RatingSummary, error) {
+	summaries := make(map[uuid.UUID]entity.RatingSummary, len(ids))
+	if len(ids) == 0 {
+		return summaries, nil
+	}
+
+	var rows []struct {
+		ID      uuid.UUID
+		Average float64
+		Count   int
+	}
+	err := db.Model(&entity.Review{}).
+		Select(column+" AS id, ROUND(AVG(rating), 2) AS average, COUNT(*) AS count").
+		Where(column+" IN ? AND status = ?", ids, entity.ReviewApproved).
+		Group(column).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		summaries[row.ID] = entity.RatingSummary{Average: row.Average, Count: row.Count}
+	}
+	return summaries, nil
+}
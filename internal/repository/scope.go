@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/pkg/scope"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// The scope is only loaded on authenticated routes, so it is applied where
+// those routes read or change rows: bookings through scopeBookings, and a
+// branch's details and services through scope.HasBranch checks in the branch
+// and branch service repositories. Branch and service listings are public and
+// never scoped.
+
+// scopeBookings limits the query to the bookings of the request's scope:
+// customers see their own, staff and branch managers those made at their
+// branches as well.
+func scopeBookings(ctx context.Context, db *gorm.DB) *gorm.DB {
+	s := scope.FromContext(ctx)
+	switch {
+	case s.Branches:
+		return db.Where("bookings.branch_id IN ? OR bookings.user_id = ?", s.BranchIDs, s.UserID)
+	case s.UserID != "":
+		return db.Where("bookings.user_id = ?", s.UserID)
+	default:
+		return db
+	}
+}
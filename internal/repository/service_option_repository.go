@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ServiceOptionRepository stores the variants and add-ons of services.
+type ServiceOptionRepository interface {
+	CreateVariant(ctx context.Context, variant *entity.ServiceVariant) error
+	GetVariant(ctx context.Context, serviceID, id uuid.UUID) (*entity.ServiceVariant, error)
+	GetVariants(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceVariant, error)
+	UpdateVariant(ctx context.Context, serviceID, id uuid.UUID, updates map[string]interface{}) error
+	DeleteVariant(ctx context.Context, serviceID, id uuid.UUID) error
+	CreateAddOn(ctx context.Context, addOn *entity.ServiceAddOn) error
+	GetAddOn(ctx context.Context, serviceID, id uuid.UUID) (*entity.ServiceAddOn, error)
+	GetAddOns(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceAddOn, error)
+	UpdateAddOn(ctx context.Context, serviceID, id uuid.UUID, updates map[string]interface{}) error
+	DeleteAddOn(ctx context.Context, serviceID, id uuid.UUID) error
+	ServiceExists(ctx context.Context, serviceID uuid.UUID) (bool, error)
+}
+
+type serviceOptionRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceOptionRepository(db *gorm.DB) ServiceOptionRepository {
+	return &serviceOptionRepository{db: db}
+}
+
+func (r *serviceOptionRepository) CreateVariant(ctx context.Context, variant *entity.ServiceVariant) error {
+	return r.db.WithContext(ctx).Create(variant).Error
+}
+
+func (r *serviceOptionRepository) GetVariant(ctx context.Context, serviceID, id uuid.UUID) (*entity.ServiceVariant, error) {
+	var variant entity.ServiceVariant
+	if err := r.db.WithContext(ctx).First(&variant, "id = ? AND service_id = ?", id, serviceID).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+func (r *serviceOptionRepository) GetVariants(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceVariant, error) {
+	var variants []entity.ServiceVariant
+	err := r.db.WithContext(ctx).
+		Where("service_id = ?", serviceID).
+		Order("sort_order asc, created_at asc").
+		Find(&variants).Error
+	return variants, err
+}
+
+func (r *serviceOptionRepository) UpdateVariant(ctx context.Context, serviceID, id uuid.UUID, updates map[string]interface{}) error {
+	return r.update(ctx, &entity.ServiceVariant{}, serviceID, id, updates)
+}
+
+func (r *serviceOptionRepository) DeleteVariant(ctx context.Context, serviceID, id uuid.UUID) error {
+	return r.delete(ctx, &entity.ServiceVariant{}, serviceID, id)
+}
+
+func (r *serviceOptionRepository) CreateAddOn(ctx context.Context, addOn *entity.ServiceAddOn) error {
+	return r.db.WithContext(ctx).Create(addOn).Error
+}
+
+func (r *serviceOptionRepository) GetAddOn(ctx context.Context, serviceID, id uuid.UUID) (*entity.ServiceAddOn, error) {
+	var addOn entity.ServiceAddOn
+	if err := r.db.WithContext(ctx).First(&addOn, "id = ? AND service_id = ?", id, serviceID).Error; err != nil {
+		return nil, err
+	}
+	return &addOn, nil
+}
+
+func (r *serviceOptionRepository) GetAddOns(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceAddOn, error) {
+	var addOns []entity.ServiceAddOn
+	err := r.db.WithContext(ctx).
+		Where("service_id = ?", serviceID).
+		Order("sort_order asc, created_at asc").
+		Find(&addOns).Error
+	return addOns, err
+}
+
+func (r *serviceOptionRepository) UpdateAddOn(ctx context.Context, serviceID, id uuid.UUID, updates map[string]interface{}) error {
+	return r.update(ctx, &entity.ServiceAddOn{}, serviceID, id, updates)
+}
+
+func (r *serviceOptionRepository) DeleteAddOn(ctx context.Context, serviceID, id uuid.UUID) error {
+	return r.delete(ctx, &entity.ServiceAddOn{}, serviceID, id)
+}
+
+func (r *serviceOptionRepository) ServiceExists(ctx context.Context, serviceID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Service{}).Where("id = ?", serviceID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *serviceOptionRepository) update(ctx context.Context, model interface{}, serviceID, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(model).Where("id = ? AND service_id = ?", id, serviceID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *serviceOptionRepository) delete(ctx context.Context, model interface{}, serviceID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND service_id = ?", id, serviceID).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -13,13 +13,21 @@ import (
 )
 
 type ServiceRepository interface {
-	Create(ctx context.Context, service *entity.Service) error
+	Create(ctx context.Context, service *entity.Service, branchPrices []entity.BranchService) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Service, error)
 	GetAll(ctx context.Context, filter *params.ServiceQueryParams) ([]entity.Service, *transport.PaginationResponse, error)
-	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}, branches []entity.Branch) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	GetBranchServices(ctx context.Context, branchID uuid.UUID, serviceIDs []uuid.UUID) (map[uuid.UUID]entity.BranchService, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}, branches []entity.Branch, branchPrices []entity.BranchService) error
+	Delete(ctx context.Context, id uuid.UUID, refundFor RefundFor) ([]entity.Booking, error)
+	GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Service, error)
+	GetDeleted(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Service, *transport.PaginationResponse, error)
+	Restore(ctx context.Context, id uuid.UUID) error
 	CheckBranchCategoryExist(ctx context.Context, service *entity.Service) error
+	Search(ctx context.Context, filter *params.ServiceSearchQueryParams) ([]entity.Service, *transport.PaginationResponse, error)
+	Suggest(ctx context.Context, filter *params.ServiceSearchQueryParams) ([]entity.ServiceSuggestion, error)
 	BuildQuery(ctx context.Context, filter *params.ServiceQueryParams, preload ...string) *gorm.DB
+	GetCurrentVersion(ctx context.Context, serviceID uuid.UUID) (*entity.ServiceVersion, error)
+	GetVersions(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceVersion, error)
 }
 
 type serviceRepository struct {
@@ -30,7 +38,7 @@ func NewServiceRepository(db *gorm.DB) ServiceRepository {
 	return &serviceRepository{db: db}
 }
 
-func (r *serviceRepository) Create(ctx context.Context, service *entity.Service) error {
+func (r *serviceRepository) Create(ctx context.Context, service *entity.Service, branchPrices []entity.BranchService) error {
 	err := r.CheckBranchCategoryExist(ctx, service)
 	if err != nil {
 		return err
@@ -59,6 +67,17 @@ func (r *serviceRepository) Create(ctx context.Context, service *entity.Service)
 		return err
 	}
 
+	// Apply per-branch price overrides
+	if err := applyBranchPrices(tx, service.ID, branchPrices); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := recordServiceVersion(tx, service.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	return tx.Commit().Error
 }
 
@@ -66,11 +85,19 @@ func (r *serviceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 	var service entity.Service
 	err := r.db.WithContext(ctx).
 		Preload("Branches").
+		Preload("ImageFile.Thumbnails").
+		Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order asc, created_at asc") }).
+		Preload("AddOns", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order asc, created_at asc") }).
 		First(&service, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
-	return &service, nil
+
+	services := []entity.Service{service}
+	if err := loadServiceRatings(r.db.WithContext(ctx), services); err != nil {
+		return nil, err
+	}
+	return &services[0], nil
 }
 
 func (r *serviceRepository) GetAll(ctx context.Context, params *params.ServiceQueryParams) ([]entity.Service, *transport.PaginationResponse, error) {
@@ -83,7 +110,7 @@ func (r *serviceRepository) GetAll(ctx context.Context, params *params.ServiceQu
 	if params.BranchID != "" {
 		if branchID, err := uuid.Parse(params.BranchID); err == nil && branchID != uuid.Nil {
 			query = query.Joins("JOIN branch_service ON services.id = branch_service.service_id").
-				Where("branch_service.branch_id = ?", branchID)
+				Where("branch_service.branch_id = ? AND branch_service.is_active = ?", branchID, true)
 		}
 	}
 
@@ -95,10 +122,33 @@ func (r *serviceRepository) GetAll(ctx context.Context, params *params.ServiceQu
 	if err := query.Find(&services).Error; err != nil {
 		return nil, nil, err
 	}
+	if err := loadServiceRatings(r.db.WithContext(ctx), services); err != nil {
+		return nil, nil, err
+	}
 
 	return services, pagination, nil
 }
 
+func (r *serviceRepository) GetBranchServices(ctx context.Context, branchID uuid.UUID, serviceIDs []uuid.UUID) (map[uuid.UUID]entity.BranchService, error) {
+	result := make(map[uuid.UUID]entity.BranchService, len(serviceIDs))
+	if len(serviceIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []entity.BranchService
+	err := r.db.WithContext(ctx).
+		Where("branch_id = ? AND service_id IN ?", branchID, serviceIDs).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.ServiceID] = row
+	}
+	return result, nil
+}
+
 func (r *serviceRepository) BuildQuery(ctx context.Context, filter *params.ServiceQueryParams, preload ...string) *gorm.DB {
 	query := utils.NewQueryBuilder(r.db, ctx)
 
@@ -109,18 +159,25 @@ func (r *serviceRepository) BuildQuery(ctx context.Context, filter *params.Servi
 		stringFilters["duration_minute"] = strconv.Itoa(filter.DurationMinute)
 	}
 	if filter.Price != 0 {
-		stringFilters["price"] = strconv.Itoa(filter.Price)
+		stringFilters["price"] = strconv.FormatInt(filter.Price, 10)
 	}
 	query.ApplyStringFilters(stringFilters)
 
 	query.ApplyPagination(filter.Limit, filter.Offset)
 
-	query.ApplyUUIDFilter("category_id", filter.CategoryID)
-
-	if filter.SortBy != "" {
-		query.ApplySorting(filter.SortBy, filter.SortOrder)
+	if categoryID, err := uuid.Parse(filter.CategoryID); err == nil && filter.IncludeDescendants {
+		query.ApplyCondition("category_id IN (?)", gorm.Expr(categoryDescendantsSQL, categoryID))
 	} else {
+		query.ApplyUUIDFilter("category_id", filter.CategoryID)
+	}
+
+	switch filter.SortBy {
+	case "":
 		query.ApplySorting("updated_at", "desc")
+	case "rating":
+		query.ApplySorting(serviceRatingSQL, filter.SortOrder)
+	default:
+		query.ApplySorting(filter.SortBy, filter.SortOrder)
 	}
 
 	query.ApplyPreloads(preload...)
@@ -128,7 +185,7 @@ func (r *serviceRepository) BuildQuery(ctx context.Context, filter *params.Servi
 	return query.Build()
 }
 
-func (r *serviceRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}, branches []entity.Branch) error {
+func (r *serviceRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}, branches []entity.Branch, branchPrices []entity.BranchService) error {
 	// Start a transaction
 	tx := r.db.WithContext(ctx).Begin()
 	if err := tx.Error; err != nil {
@@ -190,44 +247,150 @@ func (r *serviceRepository) Update(ctx context.Context, id uuid.UUID, updates ma
 		}
 	}
 
+	// Apply per-branch price overrides
+	if err := applyBranchPrices(tx, id, branchPrices); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := recordServiceVersion(tx, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	return tx.Commit().Error
 }
 
-func (r *serviceRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	// Start a transaction
-	tx := r.db.WithContext(ctx).Begin()
-	if err := tx.Error; err != nil {
+// applyBranchPrices sets the price override on existing branch_service rows.
+// Overrides can only be set for branches the service is offered at.
+func applyBranchPrices(tx *gorm.DB, serviceID uuid.UUID, branchPrices []entity.BranchService) error {
+	for _, bp := range branchPrices {
+		result := tx.Model(&entity.BranchService{}).
+			Where("service_id = ? AND branch_id = ?", serviceID, bp.BranchID).
+			Update("price_override", bp.PriceOverride)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return utils.ErrBranchNotFound
+		}
+	}
+	return nil
+}
+
+// recordServiceVersion adds a version for the service if its catalog entry
+// no longer matches the latest one.
+func recordServiceVersion(tx *gorm.DB, serviceID uuid.UUID) error {
+	var service entity.Service
+	if err := tx.Unscoped().First(&service, "id = ?", serviceID).Error; err != nil {
 		return err
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
 
-	// Get the service first
-	service := &entity.Service{}
-	if err := tx.First(service, "id = ?", id).Error; err != nil {
-		tx.Rollback()
-		if err == gorm.ErrRecordNotFound {
-			return err
+	var latest entity.ServiceVersion
+	err := tx.Where("service_id = ?", serviceID).Order("version DESC").First(&latest).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+	if err == nil && latest.Matches(&service) {
+		return nil
+	}
+
+	return tx.Create(&entity.ServiceVersion{
+		ID:             uuid.New(),
+		ServiceID:      service.ID,
+		Version:        latest.Version + 1,
+		Name:           service.Name,
+		DurationMinute: service.DurationMinute,
+		Price:          service.Price,
+		Currency:       service.Currency,
+		CategoryID:     service.CategoryID,
+	}).Error
+}
+
+// GetCurrentVersion returns the latest version of the service's catalog entry.
+func (r *serviceRepository) GetCurrentVersion(ctx context.Context, serviceID uuid.UUID) (*entity.ServiceVersion, error) {
+	var version entity.ServiceVersion
+	err := r.db.WithContext(ctx).
+		Where("service_id = ?", serviceID).
+		Order("version DESC").
+		First(&version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// GetVersions returns the history of the service's catalog entry, newest first.
+func (r *serviceRepository) GetVersions(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceVersion, error) {
+	versions := []entity.ServiceVersion{}
+	err := r.db.WithContext(ctx).
+		Where("service_id = ?", serviceID).
+		Order("version DESC").
+		Find(&versions).Error
+	return versions, err
+}
+
+// Delete moves the service to the trash. Its branches, prices and image are
+// kept so bookings made for it still resolve and a restore brings it back
+// whole. In the same transaction it cancels the service's upcoming bookings,
+// see cancelOpenBookings, and returns them.
+func (r *serviceRepository) Delete(ctx context.Context, id uuid.UUID, refundFor RefundFor) ([]entity.Booking, error) {
+	var cancelled []entity.Booking
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entity.Service{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
 		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		bookings, err := cancelOpenBookings(tx, refundFor, "service_id = ?", id)
+		cancelled = bookings
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return cancelled, nil
+}
 
-	// Clear the associations in branch_service table
-	if err := tx.Model(service).Association("Branches").Clear(); err != nil {
-		tx.Rollback()
+// GetByIDWithDeleted finds the service even if it is in the trash, for
+// bookings and invoices made before it was deleted.
+func (r *serviceRepository) GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Service, error) {
+	var service entity.Service
+	if err := r.db.WithContext(ctx).Unscoped().First(&service, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &service, nil
+}
+
+func (r *serviceRepository) GetDeleted(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Service, *transport.PaginationResponse, error) {
+	services := []entity.Service{}
+	pagination, err := listDeleted(ctx, r.db, &entity.Service{}, &services, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return services, pagination, nil
+}
+
+// Restore takes the service out of the trash. Its category has to be
+// restored first.
+func (r *serviceRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	var service entity.Service
+	if err := r.db.WithContext(ctx).Unscoped().First(&service, "id = ? AND deleted_at IS NOT NULL", id).Error; err != nil {
 		return err
 	}
 
-	// Delete the service
-	if err := tx.Delete(service).Error; err != nil {
-		tx.Rollback()
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Category{}).Where("id = ?", service.CategoryID).Count(&count).Error; err != nil {
 		return err
 	}
+	if count == 0 {
+		return utils.ErrCategoryInTrash
+	}
 
-	return tx.Commit().Error
+	return restoreDeleted(ctx, r.db, &entity.Service{}, id)
 }
 
 func (r *serviceRepository) CheckBranchCategoryExist(ctx context.Context, service *entity.Service) error {
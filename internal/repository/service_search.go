@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// serviceDocumentSQL is the text searched for a service. It must match the
+// expression of the idx_services_search index.
+const serviceDocumentSQL = `to_tsvector('simple', coalesce(services.name, '') || ' ' || coalesce(services.description, ''))`
+
+// serviceMatchSQL matches a service by full-text search over its name,
+// description and category name, or by trigram similarity of the names to
+// catch typos. Translated service names match too, so customers can search
+// in any locale. It takes the prefix tsquery and the raw search text.
+const serviceMatchSQL = `(` + serviceDocumentSQL + ` @@ to_tsquery('simple', @tsquery)
+	OR to_tsvector('simple', categories.name) @@ to_tsquery('simple', @tsquery)
+	OR @q <% services.name
+	OR categories.name % @q
+	OR EXISTS (SELECT 1 FROM service_translations st WHERE st.service_id = services.id
+		AND (to_tsvector('simple', st.name || ' ' || coalesce(st.description, '')) @@ to_tsquery('simple', @tsquery)
+			OR @q <% st.name)))`
+
+// serviceRankSQL orders matches: full-text relevance first, then how closely
+// the service, its translated names and category name resemble the search text.
+const serviceRankSQL = `ts_rank(` + serviceDocumentSQL + `, to_tsquery('simple', @tsquery)) * 2
+	+ greatest(word_similarity(@q, services.name),
+		coalesce((SELECT max(word_similarity(@q, st.name)) FROM service_translations st WHERE st.service_id = services.id), 0))
+	+ similarity(categories.name, @q) * 0.5`
+
+// Search returns the active services matching the search text, most relevant
+// first, with SearchRank set.
+func (r *serviceRepository) Search(ctx context.Context, filter *params.ServiceSearchQueryParams) ([]entity.Service, *transport.PaginationResponse, error) {
+	tsquery := prefixTSQuery(filter.Q)
+	if tsquery == "" {
+		return []entity.Service{}, utils.CalculatePagination(0, filter.Limit, filter.Offset), nil
+	}
+	args := map[string]interface{}{"tsquery": tsquery, "q": strings.TrimSpace(filter.Q)}
+
+	var total int64
+	if err := r.searchQuery(ctx, filter, args).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var rows []struct {
+		ID   uuid.UUID
+		Rank float64
+	}
+	err := r.searchQuery(ctx, filter, args).
+		Select("services.id, ("+serviceRankSQL+") AS rank", args).
+		Order("rank DESC, services.name ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var found []entity.Service
+	if len(ids) > 0 {
+		if err := r.db.WithContext(ctx).Preload("Category").Where("id IN ?", ids).Find(&found).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Put the services back in rank order
+	byID := make(map[uuid.UUID]entity.Service, len(found))
+	for _, service := range found {
+		byID[service.ID] = service
+	}
+	services := make([]entity.Service, 0, len(rows))
+	for _, row := range rows {
+		if service, ok := byID[row.ID]; ok {
+			rank := row.Rank
+			service.SearchRank = &rank
+			services = append(services, service)
+		}
+	}
+	if err := loadServiceRatings(r.db.WithContext(ctx), services); err != nil {
+		return nil, nil, err
+	}
+
+	return services, utils.CalculatePagination(total, filter.Limit, filter.Offset), nil
+}
+
+// Suggest returns up to limit short entries for completing the search text
+// as it is typed.
+func (r *serviceRepository) Suggest(ctx context.Context, filter *params.ServiceSearchQueryParams) ([]entity.ServiceSuggestion, error) {
+	suggestions := []entity.ServiceSuggestion{}
+	tsquery := prefixTSQuery(filter.Q)
+	if tsquery == "" {
+		return suggestions, nil
+	}
+	args := map[string]interface{}{"tsquery": tsquery, "q": strings.TrimSpace(filter.Q)}
+
+	err := r.searchQuery(ctx, filter, args).
+		Select("services.id, services.name, services.category_id, categories.name AS category_name, ("+serviceRankSQL+") AS rank", args).
+		Order("rank DESC, services.name ASC").
+		Limit(filter.Limit).
+		Scan(&suggestions).Error
+	return suggestions, err
+}
+
+func (r *serviceRepository) searchQuery(ctx context.Context, filter *params.ServiceSearchQueryParams, args map[string]interface{}) *gorm.DB {
+	query := utils.NewQueryBuilder(r.db, ctx).
+		ApplyCondition("services.is_active = ?", true).
+		ApplyCondition("categories.is_hidden = ?", false).
+		ApplyCondition("categories.deleted_at IS NULL").
+		ApplyCondition(serviceMatchSQL, args).
+		ApplyUUIDFilter("services.category_id", filter.CategoryID)
+
+	if branchID, err := uuid.Parse(filter.BranchID); err == nil {
+		query.ApplyCondition("EXISTS (SELECT 1 FROM branch_service WHERE branch_service.service_id = services.id AND branch_service.branch_id = ? AND branch_service.is_active)", branchID)
+	}
+
+	return query.Build().
+		Model(&entity.Service{}).
+		Joins("JOIN categories ON categories.id = services.category_id")
+}
+
+// prefixTSQuery turns free text into a tsquery matching every word as a
+// prefix, e.g. "hydra fac" becomes "hydra:* & fac:*". Punctuation is dropped
+// so user input cannot inject tsquery operators.
+func prefixTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
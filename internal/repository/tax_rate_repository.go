@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TaxRateRepository interface {
+	Create(ctx context.Context, taxRate *entity.TaxRate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.TaxRate, error)
+	GetAll(ctx context.Context, params *params.TaxRateQueryParams) ([]entity.TaxRate, *transport.PaginationResponse, error)
+	GetActive(ctx context.Context) ([]entity.TaxRate, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	BuildQuery(ctx context.Context, params *params.TaxRateQueryParams, preloads ...string) *gorm.DB
+}
+
+type taxRateRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRateRepository(db *gorm.DB) TaxRateRepository {
+	return &taxRateRepository{db: db}
+}
+
+func (r *taxRateRepository) Create(ctx context.Context, taxRate *entity.TaxRate) error {
+	if taxRate.BranchID != nil {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&entity.Branch{}).
+			Where("id = ?", taxRate.BranchID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return utils.ErrBranchNotFound
+		}
+	}
+	if taxRate.CategoryID != nil {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&entity.Category{}).
+			Where("id = ?", taxRate.CategoryID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return utils.ErrCategoryNotFound
+		}
+	}
+
+	if taxRate.IsActive {
+		if err := r.checkScopeFree(ctx, taxRate); err != nil {
+			return err
+		}
+	}
+
+	return r.db.WithContext(ctx).Omit("Branch", "Category").Create(taxRate).Error
+}
+
+// checkScopeFree makes sure no other active rate covers the same branch and
+// category, which would make the applicable rate ambiguous.
+func (r *taxRateRepository) checkScopeFree(ctx context.Context, taxRate *entity.TaxRate) error {
+	query := r.db.WithContext(ctx).Model(&entity.TaxRate{}).
+		Where("is_active = ? AND id <> ?", true, taxRate.ID)
+	if taxRate.BranchID != nil {
+		query = query.Where("branch_id = ?", taxRate.BranchID)
+	} else {
+		query = query.Where("branch_id IS NULL")
+	}
+	if taxRate.CategoryID != nil {
+		query = query.Where("category_id = ?", taxRate.CategoryID)
+	} else {
+		query = query.Where("category_id IS NULL")
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return utils.ErrTaxRateConflict
+	}
+	return nil
+}
+
+func (r *taxRateRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.TaxRate, error) {
+	var taxRate entity.TaxRate
+	err := r.db.WithContext(ctx).Preload("Branch").Preload("Category").
+		First(&taxRate, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &taxRate, nil
+}
+
+func (r *taxRateRepository) GetAll(ctx context.Context, params *params.TaxRateQueryParams) ([]entity.TaxRate, *transport.PaginationResponse, error) {
+	var taxRates []entity.TaxRate
+
+	query := r.BuildQuery(ctx, params, "Branch", "Category")
+
+	pagination, err := utils.CountAndPaginate(ctx, query, &entity.TaxRate{}, params.Limit, params.Offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Find(&taxRates).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return taxRates, pagination, nil
+}
+
+func (r *taxRateRepository) GetActive(ctx context.Context) ([]entity.TaxRate, error) {
+	var taxRates []entity.TaxRate
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&taxRates).Error
+	return taxRates, err
+}
+
+func (r *taxRateRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	if isActive, ok := updates["is_active"].(bool); ok && isActive {
+		existing, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := r.checkScopeFree(ctx, existing); err != nil {
+			return err
+		}
+	}
+
+	result := r.db.WithContext(ctx).Model(&entity.TaxRate{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *taxRateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.TaxRate{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *taxRateRepository) BuildQuery(ctx context.Context, params *params.TaxRateQueryParams, preloads ...string) *gorm.DB {
+	builder := utils.NewQueryBuilder(r.db, ctx)
+
+	builder.ApplyUUIDFilter("branch_id", params.BranchID).
+		ApplyUUIDFilter("category_id", params.CategoryID)
+
+	if params.IsActive != nil {
+		builder.ApplyStringFilters(map[string]string{
+			"is_active": utils.ParseBoolToString(params.IsActive),
+		})
+	}
+
+	// Apply sorting
+	if params.SortBy != "" {
+		builder.ApplySorting(params.SortBy, params.SortOrder)
+	} else {
+		builder.ApplySorting("updated_at", "desc")
+	}
+
+	// Apply pagination and preloads
+	builder.ApplyPagination(params.Limit, params.Offset).
+		ApplyPreloads(preloads...)
+
+	return builder.Build()
+}
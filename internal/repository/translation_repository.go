@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TranslationRepository stores the translated names, descriptions and
+// locations of services, categories and branches, one row per locale.
+type TranslationRepository interface {
+	SaveServiceTranslation(ctx context.Context, translation *entity.ServiceTranslation) error
+	GetServiceTranslations(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceTranslation, error)
+	FindServiceTranslations(ctx context.Context, locale string, serviceIDs []uuid.UUID) ([]entity.ServiceTranslation, error)
+	DeleteServiceTranslation(ctx context.Context, serviceID uuid.UUID, locale string) error
+	SaveCategoryTranslation(ctx context.Context, translation *entity.CategoryTranslation) error
+	GetCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]entity.CategoryTranslation, error)
+	FindCategoryTranslations(ctx context.Context, locale string, categoryIDs []uuid.UUID) ([]entity.CategoryTranslation, error)
+	DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error
+	SaveBranchTranslation(ctx context.Context, translation *entity.BranchTranslation) error
+	GetBranchTranslations(ctx context.Context, branchID uuid.UUID) ([]entity.BranchTranslation, error)
+	FindBranchTranslations(ctx context.Context, locale string, branchIDs []uuid.UUID) ([]entity.BranchTranslation, error)
+	DeleteBranchTranslation(ctx context.Context, branchID uuid.UUID, locale string) error
+	Exists(ctx context.Context, model interface{}, id uuid.UUID) (bool, error)
+}
+
+type translationRepository struct {
+	db *gorm.DB
+}
+
+func NewTranslationRepository(db *gorm.DB) TranslationRepository {
+	return &translationRepository{db: db}
+}
+
+func (r *translationRepository) SaveServiceTranslation(ctx context.Context, translation *entity.ServiceTranslation) error {
+	return r.save(ctx, translation, "service_id", "name", "description")
+}
+
+func (r *translationRepository) GetServiceTranslations(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceTranslation, error) {
+	var translations []entity.ServiceTranslation
+	err := r.db.WithContext(ctx).Where("service_id = ?", serviceID).Order("locale asc").Find(&translations).Error
+	return translations, err
+}
+
+func (r *translationRepository) FindServiceTranslations(ctx context.Context, locale string, serviceIDs []uuid.UUID) ([]entity.ServiceTranslation, error) {
+	var translations []entity.ServiceTranslation
+	err := r.find(ctx, &translations, "service_id", locale, serviceIDs)
+	return translations, err
+}
+
+func (r *translationRepository) DeleteServiceTranslation(ctx context.Context, serviceID uuid.UUID, locale string) error {
+	return r.delete(ctx, &entity.ServiceTranslation{}, "service_id", serviceID, locale)
+}
+
+func (r *translationRepository) SaveCategoryTranslation(ctx context.Context, translation *entity.CategoryTranslation) error {
+	return r.save(ctx, translation, "category_id", "name")
+}
+
+func (r *translationRepository) GetCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]entity.CategoryTranslation, error) {
+	var translations []entity.CategoryTranslation
+	err := r.db.WithContext(ctx).Where("category_id = ?", categoryID).Order("locale asc").Find(&translations).Error
+	return translations, err
+}
+
+func (r *translationRepository) FindCategoryTranslations(ctx context.Context, locale string, categoryIDs []uuid.UUID) ([]entity.CategoryTranslation, error) {
+	var translations []entity.CategoryTranslation
+	err := r.find(ctx, &translations, "category_id", locale, categoryIDs)
+	return translations, err
+}
+
+func (r *translationRepository) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, locale string) error {
+	return r.delete(ctx, &entity.CategoryTranslation{}, "category_id", categoryID, locale)
+}
+
+func (r *translationRepository) SaveBranchTranslation(ctx context.Context, translation *entity.BranchTranslation) error {
+	return r.save(ctx, translation, "branch_id", "name", "location")
+}
+
+func (r *translationRepository) GetBranchTranslations(ctx context.Context, branchID uuid.UUID) ([]entity.BranchTranslation, error) {
+	var translations []entity.BranchTranslation
+	err := r.db.WithContext(ctx).Where("branch_id = ?", branchID).Order("locale asc").Find(&translations).Error
+	return translations, err
+}
+
+func (r *translationRepository) FindBranchTranslations(ctx context.Context, locale string, branchIDs []uuid.UUID) ([]entity.BranchTranslation, error) {
+	var translations []entity.BranchTranslation
+	err := r.find(ctx, &translations, "branch_id", locale, branchIDs)
+	return translations, err
+}
+
+func (r *translationRepository) DeleteBranchTranslation(ctx context.Context, branchID uuid.UUID, locale string) error {
+	return r.delete(ctx, &entity.BranchTranslation{}, "branch_id", branchID, locale)
+}
+
+func (r *translationRepository) Exists(ctx context.Context, model interface{}, id uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// save inserts the translation, or overwrites the given columns of the one
+// already kept for the same record and locale. The stored row is read back
+// into translation so an overwrite keeps its original ID and CreatedAt.
+func (r *translationRepository) save(ctx context.Context, translation interface{}, parentColumn string, columns ...string) error {
+	return r.db.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: parentColumn}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns(append(columns, "updated_at")),
+		},
+		clause.Returning{},
+	).Create(translation).Error
+}
+
+func (r *translationRepository) find(ctx context.Context, dest interface{}, parentColumn, locale string, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("locale = ? AND "+parentColumn+" IN ?", locale, ids).Find(dest).Error
+}
+
+func (r *translationRepository) delete(ctx context.Context, model interface{}, parentColumn string, parentID uuid.UUID, locale string) error {
+	result := r.db.WithContext(ctx).Where(parentColumn+" = ? AND locale = ?", parentID, locale).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
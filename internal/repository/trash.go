@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/utils"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// withDeleted lets a preload resolve soft-deleted rows, so bookings keep
+// showing the service and branch they were made for.
+func withDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// listDeleted finds the soft-deleted rows of model into dest, most recently
+// deleted first.
+func listDeleted(ctx context.Context, db *gorm.DB, model, dest interface{}, filter *params.TrashQueryParams) (*transport.PaginationResponse, error) {
+	deleted := func() *gorm.DB {
+		query := db.WithContext(ctx).Unscoped().Model(model).Where("deleted_at IS NOT NULL")
+		if filter.Name != "" {
+			query = query.Where("name ILIKE ?", "%"+filter.Name+"%")
+		}
+		return query
+	}
+
+	var total int64
+	if err := deleted().Count(&total).Error; err != nil {
+		return nil, err
+	}
+	err := deleted().
+		Order("deleted_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(dest).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.CalculatePagination(total, filter.Limit, filter.Offset), nil
+}
+
+// restoreDeleted takes a soft-deleted row of model out of the trash.
+func restoreDeleted(ctx context.Context, db *gorm.DB, model interface{}, id uuid.UUID) error {
+	result := db.WithContext(ctx).Unscoped().Model(model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage writes objects under Dir. The server publishes Dir at BaseURL.
+type LocalStorage struct {
+	Dir     string
+	BaseURL string
+}
+
+func NewLocalStorage(dir, baseURL string) *LocalStorage {
+	return &LocalStorage{Dir: dir, BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see half an image
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// Remove directories the object leaves empty, up to Dir
+	for dir := filepath.Dir(name); dir != filepath.Clean(s.Dir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.BaseURL + "/" + key
+}
+
+// path maps the key into Dir, refusing keys that would escape it.
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", errors.New("invalid storage key: " + key)
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(clean)), nil
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Storage keeps objects in a bucket of S3 or an S3-compatible server such
+// as MinIO, signing requests with AWS Signature Version 4.
+type S3Storage struct {
+	Endpoint  string // e.g. https://s3.us-east-1.amazonaws.com or http://localhost:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket as endpoint/bucket instead of
+	// bucket.endpoint, which local stand-ins need
+	PathStyle bool
+	// PublicURL is where objects are served from; the bucket URL by default
+	PublicURL string
+	Client    *http.Client
+}
+
+func newS3StorageFromEnv() (*S3Storage, error) {
+	region := envOr("S3_REGION", "us-east-1")
+	s := &S3Storage{
+		Endpoint:  strings.TrimRight(envOr("S3_ENDPOINT", "https://s3."+region+".amazonaws.com"), "/"),
+		Region:    region,
+		Bucket:    os.Getenv("S3_BUCKET"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY_ID"),
+		SecretKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		PathStyle: os.Getenv("S3_FORCE_PATH_STYLE") == "true",
+		Client:    &http.Client{Timeout: 30 * time.Second},
+	}
+	for name, value := range map[string]string{
+		"S3_BUCKET":            s.Bucket,
+		"S3_ACCESS_KEY_ID":     s.AccessKey,
+		"S3_SECRET_ACCESS_KEY": s.SecretKey,
+	} {
+		if value == "" {
+			return nil, fmt.Errorf("%w: %s", ErrMissingSetting, name)
+		}
+	}
+	s.PublicURL = strings.TrimRight(envOr("S3_PUBLIC_URL", s.bucketURL()), "/")
+	return s, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	return s.do(ctx, http.MethodPut, key, body, contentType)
+}
+
+// Delete removes the object. Deleting a missing object is not an error.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.do(ctx, http.MethodDelete, key, nil, "")
+}
+
+func (s *S3Storage) URL(key string) string {
+	return s.PublicURL + "/" + encodeKey(key)
+}
+
+func (s *S3Storage) bucketURL() string {
+	if s.PathStyle {
+		return s.Endpoint + "/" + s.Bucket
+	}
+	u, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return s.Endpoint + "/" + s.Bucket
+	}
+	u.Host = s.Bucket + "." + u.Host
+	return u.String()
+}
+
+func (s *S3Storage) do(ctx context.Context, method, key string, body []byte, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, method, s.bucketURL()+"/"+encodeKey(key), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body, time.Now().UTC())
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("s3 %s %s: %s: %s", method, key, res.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// sign adds the Signature Version 4 headers to req.
+func (s *S3Storage) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), date)
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKey, scope, signedHeaders, signature))
+}
+
+// encodeKey escapes each segment of the key the way S3 expects in a path.
+func encodeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+	}
+	return strings.Join(segments, "/")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,49 @@
+// Package storage keeps uploaded files such as service and branch images,
+// either on the local filesystem or in an S3-compatible bucket.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrUnknownDriver  = errors.New("unknown storage driver")
+	ErrMissingSetting = errors.New("storage setting missing")
+)
+
+// Storage puts and removes objects by key. Keys are slash separated paths
+// such as images/<id>/original.jpg.
+type Storage interface {
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL is where clients can fetch the object
+	URL(key string) string
+}
+
+// NewStorageFromEnv builds the storage named by STORAGE_DRIVER, "local" by
+// default or "s3".
+func NewStorageFromEnv() (Storage, error) {
+	driver := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_DRIVER")))
+	switch driver {
+	case "", "local":
+		return NewLocalStorage(
+			envOr("STORAGE_LOCAL_DIR", "uploads"),
+			envOr("STORAGE_PUBLIC_URL", "/uploads"),
+		), nil
+	case "s3":
+		return newS3StorageFromEnv()
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, driver)
+	}
+}
+
+func envOr(name, fallback string) string {
+	if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+		return value
+	}
+	return fallback
+}
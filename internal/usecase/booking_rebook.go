@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxRebookDays is the furthest ahead book again looks for slots
+	maxRebookDays = 60
+	// maxRebookSlots is the most slots book again proposes
+	maxRebookSlots = 20
+)
+
+// RebookProposal is what it takes to book a past booking again: the same
+// service, branch and options, and the next slots open for them. Posting it
+// to /booking with one of the slots makes the booking.
+type RebookProposal struct {
+	BookingID uuid.UUID    `json:"booking_id"`
+	ServiceID uuid.UUID    `json:"service_id"`
+	BranchID  uuid.UUID    `json:"branch_id"`
+	VariantID *uuid.UUID   `json:"variant_id,omitempty"`
+	AddOnIDs  []uuid.UUID  `json:"add_on_ids"`
+	Slots     []RebookSlot `json:"slots"`
+}
+
+// RebookSlot is an open slot with the price quoted for it, add-ons excluded,
+// to send back as quoted_price.
+type RebookSlot struct {
+	BookedDate string `json:"booked_date"`
+	BookedTime string `json:"booked_time"`
+	Price      *int64 `json:"price,omitempty"`
+	Currency   string `json:"currency,omitempty"`
+}
+
+// BookAgain proposes the next open slots for the service, variant and add-ons
+// of one of the user's bookings, at the same branch. Add-ons that are no
+// longer offered are dropped; the service must still be offered at the branch.
+func (u *bookingUsecase) BookAgain(ctx context.Context, id uuid.UUID, userID string, filter *params.BookAgainQueryParams) (*RebookProposal, error) {
+	booking, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	// The offering and pricing rules are loaded once for all the days
+	offering, err := u.loadSlotOffering(ctx, booking.ServiceID, booking.BranchID, booking.VariantID)
+	if err != nil {
+		return nil, err
+	}
+	variant, _, err := selectOptions(offering.service, booking.VariantID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal := &RebookProposal{
+		BookingID: booking.ID,
+		ServiceID: booking.ServiceID,
+		BranchID:  booking.BranchID,
+		AddOnIDs:  []uuid.UUID{},
+		Slots:     []RebookSlot{},
+	}
+	if variant != nil {
+		proposal.VariantID = &variant.ID
+	}
+	for _, bookedAddOn := range booking.AddOns {
+		for _, addOn := range offering.service.AddOns {
+			if addOn.ID == bookedAddOn.AddOnID && addOn.IsActive {
+				proposal.AddOnIDs = append(proposal.AddOnIDs, addOn.ID)
+				break
+			}
+		}
+	}
+
+	days := min(max(filter.Days, 1), maxRebookDays)
+	limit := min(max(filter.Limit, 1), maxRebookSlots)
+
+	now := time.Now()
+	for day := 0; day < days && len(proposal.Slots) < limit; day++ {
+		bookedDate := now.AddDate(0, 0, day).Format("02/01/2006")
+		slots, err := u.offeringSlots(ctx, offering, bookedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		open := openSlotsAfter(slots, bookedDate, now)
+		for _, slot := range open {
+			if len(proposal.Slots) == limit {
+				break
+			}
+			proposal.Slots = append(proposal.Slots, RebookSlot{
+				BookedDate: bookedDate,
+				BookedTime: slot.Slot,
+				Price:      slot.Price,
+				Currency:   slot.Currency,
+			})
+		}
+	}
+
+	return proposal, nil
+}
+
+// openSlotsAfter returns the available slots of the day that start after
+// now, earliest first.
+func openSlotsAfter(slots []Slot, bookedDate string, now time.Time) []Slot {
+	type timedSlot struct {
+		slot Slot
+		at   time.Time
+	}
+
+	open := make([]timedSlot, 0, len(slots))
+	for _, slot := range slots {
+		if !slot.IsAvailable {
+			continue
+		}
+		at, err := utils.ParseBookingTime(bookedDate, slot.Slot)
+		if err != nil || !at.After(now) {
+			continue
+		}
+		open = append(open, timedSlot{slot: slot, at: at})
+	}
+	sort.Slice(open, func(i, j int) bool { return open[i].at.Before(open[j].at) })
+
+	result := make([]Slot, len(open))
+	for i, timed := range open {
+		result[i] = timed.slot
+	}
+	return result
+}
@@ -2,15 +2,20 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"KaungHtetHein116/IVY-backend/api/transport"
 	"KaungHtetHein116/IVY-backend/api/v1/params"
 	"KaungHtetHein116/IVY-backend/api/v1/request"
 	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/payment"
 	"KaungHtetHein116/IVY-backend/internal/repository"
 	"KaungHtetHein116/IVY-backend/utils"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type BookingUsecase interface {
@@ -19,16 +24,50 @@ type BookingUsecase interface {
 	GetAllBookings(ctx context.Context, filter *params.BookingQueryParams) ([]entity.Booking, *transport.PaginationResponse, error)
 	GetUserBookings(ctx context.Context, userID string) ([]entity.Booking, error)
 	UpdateBooking(ctx context.Context, id uuid.UUID, req *request.UpdateBookingRequest) (*entity.Booking, error)
+	CancelBooking(ctx context.Context, id uuid.UUID, userID string) (*entity.Booking, error)
 	DeleteBooking(ctx context.Context, id uuid.UUID) error
-	GetTimeSlotsByBranchIDAndDate(ctx context.Context, branchID uuid.UUID, bookedDate string) ([]Slot, error)
+	PayBooking(ctx context.Context, id uuid.UUID, userID string, req *request.PayBookingRequest) (*entity.Booking, error)
+	GetBookingPayments(ctx context.Context, id uuid.UUID) ([]entity.BookingPayment, error)
+	GetTimeSlotsByBranchIDAndDate(ctx context.Context, branchID uuid.UUID, bookedDate string, serviceID uuid.UUID, variantID *uuid.UUID) ([]Slot, error)
+	BookAgain(ctx context.Context, id uuid.UUID, userID string, filter *params.BookAgainQueryParams) (*RebookProposal, error)
 }
 
 type bookingUsecase struct {
-	repo repository.BookingRepository
+	repo             repository.BookingRepository
+	serviceRepo      repository.ServiceRepository
+	packageRepo      repository.PackageRepository
+	giftCardRepo     repository.GiftCardRepository
+	membershipRepo   repository.MembershipRepository
+	promotionUsecase PromotionUsecase
+	loyaltyUsecase   LoyaltyUsecase
+	invoiceUsecase   InvoiceUsecase
+	taxRateUsecase   TaxRateUsecase
+	pricingUsecase   PricingRuleUsecase
+	refundUsecase    RefundUsecase
+	inventoryUsecase InventoryUsecase
+	provider         payment.Provider
 }
 
-func NewBookingUsecase(repo repository.BookingRepository) BookingUsecase {
-	return &bookingUsecase{repo: repo}
+func NewBookingUsecase(repo repository.BookingRepository, serviceRepo repository.ServiceRepository,
+	packageRepo repository.PackageRepository, giftCardRepo repository.GiftCardRepository,
+	membershipRepo repository.MembershipRepository, promotionUsecase PromotionUsecase, loyaltyUsecase LoyaltyUsecase, invoiceUsecase InvoiceUsecase,
+	taxRateUsecase TaxRateUsecase, pricingUsecase PricingRuleUsecase, refundUsecase RefundUsecase,
+	inventoryUsecase InventoryUsecase, provider payment.Provider) BookingUsecase {
+	return &bookingUsecase{
+		repo:             repo,
+		serviceRepo:      serviceRepo,
+		packageRepo:      packageRepo,
+		giftCardRepo:     giftCardRepo,
+		membershipRepo:   membershipRepo,
+		promotionUsecase: promotionUsecase,
+		loyaltyUsecase:   loyaltyUsecase,
+		invoiceUsecase:   invoiceUsecase,
+		taxRateUsecase:   taxRateUsecase,
+		pricingUsecase:   pricingUsecase,
+		refundUsecase:    refundUsecase,
+		inventoryUsecase: inventoryUsecase,
+		provider:         provider,
+	}
 }
 
 func (u *bookingUsecase) CreateBooking(ctx context.Context, userID string, req *request.CreateBookingRequest) (*entity.Booking, error) {
@@ -52,6 +91,10 @@ func (u *bookingUsecase) CreateBooking(ctx context.Context, userID string, req *
 		return nil, err
 	}
 
+	if err := u.priceBooking(ctx, booking, req); err != nil {
+		return nil, err
+	}
+
 	err = u.repo.Create(ctx, booking)
 
 	if err != nil {
@@ -61,6 +104,258 @@ func (u *bookingUsecase) CreateBooking(ctx context.Context, userID string, req *
 	return booking, nil
 }
 
+// priceBooking checks the branch offers the service and has room for it in
+// the slot, then snapshots the service as it is in the catalog, the price quoted
+// for the slot at the branch for the chosen variant, the add-ons on top of it, the member discount, the discount of the promo code and the loyalty points
+// redeemed, if given, and the tax on what is left.
+func (u *bookingUsecase) priceBooking(ctx context.Context, booking *entity.Booking, req *request.CreateBookingRequest) error {
+	service, branchService, err := u.branchOffering(ctx, req.ServiceID, req.BranchID)
+	if err != nil {
+		return err
+	}
+
+	counts, err := u.repo.CountServiceBookingsBySlot(ctx, req.BranchID, req.ServiceID, req.BookedDate)
+	if err != nil {
+		return err
+	}
+	if branchService.IsFull(counts[req.BookedTime]) {
+		return utils.ErrSlotFull
+	}
+
+	variant, addOns, err := selectOptions(service, req.VariantID, req.AddOnIDs)
+	if err != nil {
+		return err
+	}
+
+	basePrice := branchBasePrice(service, variant, branchService)
+
+	price := basePrice
+	if at, err := utils.ParseBookingTime(req.BookedDate, req.BookedTime); err == nil {
+		rules, err := u.pricingUsecase.GetActiveRules(ctx)
+		if err != nil {
+			return err
+		}
+		var rule *entity.PricingRule
+		price, rule = rules.Price(basePrice, service, req.BranchID, at, time.Now())
+		if rule != nil {
+			booking.PricingRuleID = &rule.ID
+		}
+	}
+
+	if req.QuotedPrice != nil && *req.QuotedPrice != price {
+		return utils.ErrPriceChanged
+	}
+
+	version, err := u.serviceRepo.GetCurrentVersion(ctx, service.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if version != nil {
+		booking.ServiceVersionID = &version.ID
+	}
+	booking.ServiceName = service.Name
+
+	booking.DurationMinute = branchService.EffectiveDuration(service.DurationMinute)
+	if variant != nil {
+		booking.VariantID = &variant.ID
+		booking.VariantName = variant.Name
+		booking.DurationMinute = branchService.VariantDuration(service.DurationMinute, variant.DurationMinute)
+	}
+	for _, addOn := range addOns {
+		booking.AddOns = append(booking.AddOns, entity.BookingAddOn{
+			ID:             uuid.New(),
+			BookingID:      booking.ID,
+			AddOnID:        addOn.ID,
+			Name:           addOn.Name,
+			DurationMinute: addOn.DurationMinute,
+			Price:          addOn.Price,
+		})
+		booking.AddOnsPrice += addOn.Price
+		booking.DurationMinute += addOn.DurationMinute
+	}
+
+	booking.Currency = service.Currency
+	booking.BasePrice = basePrice
+	booking.ServicePrice = price + booking.AddOnsPrice
+
+	subscription, err := u.currentSubscription(ctx, booking.UserID)
+	if err != nil {
+		return err
+	}
+	if subscription != nil {
+		booking.SubscriptionID = &subscription.ID
+		booking.MemberDiscount = booking.ServicePrice * int64(subscription.Plan.DiscountPercent) / 100
+	}
+
+	if req.PromoCode != nil && *req.PromoCode != "" {
+		promotion, discount, err := u.promotionUsecase.ApplyPromotion(ctx, *req.PromoCode, booking.UserID, PromotionLine{
+			ServiceID:  service.ID,
+			CategoryID: service.CategoryID,
+			BranchID:   req.BranchID,
+			Price:      booking.ServicePrice - booking.MemberDiscount,
+			Currency:   service.Currency,
+		})
+		if err != nil {
+			return err
+		}
+		booking.PromotionID = &promotion.ID
+		booking.PromoCode = &promotion.Code
+		booking.DiscountAmount = discount
+	}
+
+	booking.TotalPrice = booking.ServicePrice - booking.MemberDiscount - booking.DiscountAmount
+
+	if req.RedeemPoints > 0 {
+		if req.CustomerPackageID != nil {
+			return utils.ErrRedemptionConflict
+		}
+		points, discount, err := u.loyaltyUsecase.PointsDiscount(ctx, booking.UserID, req.RedeemPoints, booking.TotalPrice)
+		if err != nil {
+			return err
+		}
+		booking.PointsRedeemed = points
+		booking.PointsDiscount = discount
+		booking.TotalPrice -= discount
+	}
+
+	rates, err := u.taxRateUsecase.GetActiveRates(ctx)
+	if err != nil {
+		return err
+	}
+	breakdown := rates.Breakdown(booking.TotalPrice, req.BranchID, service.CategoryID)
+	booking.Subtotal = breakdown.Subtotal
+	booking.TaxAmount = breakdown.Tax
+	booking.TaxRate = breakdown.Rate
+	booking.TaxInclusive = breakdown.Inclusive
+	booking.TotalPrice = breakdown.Total
+
+	booking.AmountDue = booking.TotalPrice
+
+	if err := u.attachPrepaidValue(ctx, booking, req); err != nil {
+		return err
+	}
+
+	if subscription != nil {
+		useIncludedSession(booking, subscription)
+	}
+	return nil
+}
+
+// currentSubscription returns the user's membership if it grants benefits
+// now, or nil.
+func (u *bookingUsecase) currentSubscription(ctx context.Context, userID string) (*entity.Subscription, error) {
+	subscription, err := u.membershipRepo.GetUserSubscription(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !subscription.IsCurrent(time.Now()) {
+		return nil, nil
+	}
+	return subscription, nil
+}
+
+// useIncludedSession covers the booking with a session included in the
+// membership when one is left for the service. It is only used when the
+// customer has not asked to pay another way.
+func useIncludedSession(booking *entity.Booking, subscription *entity.Subscription) {
+	if booking.PackageCreditID != nil || booking.GiftCardID != nil ||
+		booking.PromotionID != nil || booking.PointsRedeemed > 0 {
+		return
+	}
+
+	for _, usage := range subscription.Usages {
+		if usage.ServiceID == booking.ServiceID && usage.Used < usage.Included {
+			booking.SubscriptionUsageID = &usage.ID
+			booking.AmountDue = 0
+			return
+		}
+	}
+}
+
+// branchOffering returns the service and how the branch offers it. Services
+// the branch does not offer, or has paused, cannot be booked there.
+func (u *bookingUsecase) branchOffering(ctx context.Context, serviceID, branchID uuid.UUID) (*entity.Service, *entity.BranchService, error) {
+	service, err := u.serviceRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, utils.ErrServiceNotFound
+		}
+		return nil, nil, err
+	}
+
+	branchServices, err := u.serviceRepo.GetBranchServices(ctx, branchID, []uuid.UUID{serviceID})
+	if err != nil {
+		return nil, nil, err
+	}
+	branchService, ok := branchServices[serviceID]
+	if !ok || !branchService.IsActive {
+		return nil, nil, utils.ErrServiceNotAtBranch
+	}
+	return service, &branchService, nil
+}
+
+// branchBasePrice is the price of the variant, or of the service, at the
+// branch before pricing rules.
+func branchBasePrice(service *entity.Service, variant *entity.ServiceVariant, branchService *entity.BranchService) int64 {
+	if variant != nil {
+		return branchService.VariantPrice(service.Price, variant.Price)
+	}
+	return branchService.EffectivePrice(service.Price)
+}
+
+// attachPrepaidValue resolves the package credit and gift card the customer
+// wants to redeem. The balances themselves are checked and moved when the
+// booking is stored.
+func (u *bookingUsecase) attachPrepaidValue(ctx context.Context, booking *entity.Booking, req *request.CreateBookingRequest) error {
+	hasGiftCard := req.GiftCardCode != nil && *req.GiftCardCode != ""
+
+	if req.CustomerPackageID != nil {
+		if booking.PromotionID != nil || hasGiftCard {
+			return utils.ErrRedemptionConflict
+		}
+
+		customerPackage, err := u.packageRepo.GetCustomerPackageByID(ctx, *req.CustomerPackageID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrPackageCreditNotFound
+			}
+			return err
+		}
+		if customerPackage.UserID != booking.UserID {
+			return utils.ErrPackageCreditNotFound
+		}
+
+		for _, credit := range customerPackage.Credits {
+			if credit.ServiceID == booking.ServiceID {
+				booking.PackageCreditID = &credit.ID
+				break
+			}
+		}
+		if booking.PackageCreditID == nil {
+			return utils.ErrPackageCreditNotFound
+		}
+
+		// The session is prepaid, nothing is left to pay
+		booking.AmountDue = 0
+	}
+
+	if hasGiftCard {
+		giftCard, err := u.giftCardRepo.GetByCode(ctx, *req.GiftCardCode)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrGiftCardNotFound
+			}
+			return err
+		}
+		booking.GiftCardID = &giftCard.ID
+	}
+
+	return nil
+}
+
 func (u *bookingUsecase) GetBookingByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
 	return u.repo.GetByID(ctx, id)
 }
@@ -75,32 +370,273 @@ func (u *bookingUsecase) GetUserBookings(ctx context.Context, userID string) ([]
 
 func (u *bookingUsecase) UpdateBooking(ctx context.Context, id uuid.UUID, req *request.UpdateBookingRequest) (*entity.Booking, error) {
 	// Check if booking exists
-	_, err := u.repo.GetByID(ctx, id)
+	booking, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := u.repo.Update(ctx, id, req); err != nil {
+	// Redemptions are given back on cancellation, so it cannot be undone
+	if booking.Status == "CANCELLED" && req.Status != "CANCELLED" {
+		return nil, utils.ErrBookingCancelled
+	}
+	// Points are awarded and stock consumed on completion, so it is final
+	if booking.Status == "COMPLETED" && req.Status != "COMPLETED" {
+		return nil, utils.ErrBookingCompleted
+	}
+	// Money taken is only given back through a refund
+	if req.PaymentStatus != nil && *req.PaymentStatus == "UNPAID" && booking.AmountPaid > 0 {
+		return nil, utils.ErrBookingHasPayments
+	}
+
+	if req.Status == "CANCELLED" {
+		return u.cancel(ctx, id)
+	}
+
+	err = u.repo.Update(ctx, id, map[string]interface{}{"status": req.Status})
+	if err == nil && req.PaymentStatus != nil && *req.PaymentStatus != booking.PaymentStatus {
+		err = u.setPaymentStatus(ctx, booking, *req.PaymentStatus)
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	// Get updated booking
+	booking, err = u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status == "COMPLETED" {
+		if err := u.awardPoints(ctx, booking); err != nil {
+			return nil, err
+		}
+		if err := u.inventoryUsecase.ConsumeStock(ctx, booking); err != nil {
+			return nil, err
+		}
+	}
+
+	if booking.Status == "COMPLETED" || booking.PaymentStatus == "PAID" {
+		if _, err := u.invoiceUsecase.IssueInvoice(ctx, booking.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return booking, nil
+}
+
+// CancelBooking lets the customer cancel their own booking. It is the only
+// change customers make to a booking; staff confirm, complete and mark it
+// paid through UpdateBooking.
+func (u *bookingUsecase) CancelBooking(ctx context.Context, id uuid.UUID, userID string) (*entity.Booking, error) {
+	booking, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return u.cancel(ctx, id)
+}
+
+// cancel cancels the booking, giving back what it redeemed, and opens a
+// refund of what was paid.
+func (u *bookingUsecase) cancel(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	refundFor, err := u.refundUsecase.RefundFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.Cancel(ctx, id, refundFor); err != nil {
+		return nil, err
+	}
+
 	return u.repo.GetByID(ctx, id)
 }
 
+// setPaymentStatus marks the booking as paid at the counter, recording the
+// rest of the amount due as a counter payment, or undoes that mark while
+// nothing has been paid.
+func (u *bookingUsecase) setPaymentStatus(ctx context.Context, booking *entity.Booking, status string) error {
+	if status == "PAID" {
+		return u.repo.RecordPayment(ctx, &entity.BookingPayment{
+			ID:        uuid.New(),
+			BookingID: booking.ID,
+			Amount:    max(booking.AmountDue-booking.AmountPaid, 0),
+			Currency:  booking.Currency,
+			Method:    entity.PaymentMethodCounter,
+		})
+	}
+	return u.repo.Update(ctx, booking.ID, map[string]interface{}{
+		"payment_status": status,
+		"paid_at":        nil,
+	})
+}
+
+// PayBooking charges the customer's card for the booking through the payment
+// provider. Paying less than the amount still due leaves a deposit.
+func (u *bookingUsecase) PayBooking(ctx context.Context, id uuid.UUID, userID string, req *request.PayBookingRequest) (*entity.Booking, error) {
+	booking, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	remaining := booking.AmountDue - booking.AmountPaid
+	if booking.Status == "CANCELLED" || booking.PaymentStatus == "PAID" || remaining <= 0 {
+		return nil, utils.ErrBookingNotPayable
+	}
+
+	amount := remaining
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+	if amount <= 0 || amount > remaining {
+		return nil, utils.ErrInvalidPaymentAmount
+	}
+
+	charge, err := u.provider.Charge(ctx, payment.ChargeRequest{
+		CustomerID:     userID,
+		Amount:         amount,
+		Currency:       booking.Currency,
+		Description:    fmt.Sprintf("Booking on %s at %s", booking.BookedDate, booking.BookedTime),
+		IdempotencyKey: fmt.Sprintf("booking:%s:%d:%d", booking.ID, booking.AmountPaid, amount),
+	})
+	if err != nil {
+		if errors.Is(err, payment.ErrChargeDeclined) {
+			return nil, utils.ErrPaymentDeclined
+		}
+		return nil, err
+	}
+
+	if err := u.repo.RecordPayment(ctx, &entity.BookingPayment{
+		ID:        uuid.New(),
+		BookingID: booking.ID,
+		Amount:    charge.Amount,
+		Currency:  booking.Currency,
+		Method:    entity.PaymentMethodCard,
+		ChargeID:  &charge.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	booking, err = u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.PaymentStatus == "PAID" {
+		if _, err := u.invoiceUsecase.IssueInvoice(ctx, booking.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return booking, nil
+}
+
+func (u *bookingUsecase) GetBookingPayments(ctx context.Context, id uuid.UUID) ([]entity.BookingPayment, error) {
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.repo.GetPayments(ctx, id)
+}
+
+// awardPoints credits the loyalty points earned by a completed booking.
+func (u *bookingUsecase) awardPoints(ctx context.Context, booking *entity.Booking) error {
+	service, err := u.serviceRepo.GetByIDWithDeleted(ctx, booking.ServiceID)
+	if err != nil {
+		return err
+	}
+	return u.loyaltyUsecase.AwardPoints(ctx, booking, service.CategoryID)
+}
+
 func (u *bookingUsecase) DeleteBooking(ctx context.Context, id uuid.UUID) error {
 	return u.repo.Delete(ctx, id)
 }
 
-func (u *bookingUsecase) GetTimeSlotsByBranchIDAndDate(ctx context.Context, branchID uuid.UUID, bookedDate string) ([]Slot, error) {
-	takenTimeSlots := u.repo.GetBookingTimeSlotByDateAndBranch(ctx, branchID, bookedDate)
+func (u *bookingUsecase) GetTimeSlotsByBranchIDAndDate(ctx context.Context, branchID uuid.UUID, bookedDate string, serviceID uuid.UUID, variantID *uuid.UUID) ([]Slot, error) {
+	if serviceID == uuid.Nil {
+		takenTimeSlots := u.repo.GetBookingTimeSlotByDateAndBranch(ctx, branchID, bookedDate)
+		return getAvailableTimeSlots(takenTimeSlots), nil
+	}
+
+	offering, err := u.loadSlotOffering(ctx, serviceID, branchID, variantID)
+	if err != nil {
+		return nil, err
+	}
+	return u.offeringSlots(ctx, offering, bookedDate)
+}
+
+// slotOffering is what the slots of a service at a branch are checked and
+// priced against. It is loaded once, however many days are looked at.
+type slotOffering struct {
+	service       *entity.Service
+	branchService *entity.BranchService
+	basePrice     int64
+	rules         PricingRules
+}
+
+// loadSlotOffering loads how the branch offers the service, or the variant if
+// given, and the active pricing rules.
+func (u *bookingUsecase) loadSlotOffering(ctx context.Context, serviceID, branchID uuid.UUID, variantID *uuid.UUID) (*slotOffering, error) {
+	service, branchService, err := u.branchOffering(ctx, serviceID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	var variant *entity.ServiceVariant
+	if variantID != nil {
+		if variant, _, err = selectOptions(service, variantID, nil); err != nil {
+			return nil, err
+		}
+	}
+	rules, err := u.pricingUsecase.GetActiveRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &slotOffering{
+		service:       service,
+		branchService: branchService,
+		basePrice:     branchBasePrice(service, variant, branchService),
+		rules:         rules,
+	}, nil
+}
+
+// offeringSlots returns the slots of the day with those the branch or the
+// service is full in unavailable, and quotes the price of every slot.
+// Add-ons are not included.
+func (u *bookingUsecase) offeringSlots(ctx context.Context, offering *slotOffering, bookedDate string) ([]Slot, error) {
+	branchID, serviceID := offering.branchService.BranchID, offering.service.ID
+	slots := getAvailableTimeSlots(u.repo.GetBookingTimeSlotByDateAndBranch(ctx, branchID, bookedDate))
+
+	// Slots the service has reached its capacity in are full for it
+	counts, err := u.repo.CountServiceBookingsBySlot(ctx, branchID, serviceID, bookedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range slots {
+		if offering.branchService.IsFull(counts[slots[i].Slot]) {
+			slots[i].IsAvailable = false
+		}
 
-	return getAvailableTimeSlots(takenTimeSlots), nil
+		price := offering.basePrice
+		if at, err := utils.ParseBookingTime(bookedDate, slots[i].Slot); err == nil {
+			price, _ = offering.rules.Price(offering.basePrice, offering.service, branchID, at, now)
+		}
+		slots[i].Price = &price
+		slots[i].Currency = offering.service.Currency
+	}
+	return slots, nil
 }
 
 type Slot struct {
 	Slot        string `json:"slot"`
 	IsAvailable bool   `json:"is_available"`
+	// Price is the quoted price of the requested service in this slot
+	Price    *int64 `json:"price,omitempty"`
+	Currency string `json:"currency,omitempty"`
 }
 
 func getAvailableTimeSlots(takenTimeSlots []string) []Slot {
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BranchMemberUsecase assigns staff and branch managers to the branches they
+// work at.
+type BranchMemberUsecase interface {
+	GetBranchMembers(ctx context.Context, branchID uuid.UUID) ([]entity.BranchMember, error)
+	GetMyBranches(ctx context.Context, userID string) ([]entity.BranchMember, error)
+	AddBranchMember(ctx context.Context, branchID uuid.UUID, userID string) (*entity.BranchMember, error)
+	RemoveBranchMember(ctx context.Context, branchID uuid.UUID, userID string) error
+}
+
+type branchMemberUsecase struct {
+	repo       repository.BranchMemberRepository
+	branchRepo repository.BranchRepository
+	userRepo   repository.UserRepository
+}
+
+func NewBranchMemberUsecase(repo repository.BranchMemberRepository, branchRepo repository.BranchRepository, userRepo repository.UserRepository) BranchMemberUsecase {
+	return &branchMemberUsecase{repo: repo, branchRepo: branchRepo, userRepo: userRepo}
+}
+
+func (u *branchMemberUsecase) GetBranchMembers(ctx context.Context, branchID uuid.UUID) ([]entity.BranchMember, error) {
+	if _, err := u.branchRepo.GetByID(ctx, branchID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrBranchNotFound
+		}
+		return nil, err
+	}
+	return u.repo.GetMembers(ctx, branchID)
+}
+
+func (u *branchMemberUsecase) GetMyBranches(ctx context.Context, userID string) ([]entity.BranchMember, error) {
+	return u.repo.GetBranches(ctx, userID)
+}
+
+// AddBranchMember makes a STAFF or BRANCH_MANAGER user a member of the
+// branch. Give the user their role first.
+func (u *branchMemberUsecase) AddBranchMember(ctx context.Context, branchID uuid.UUID, userID string) (*entity.BranchMember, error) {
+	if _, err := u.branchRepo.GetByID(ctx, branchID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrBranchNotFound
+		}
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.Role == nil || !entity.IsBranchRole(*user.Role) {
+		return nil, utils.ErrNotBranchRole
+	}
+
+	member := &entity.BranchMember{BranchID: branchID, UserID: userID}
+	if err := u.repo.Add(ctx, member); err != nil {
+		return nil, err
+	}
+	member.User = user
+	return member, nil
+}
+
+func (u *branchMemberUsecase) RemoveBranchMember(ctx context.Context, branchID uuid.UUID, userID string) error {
+	return u.repo.Remove(ctx, branchID, userID)
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BranchServiceUsecase manages the services a branch offers and the price,
+// duration and capacity they have there.
+type BranchServiceUsecase interface {
+	GetBranchServices(ctx context.Context, branchID uuid.UUID) ([]entity.Service, error)
+	SaveBranchService(ctx context.Context, branchID, serviceID uuid.UUID, req *request.SaveBranchServiceRequest) (*entity.BranchService, error)
+	RemoveBranchService(ctx context.Context, branchID, serviceID uuid.UUID) error
+}
+
+type branchServiceUsecase struct {
+	repo       repository.BranchServiceRepository
+	branchRepo repository.BranchRepository
+}
+
+func NewBranchServiceUsecase(repo repository.BranchServiceRepository, branchRepo repository.BranchRepository) BranchServiceUsecase {
+	return &branchServiceUsecase{repo: repo, branchRepo: branchRepo}
+}
+
+func (u *branchServiceUsecase) GetBranchServices(ctx context.Context, branchID uuid.UUID) ([]entity.Service, error) {
+	if _, err := u.branchRepo.GetByID(ctx, branchID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrBranchNotFound
+		}
+		return nil, err
+	}
+	return u.repo.GetServices(ctx, branchID)
+}
+
+// SaveBranchService offers the service at the branch, or changes how it is
+// offered there.
+func (u *branchServiceUsecase) SaveBranchService(ctx context.Context, branchID, serviceID uuid.UUID, req *request.SaveBranchServiceRequest) (*entity.BranchService, error) {
+	branchService := &entity.BranchService{
+		BranchID:         branchID,
+		ServiceID:        serviceID,
+		IsActive:         req.IsActive == nil || *req.IsActive,
+		PriceOverride:    req.PriceOverride,
+		DurationOverride: req.DurationOverride,
+		Capacity:         req.Capacity,
+	}
+	if err := u.repo.Save(ctx, branchService); err != nil {
+		return nil, err
+	}
+	return u.repo.Get(ctx, branchID, serviceID)
+}
+
+func (u *branchServiceUsecase) RemoveBranchService(ctx context.Context, branchID, serviceID uuid.UUID) error {
+	return u.repo.Delete(ctx, branchID, serviceID)
+}
@@ -8,6 +8,7 @@ import (
 	"KaungHtetHein116/IVY-backend/api/v1/request"
 	"KaungHtetHein116/IVY-backend/internal/entity"
 	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
 
 	"github.com/google/uuid"
 )
@@ -16,16 +17,28 @@ type BranchUsecase interface {
 	CreateBranch(ctx context.Context, req *request.CreateBranchRequest) (*entity.Branch, error)
 	GetBranchByID(ctx context.Context, id uuid.UUID) (*entity.Branch, error)
 	GetAllBranches(ctx context.Context, filter *params.BranchQueryParams) ([]entity.Branch, *transport.PaginationResponse, error)
+	GetNearbyBranches(ctx context.Context, filter *params.BranchNearbyQueryParams) ([]entity.Branch, *transport.PaginationResponse, error)
 	UpdateBranch(ctx context.Context, id uuid.UUID, req *request.UpdateBranchRequest) (*entity.Branch, error)
-	DeleteBranch(ctx context.Context, id uuid.UUID) error
+	GetDeleteImpact(ctx context.Context, id uuid.UUID) (*DeleteImpact, error)
+	DeleteBranch(ctx context.Context, id uuid.UUID, opts *params.DeleteQueryParams) (*DeleteImpact, error)
+	GetDeletedBranches(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Branch, *transport.PaginationResponse, error)
+	RestoreBranch(ctx context.Context, id uuid.UUID) (*entity.Branch, error)
+	UploadBranchImage(ctx context.Context, id uuid.UUID, data []byte) (*entity.Branch, error)
 }
 
+// MaxNearbyRadiusKm is the widest radius a nearby search may cover.
+const MaxNearbyRadiusKm = 100
+
 type branchUsecase struct {
-	repo repository.BranchRepository
+	repo               repository.BranchRepository
+	imageUsecase       ImageUsecase
+	translationUsecase TranslationUsecase
+	safeguardUsecase   DeleteSafeguardUsecase
 }
 
-func NewBranchUsecase(repo repository.BranchRepository) BranchUsecase {
-	return &branchUsecase{repo: repo}
+func NewBranchUsecase(repo repository.BranchRepository, imageUsecase ImageUsecase, translationUsecase TranslationUsecase,
+	safeguardUsecase DeleteSafeguardUsecase) BranchUsecase {
+	return &branchUsecase{repo: repo, imageUsecase: imageUsecase, translationUsecase: translationUsecase, safeguardUsecase: safeguardUsecase}
 }
 
 func (u *branchUsecase) CreateBranch(ctx context.Context, req *request.CreateBranchRequest) (*entity.Branch, error) {
@@ -43,11 +56,50 @@ func (u *branchUsecase) CreateBranch(ctx context.Context, req *request.CreateBra
 }
 
 func (u *branchUsecase) GetBranchByID(ctx context.Context, id uuid.UUID) (*entity.Branch, error) {
-	return u.repo.GetByID(ctx, id)
+	branch, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := []entity.Branch{*branch}
+	if err := u.translationUsecase.LocalizeBranches(ctx, branches); err != nil {
+		return nil, err
+	}
+	return &branches[0], nil
 }
 
 func (u *branchUsecase) GetAllBranches(ctx context.Context, filter *params.BranchQueryParams) ([]entity.Branch, *transport.PaginationResponse, error) {
-	return u.repo.GetAll(ctx, filter)
+	branches, pagination, err := u.repo.GetAll(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := u.translationUsecase.LocalizeBranches(ctx, branches); err != nil {
+		return nil, nil, err
+	}
+	return branches, pagination, nil
+}
+
+// GetNearbyBranches returns the branches within the radius of the point,
+// nearest first, each with its distance.
+func (u *branchUsecase) GetNearbyBranches(ctx context.Context, filter *params.BranchNearbyQueryParams) ([]entity.Branch, *transport.PaginationResponse, error) {
+	if filter.Lat == nil || filter.Lng == nil ||
+		*filter.Lat < -90 || *filter.Lat > 90 || *filter.Lng < -180 || *filter.Lng > 180 {
+		return nil, nil, utils.ErrInvalidCoordinates
+	}
+	if filter.RadiusKm <= 0 || filter.RadiusKm > MaxNearbyRadiusKm {
+		return nil, nil, utils.ErrInvalidRadius
+	}
+
+	branches, pagination, err := u.repo.GetNearby(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := u.translationUsecase.LocalizeBranches(ctx, branches); err != nil {
+		return nil, nil, err
+	}
+	return branches, pagination, nil
 }
 
 func (u *branchUsecase) UpdateBranch(ctx context.Context, id uuid.UUID, req *request.UpdateBranchRequest) (*entity.Branch, error) {
@@ -66,6 +118,89 @@ func (u *branchUsecase) UpdateBranch(ctx context.Context, id uuid.UUID, req *req
 	return u.repo.GetByID(ctx, id)
 }
 
-func (u *branchUsecase) DeleteBranch(ctx context.Context, id uuid.UUID) error {
-	return u.repo.Delete(ctx, id)
+func (u *branchUsecase) GetDeleteImpact(ctx context.Context, id uuid.UUID) (*DeleteImpact, error) {
+	impact, err := u.deleteImpact(ctx, id)
+	return impact, err
+}
+
+// DeleteBranch moves the branch to the trash, keeping its image for a
+// restore. By default it refuses while the branch has upcoming bookings;
+// cancel cancels them along with the delete.
+func (u *branchUsecase) DeleteBranch(ctx context.Context, id uuid.UUID, opts *params.DeleteQueryParams) (*DeleteImpact, error) {
+	strategy, err := deleteStrategy(opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	impact, err := u.deleteImpact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if strategy == DeleteStrategyBlock && impact.Blocked() {
+		return impact, utils.ErrDeleteBlocked
+	}
+
+	refundFor, err := u.safeguardUsecase.RefundFor(ctx, strategy)
+	if err != nil {
+		return nil, err
+	}
+	cancelled, err := u.repo.Delete(ctx, id, refundFor)
+	if err != nil {
+		return nil, err
+	}
+	u.safeguardUsecase.NotifyCancelled(ctx, cancelled)
+	return nil, nil
+}
+
+func (u *branchUsecase) deleteImpact(ctx context.Context, id uuid.UUID) (*DeleteImpact, error) {
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	bookings, err := u.safeguardUsecase.GetBranchBookings(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteImpact{FutureBookings: len(bookings)}, nil
+}
+
+func (u *branchUsecase) GetDeletedBranches(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Branch, *transport.PaginationResponse, error) {
+	return u.repo.GetDeleted(ctx, filter)
+}
+
+func (u *branchUsecase) RestoreBranch(ctx context.Context, id uuid.UUID) (*entity.Branch, error) {
+	if err := u.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+// UploadBranchImage stores the uploaded image with its thumbnails, shows it
+// on the branch and removes the image it replaces.
+func (u *branchUsecase) UploadBranchImage(ctx context.Context, id uuid.UUID, data []byte) (*entity.Branch, error) {
+	branch, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := u.imageUsecase.Upload(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Update(ctx, id, map[string]interface{}{"image": image.URL, "image_id": image.ID}); err != nil {
+		u.removeImage(ctx, image.ID)
+		return nil, err
+	}
+
+	if branch.ImageID != nil {
+		u.removeImage(ctx, *branch.ImageID)
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+// removeImage deletes an image nothing points at any more, leaving failures
+// to the orphan cleanup job.
+func (u *branchUsecase) removeImage(ctx context.Context, imageID uuid.UUID) {
+	_ = u.imageUsecase.Remove(context.WithoutCancel(ctx), imageID)
 }
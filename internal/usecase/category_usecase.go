@@ -2,61 +2,284 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
 	"KaungHtetHein116/IVY-backend/api/transport"
 	"KaungHtetHein116/IVY-backend/api/v1/params"
 	"KaungHtetHein116/IVY-backend/api/v1/request"
 	"KaungHtetHein116/IVY-backend/internal/entity"
 	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type CategoryUsecase interface {
 	CreateCategory(ctx context.Context, req *request.CreateCategoryRequest) (*entity.Category, error)
 	GetCategoryByID(ctx context.Context, id uuid.UUID) (*entity.Category, error)
 	GetAllCategories(ctx context.Context, params *params.CategoryQueryParams) ([]entity.Category, *transport.PaginationResponse, error)
+	GetCategoryTree(ctx context.Context, includeHidden bool) ([]entity.Category, error)
 	UpdateCategory(ctx context.Context, id uuid.UUID, req *request.UpdateCategoryRequest) (*entity.Category, error)
-	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	ReorderCategories(ctx context.Context, req *request.ReorderCategoriesRequest) ([]entity.Category, error)
+	GetDeleteImpact(ctx context.Context, id uuid.UUID) (*DeleteImpact, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID, opts *params.DeleteQueryParams) (*DeleteImpact, error)
+	GetDeletedCategories(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Category, *transport.PaginationResponse, error)
+	RestoreCategory(ctx context.Context, id uuid.UUID) (*entity.Category, error)
 }
 
 type categoryUsecase struct {
-	repo repository.CategoryRepository
+	repo               repository.CategoryRepository
+	translationUsecase TranslationUsecase
+	safeguardUsecase   DeleteSafeguardUsecase
 }
 
-func NewCategoryUsecase(repo repository.CategoryRepository) CategoryUsecase {
-	return &categoryUsecase{repo: repo}
+func NewCategoryUsecase(repo repository.CategoryRepository, translationUsecase TranslationUsecase,
+	safeguardUsecase DeleteSafeguardUsecase) CategoryUsecase {
+	return &categoryUsecase{repo: repo, translationUsecase: translationUsecase, safeguardUsecase: safeguardUsecase}
 }
 
 func (u *categoryUsecase) CreateCategory(ctx context.Context, req *request.CreateCategoryRequest) (*entity.Category, error) {
 	category := &entity.Category{
-		ID:   uuid.New(),
-		Name: req.Name,
+		ID:        uuid.New(),
+		Name:      req.Name,
+		ParentID:  req.ParentID,
+		SortOrder: req.SortOrder,
+		Icon:      req.Icon,
+		Image:     req.Image,
+		IsHidden:  req.IsHidden,
 	}
 	err := u.repo.Create(ctx, category)
 	return category, err
 }
 
 func (u *categoryUsecase) GetCategoryByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
-	return u.repo.GetByID(ctx, id)
+	category, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := []entity.Category{*category}
+	if err := u.translationUsecase.LocalizeCategories(ctx, categories); err != nil {
+		return nil, err
+	}
+	return &categories[0], nil
 }
 
 func (u *categoryUsecase) GetAllCategories(ctx context.Context, params *params.CategoryQueryParams) ([]entity.Category, *transport.PaginationResponse, error) {
-	return u.repo.GetAll(ctx, params)
+	categories, pagination, err := u.repo.GetAll(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := u.translationUsecase.LocalizeCategories(ctx, categories); err != nil {
+		return nil, nil, err
+	}
+	return categories, pagination, nil
 }
 
-func (u *categoryUsecase) UpdateCategory(ctx context.Context, id uuid.UUID, req *request.UpdateCategoryRequest) (*entity.Category, error) {
-	category, err := u.repo.GetByID(ctx, id)
+// GetCategoryTree returns the top-level categories with their subcategories
+// nested under them. Hidden categories are left out with their subtree.
+func (u *categoryUsecase) GetCategoryTree(ctx context.Context, includeHidden bool) ([]entity.Category, error) {
+	categories, err := u.repo.GetTree(ctx, includeHidden)
 	if err != nil {
 		return nil, err
 	}
+	if err := u.translationUsecase.LocalizeCategories(ctx, categories); err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories, nil), nil
+}
+
+// buildCategoryTree nests the children of parentID, keeping their order.
+func buildCategoryTree(categories []entity.Category, parentID *uuid.UUID) []entity.Category {
+	nodes := make([]entity.Category, 0)
+	for _, category := range categories {
+		if (parentID == nil) != (category.ParentID == nil) {
+			continue
+		}
+		if parentID != nil && *category.ParentID != *parentID {
+			continue
+		}
+		category.Children = buildCategoryTree(categories, &category.ID)
+		nodes = append(nodes, category)
+	}
+	return nodes
+}
+
+func (u *categoryUsecase) UpdateCategory(ctx context.Context, id uuid.UUID, req *request.UpdateCategoryRequest) (*entity.Category, error) {
+	updates := make(map[string]interface{})
 	if req.Name != "" {
-		category.Name = req.Name
+		updates["name"] = req.Name
 	}
-	err = u.repo.Update(ctx, category)
-	return category, err
+	if req.MoveToRoot {
+		updates["parent_id"] = nil
+	} else if req.ParentID != nil {
+		if err := u.checkNewParent(ctx, id, *req.ParentID); err != nil {
+			return nil, err
+		}
+		updates["parent_id"] = req.ParentID
+	}
+	if req.SortOrder != nil {
+		updates["sort_order"] = *req.SortOrder
+	}
+	if req.Icon != nil {
+		updates["icon"] = *req.Icon
+	}
+	if req.Image != nil {
+		updates["image"] = *req.Image
+	}
+	if req.IsHidden != nil {
+		updates["is_hidden"] = *req.IsHidden
+	}
+
+	if len(updates) > 0 {
+		if err := u.repo.Update(ctx, id, updates); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+// checkNewParent makes sure the parent exists and is not the category itself
+// or one of its descendants.
+func (u *categoryUsecase) checkNewParent(ctx context.Context, id, parentID uuid.UUID) error {
+	if _, err := u.repo.GetByID(ctx, parentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrParentCategoryNotFound
+		}
+		return err
+	}
+
+	cycle, err := u.repo.IsDescendant(ctx, parentID, id)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return utils.ErrCategoryCycle
+	}
+	return nil
 }
 
-func (u *categoryUsecase) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	return u.repo.Delete(ctx, id)
+// ReorderCategories puts the siblings in the order given and returns them.
+func (u *categoryUsecase) ReorderCategories(ctx context.Context, req *request.ReorderCategoriesRequest) ([]entity.Category, error) {
+	seen := make(map[uuid.UUID]bool, len(req.CategoryIDs))
+	for _, id := range req.CategoryIDs {
+		if seen[id] {
+			return nil, utils.ErrCategoryNotSibling
+		}
+		seen[id] = true
+	}
+
+	if err := u.repo.Reorder(ctx, req.ParentID, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+
+	categories, err := u.repo.GetTree(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	siblings := make([]entity.Category, 0, len(req.CategoryIDs))
+	for _, category := range categories {
+		if seen[category.ID] {
+			siblings = append(siblings, category)
+		}
+	}
+	return siblings, nil
+}
+
+func (u *categoryUsecase) GetDeleteImpact(ctx context.Context, id uuid.UUID) (*DeleteImpact, error) {
+	impact, err := u.deleteImpact(ctx, id)
+	return impact, err
+}
+
+// DeleteCategory moves the category to the trash. By default it refuses
+// while the category has services or upcoming bookings and returns what is
+// in the way; reassign first moves the services to opts.ReassignTo, and
+// cancel trashes the services too, cancelling their bookings.
+// Subcategories always have to be moved or deleted first.
+func (u *categoryUsecase) DeleteCategory(ctx context.Context, id uuid.UUID, opts *params.DeleteQueryParams) (*DeleteImpact, error) {
+	strategy, err := deleteStrategy(opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	impact, err := u.deleteImpact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if impact.Subcategories > 0 {
+		return impact, utils.ErrCategoryHasChildren
+	}
+
+	switch strategy {
+	case DeleteStrategyReassign:
+		targetID, err := uuid.Parse(opts.ReassignTo)
+		if err != nil || targetID == id {
+			return nil, utils.ErrReassignTargetInvalid
+		}
+		if _, err := u.repo.GetByID(ctx, targetID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, utils.ErrReassignTargetInvalid
+			}
+			return nil, err
+		}
+		return nil, u.repo.DeleteReassigning(ctx, id, targetID)
+
+	case DeleteStrategyCancel:
+		refundFor, err := u.safeguardUsecase.RefundFor(ctx, strategy)
+		if err != nil {
+			return nil, err
+		}
+		cancelled, err := u.repo.DeleteWithServices(ctx, id, refundFor)
+		if err != nil {
+			return nil, err
+		}
+		u.safeguardUsecase.NotifyCancelled(ctx, cancelled)
+		return nil, nil
+	}
+
+	if impact.Blocked() {
+		return impact, utils.ErrDeleteBlocked
+	}
+	return nil, u.repo.Delete(ctx, id)
+}
+
+// deleteImpact counts the subcategories, services and upcoming bookings of
+// the category.
+func (u *categoryUsecase) deleteImpact(ctx context.Context, id uuid.UUID) (*DeleteImpact, error) {
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	children, err := u.repo.CountChildren(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	serviceIDs, err := u.repo.GetServiceIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := u.safeguardUsecase.GetServiceBookings(ctx, serviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteImpact{
+		Services:       len(serviceIDs),
+		Subcategories:  int(children),
+		FutureBookings: len(bookings),
+	}, nil
+}
+
+func (u *categoryUsecase) GetDeletedCategories(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Category, *transport.PaginationResponse, error) {
+	return u.repo.GetDeleted(ctx, filter)
+}
+
+func (u *categoryUsecase) RestoreCategory(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	if err := u.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.repo.GetByID(ctx, id)
 }
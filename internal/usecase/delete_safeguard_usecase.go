@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// Delete strategies for categories, services and branches that other
+// records still depend on.
+const (
+	DeleteStrategyBlock    = "block"
+	DeleteStrategyReassign = "reassign"
+	DeleteStrategyCancel   = "cancel"
+)
+
+// DeleteImpact counts what a delete would affect. Subcategories always block
+// a category delete; the rest are handled by the chosen strategy.
+type DeleteImpact struct {
+	Services       int `json:"services"`
+	Subcategories  int `json:"subcategories"`
+	FutureBookings int `json:"future_bookings"`
+}
+
+// Blocked reports whether the block strategy refuses the delete.
+func (i *DeleteImpact) Blocked() bool {
+	return i.Services > 0 || i.Subcategories > 0 || i.FutureBookings > 0
+}
+
+// DeleteSafeguardUsecase finds the upcoming bookings a delete would strand.
+// The delete cancels them on behalf of the salon in its own transaction,
+// with the refunds worked out by RefundFor, and the customers are told
+// afterwards. By then the delete has committed, so a notice that fails is
+// logged rather than failing it.
+type DeleteSafeguardUsecase interface {
+	GetServiceBookings(ctx context.Context, serviceIDs []uuid.UUID) ([]entity.Booking, error)
+	GetBranchBookings(ctx context.Context, branchID uuid.UUID) ([]entity.Booking, error)
+	RefundFor(ctx context.Context, strategy string) (repository.RefundFor, error)
+	NotifyCancelled(ctx context.Context, bookings []entity.Booking)
+}
+
+type deleteSafeguardUsecase struct {
+	bookingRepo         repository.BookingRepository
+	refundUsecase       RefundUsecase
+	notificationUsecase NotificationUsecase
+}
+
+func NewDeleteSafeguardUsecase(bookingRepo repository.BookingRepository, refundUsecase RefundUsecase,
+	notificationUsecase NotificationUsecase) DeleteSafeguardUsecase {
+	return &deleteSafeguardUsecase{
+		bookingRepo:         bookingRepo,
+		refundUsecase:       refundUsecase,
+		notificationUsecase: notificationUsecase,
+	}
+}
+
+// GetServiceBookings returns the open bookings for the services that are
+// still ahead.
+func (u *deleteSafeguardUsecase) GetServiceBookings(ctx context.Context, serviceIDs []uuid.UUID) ([]entity.Booking, error) {
+	return u.bookingRepo.GetOpenByServiceIDs(ctx, serviceIDs)
+}
+
+// GetBranchBookings returns the open bookings at the branch that are still
+// ahead.
+func (u *deleteSafeguardUsecase) GetBranchBookings(ctx context.Context, branchID uuid.UUID) ([]entity.Booking, error) {
+	return u.bookingRepo.GetOpenByBranchID(ctx, branchID)
+}
+
+// RefundFor works out the refunds of the bookings a delete under strategy
+// cancels. It is nil for block, which makes the delete refuse if bookings
+// were made after the impact was checked.
+func (u *deleteSafeguardUsecase) RefundFor(ctx context.Context, strategy string) (repository.RefundFor, error) {
+	if strategy != DeleteStrategyCancel {
+		return nil, nil
+	}
+	return u.refundUsecase.RefundFor(ctx)
+}
+
+// NotifyCancelled lets the customer of each cancelled booking know. A
+// failed notice is logged and the rest are still sent.
+func (u *deleteSafeguardUsecase) NotifyCancelled(ctx context.Context, bookings []entity.Booking) {
+	for i := range bookings {
+		if err := u.notificationUsecase.NotifyBookingCancelled(ctx, &bookings[i]); err != nil {
+			log.WithError(err).Errorf("notifying the cancellation of booking %s failed", bookings[i].ID)
+		}
+	}
+}
+
+// deleteStrategy reads the strategy chosen for a delete, block if none was.
+// Only categories have services to reassign.
+func deleteStrategy(opts *params.DeleteQueryParams, canReassign bool) (string, error) {
+	if opts == nil || opts.Strategy == "" {
+		return DeleteStrategyBlock, nil
+	}
+	switch opts.Strategy {
+	case DeleteStrategyBlock, DeleteStrategyCancel:
+		return opts.Strategy, nil
+	case DeleteStrategyReassign:
+		if !canReassign {
+			return "", utils.ErrReassignNotAllowed
+		}
+		return opts.Strategy, nil
+	}
+	return "", utils.ErrInvalidDeleteStrategy
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// FavoriteUsecase manages the services and branches a customer saved.
+type FavoriteUsecase interface {
+	GetFavorites(ctx context.Context, userID string) (*entity.Favorites, error)
+	AddFavoriteService(ctx context.Context, userID string, serviceID uuid.UUID) error
+	RemoveFavoriteService(ctx context.Context, userID string, serviceID uuid.UUID) error
+	AddFavoriteBranch(ctx context.Context, userID string, branchID uuid.UUID) error
+	RemoveFavoriteBranch(ctx context.Context, userID string, branchID uuid.UUID) error
+}
+
+type favoriteUsecase struct {
+	repo               repository.FavoriteRepository
+	translationUsecase TranslationUsecase
+}
+
+func NewFavoriteUsecase(repo repository.FavoriteRepository, translationUsecase TranslationUsecase) FavoriteUsecase {
+	return &favoriteUsecase{repo: repo, translationUsecase: translationUsecase}
+}
+
+func (u *favoriteUsecase) GetFavorites(ctx context.Context, userID string) (*entity.Favorites, error) {
+	services, err := u.repo.GetServices(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	branches, err := u.repo.GetBranches(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.translationUsecase.LocalizeServices(ctx, services); err != nil {
+		return nil, err
+	}
+	if err := u.translationUsecase.LocalizeBranches(ctx, branches); err != nil {
+		return nil, err
+	}
+	return &entity.Favorites{Services: services, Branches: branches}, nil
+}
+
+func (u *favoriteUsecase) AddFavoriteService(ctx context.Context, userID string, serviceID uuid.UUID) error {
+	return u.repo.AddService(ctx, userID, serviceID)
+}
+
+func (u *favoriteUsecase) RemoveFavoriteService(ctx context.Context, userID string, serviceID uuid.UUID) error {
+	return u.repo.RemoveService(ctx, userID, serviceID)
+}
+
+func (u *favoriteUsecase) AddFavoriteBranch(ctx context.Context, userID string, branchID uuid.UUID) error {
+	return u.repo.AddBranch(ctx, userID, branchID)
+}
+
+func (u *favoriteUsecase) RemoveFavoriteBranch(ctx context.Context, userID string, branchID uuid.UUID) error {
+	return u.repo.RemoveBranch(ctx, userID, branchID)
+}
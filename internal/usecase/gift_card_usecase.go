@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/money"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GiftCardUsecase interface {
+	IssueGiftCard(ctx context.Context, req *request.CreateGiftCardRequest) (*entity.GiftCard, error)
+	GetGiftCardByID(ctx context.Context, id uuid.UUID) (*entity.GiftCard, error)
+	GetGiftCardByCode(ctx context.Context, code string) (*entity.GiftCard, error)
+	GetAllGiftCards(ctx context.Context, filter *params.GiftCardQueryParams) ([]entity.GiftCard, *transport.PaginationResponse, error)
+	UpdateGiftCard(ctx context.Context, id uuid.UUID, req *request.UpdateGiftCardRequest) (*entity.GiftCard, error)
+	ExpireGiftCards(ctx context.Context) (int, error)
+}
+
+type giftCardUsecase struct {
+	repo repository.GiftCardRepository
+}
+
+func NewGiftCardUsecase(repo repository.GiftCardRepository) GiftCardUsecase {
+	return &giftCardUsecase{repo: repo}
+}
+
+// giftCardCodeAttempts bounds the retries when a generated code is taken.
+const giftCardCodeAttempts = 5
+
+func (u *giftCardUsecase) IssueGiftCard(ctx context.Context, req *request.CreateGiftCardRequest) (*entity.GiftCard, error) {
+	for attempt := 0; attempt < giftCardCodeAttempts; attempt++ {
+		code, err := utils.GenerateCode(3)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := u.repo.GetByCode(ctx, code); err == nil {
+			continue
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		giftCard := &entity.GiftCard{
+			ID:              uuid.New(),
+			Code:            code,
+			InitialBalance:  req.InitialBalance,
+			Balance:         req.InitialBalance,
+			Currency:        money.NormalizeCurrency(req.Currency),
+			PurchaserUserID: req.PurchaserUserID,
+			RecipientName:   req.RecipientName,
+			ExpiresAt:       req.ExpiresAt,
+			IsActive:        true,
+		}
+		if err := u.repo.Create(ctx, giftCard); err != nil {
+			return nil, err
+		}
+		return giftCard, nil
+	}
+
+	return nil, utils.ErrDuplicateEntry
+}
+
+func (u *giftCardUsecase) GetGiftCardByID(ctx context.Context, id uuid.UUID) (*entity.GiftCard, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *giftCardUsecase) GetGiftCardByCode(ctx context.Context, code string) (*entity.GiftCard, error) {
+	return u.repo.GetByCode(ctx, code)
+}
+
+func (u *giftCardUsecase) GetAllGiftCards(ctx context.Context, filter *params.GiftCardQueryParams) ([]entity.GiftCard, *transport.PaginationResponse, error) {
+	return u.repo.GetAll(ctx, filter)
+}
+
+func (u *giftCardUsecase) UpdateGiftCard(ctx context.Context, id uuid.UUID, req *request.UpdateGiftCardRequest) (*entity.GiftCard, error) {
+	updates := make(map[string]interface{})
+	if req.RecipientName != nil {
+		updates["recipient_name"] = *req.RecipientName
+	}
+	if req.ExpiresAt != nil {
+		updates["expires_at"] = *req.ExpiresAt
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := u.repo.Update(ctx, id, updates); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *giftCardUsecase) ExpireGiftCards(ctx context.Context) (int, error) {
+	return u.repo.ExpireGiftCards(ctx, time.Now())
+}
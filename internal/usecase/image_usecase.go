@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/config"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/internal/storage"
+	"KaungHtetHein116/IVY-backend/pkg/thumbnail"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ImageUsecase interface {
+	Upload(ctx context.Context, data []byte) (*entity.Image, error)
+	Remove(ctx context.Context, id uuid.UUID) error
+	CleanupOrphans(ctx context.Context) (int, error)
+}
+
+type imageUsecase struct {
+	repo  repository.ImageRepository
+	store storage.Storage
+}
+
+func NewImageUsecase(repo repository.ImageRepository, store storage.Storage) ImageUsecase {
+	return &imageUsecase{repo: repo, store: store}
+}
+
+// Upload checks the file is a JPEG, PNG or GIF within the size limit, stores
+// it with its thumbnails and records it. Nothing is left in storage when any
+// step fails.
+func (u *imageUsecase) Upload(ctx context.Context, data []byte) (*entity.Image, error) {
+	if int64(len(data)) > config.UploadMaxBytes() {
+		return nil, utils.ErrImageTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := thumbnail.ContentTypes[contentType]
+	if !ok {
+		return nil, utils.ErrUnsupportedImageType
+	}
+
+	img, format, err := thumbnail.Decode(data)
+	if err != nil {
+		if errors.Is(err, thumbnail.ErrTooManyPixels) {
+			return nil, utils.ErrImageTooLarge
+		}
+		return nil, utils.ErrUnsupportedImageType
+	}
+
+	id := uuid.New()
+	bounds := img.Bounds()
+	image := &entity.Image{
+		ID:          id,
+		Key:         fmt.Sprintf("images/%s/original.%s", id, ext),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+	}
+	image.URL = u.store.URL(image.Key)
+
+	stored := make([]string, 0, len(thumbnail.Sizes)+1)
+	put := func(key string, body []byte, contentType string) error {
+		if err := u.store.Put(ctx, key, body, contentType); err != nil {
+			return err
+		}
+		stored = append(stored, key)
+		return nil
+	}
+	fail := func(err error) (*entity.Image, error) {
+		u.deleteObjects(context.WithoutCancel(ctx), stored)
+		return nil, err
+	}
+
+	if err := put(image.Key, data, contentType); err != nil {
+		return fail(err)
+	}
+
+	for _, size := range thumbnail.Sizes {
+		scaled := thumbnail.Resize(img, size.Width)
+		body, thumbType, err := thumbnail.Encode(scaled, format)
+		if err != nil {
+			return fail(err)
+		}
+		key := fmt.Sprintf("images/%s/%s.%s", id, size.Name, thumbnail.ContentTypes[thumbType])
+		if err := put(key, body, thumbType); err != nil {
+			return fail(err)
+		}
+		image.Thumbnails = append(image.Thumbnails, entity.ImageThumbnail{
+			ID:      uuid.New(),
+			ImageID: id,
+			Name:    size.Name,
+			Key:     key,
+			URL:     u.store.URL(key),
+			Width:   scaled.Bounds().Dx(),
+			Height:  scaled.Bounds().Dy(),
+		})
+	}
+
+	if err := u.repo.Create(ctx, image); err != nil {
+		return fail(err)
+	}
+	return image, nil
+}
+
+// Remove deletes the image and its thumbnails from storage and the database.
+func (u *imageUsecase) Remove(ctx context.Context, id uuid.UUID) error {
+	image, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if err := u.deleteObjects(ctx, image.Keys()); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, id)
+}
+
+// CleanupOrphans removes images nothing has pointed at for the grace period
+// and returns how many were removed.
+func (u *imageUsecase) CleanupOrphans(ctx context.Context) (int, error) {
+	images, err := u.repo.GetOrphans(ctx, time.Now().Add(-config.OrphanImageGrace()), 100)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, image := range images {
+		if err := u.deleteObjects(ctx, image.Keys()); err != nil {
+			return removed, err
+		}
+		if err := u.repo.Delete(ctx, image.ID); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// deleteObjects deletes every key, carrying on past failures, and returns
+// the first error.
+func (u *imageUsecase) deleteObjects(ctx context.Context, keys []string) error {
+	var first error
+	for _, key := range keys {
+		if err := u.store.Delete(ctx, key); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+)
+
+type memoryImageRepository struct {
+	repository.ImageRepository
+	images []*entity.Image
+}
+
+func (r *memoryImageRepository) Create(ctx context.Context, image *entity.Image) error {
+	r.images = append(r.images, image)
+	return nil
+}
+
+// memoryStorage keeps objects in a map and fails the put numbered failPut.
+type memoryStorage struct {
+	objects map[string]string
+	puts    int
+	failPut int
+}
+
+func (s *memoryStorage) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	s.puts++
+	if s.puts == s.failPut {
+		return errors.New("storage unavailable")
+	}
+	s.objects[key] = contentType
+	return nil
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) error {
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *memoryStorage) URL(key string) string {
+	return "/uploads/" + key
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadThumbnails(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		sizes         [][2]int
+	}{
+		{"wide image", 2000, 1000, [][2]int{{160, 80}, {480, 240}, {1024, 512}}},
+		{"narrower than medium", 300, 600, [][2]int{{160, 320}, {300, 600}, {300, 600}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryImageRepository{}
+			store := &memoryStorage{objects: map[string]string{}}
+			u := NewImageUsecase(repo, store)
+
+			img, err := u.Upload(context.Background(), testPNG(t, tt.width, tt.height))
+			if err != nil {
+				t.Fatalf("Upload: %v", err)
+			}
+			if img.Width != tt.width || img.Height != tt.height || img.ContentType != "image/png" {
+				t.Errorf("image = %dx%d %s, want %dx%d image/png", img.Width, img.Height, img.ContentType, tt.width, tt.height)
+			}
+			if len(img.Thumbnails) != len(tt.sizes) {
+				t.Fatalf("got %d thumbnails, want %d", len(img.Thumbnails), len(tt.sizes))
+			}
+			for i, thumb := range img.Thumbnails {
+				if thumb.Width != tt.sizes[i][0] || thumb.Height != tt.sizes[i][1] {
+					t.Errorf("%s thumbnail = %dx%d, want %dx%d", thumb.Name, thumb.Width, thumb.Height, tt.sizes[i][0], tt.sizes[i][1])
+				}
+				if store.objects[thumb.Key] != "image/png" {
+					t.Errorf("%s thumbnail was not stored as a PNG", thumb.Name)
+				}
+			}
+			if len(store.objects) != len(tt.sizes)+1 || len(repo.images) != 1 {
+				t.Errorf("stored %d objects and %d images, want %d and 1", len(store.objects), len(repo.images), len(tt.sizes)+1)
+			}
+		})
+	}
+}
+
+func TestUploadRejected(t *testing.T) {
+	t.Setenv("UPLOAD_MAX_BYTES", "1024")
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"over the size limit", bytes.Repeat([]byte{0}, 1025), utils.ErrImageTooLarge},
+		{"plain text", []byte("not an image"), utils.ErrUnsupportedImageType},
+		{"svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), utils.ErrUnsupportedImageType},
+		{"truncated png", testPNG(t, 10, 10)[:40], utils.ErrUnsupportedImageType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryImageRepository{}
+			store := &memoryStorage{objects: map[string]string{}}
+			u := NewImageUsecase(repo, store)
+
+			if _, err := u.Upload(context.Background(), tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("Upload error = %v, want %v", err, tt.want)
+			}
+			if len(store.objects) != 0 || len(repo.images) != 0 {
+				t.Errorf("a rejected upload was stored")
+			}
+		})
+	}
+}
+
+func TestUploadStorageFailure(t *testing.T) {
+	repo := &memoryImageRepository{}
+	store := &memoryStorage{objects: map[string]string{}, failPut: 3}
+	u := NewImageUsecase(repo, store)
+
+	if _, err := u.Upload(context.Background(), testPNG(t, 500, 500)); err == nil {
+		t.Fatal("Upload succeeded with a failing storage")
+	}
+	if len(store.objects) != 0 || len(repo.images) != 0 {
+		t.Errorf("left %d objects and %d images behind", len(store.objects), len(repo.images))
+	}
+}
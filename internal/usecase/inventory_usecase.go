@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/money"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+type InventoryUsecase interface {
+	CreateProduct(ctx context.Context, req *request.CreateProductRequest) (*entity.Product, error)
+	GetProductByID(ctx context.Context, id uuid.UUID) (*entity.Product, error)
+	GetAllProducts(ctx context.Context, filter *params.ProductQueryParams) ([]entity.Product, *transport.PaginationResponse, error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, req *request.UpdateProductRequest) (*entity.Product, error)
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	GetBranchStock(ctx context.Context, branchID uuid.UUID) ([]entity.BranchStock, error)
+	SetLowStockLevel(ctx context.Context, branchID, productID uuid.UUID, req *request.SetLowStockLevelRequest) (*entity.BranchStock, error)
+	GetLowStockReport(ctx context.Context, branchID *uuid.UUID) ([]LowStockReport, error)
+	RecordMovement(ctx context.Context, adminID string, req *request.RecordStockMovementRequest) (*entity.StockMovement, error)
+	GetMovements(ctx context.Context, filter *params.StockMovementQueryParams) ([]entity.StockMovement, *transport.PaginationResponse, error)
+	GetServiceConsumables(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceConsumable, error)
+	SetServiceConsumables(ctx context.Context, serviceID uuid.UUID, req *request.SetServiceConsumablesRequest) ([]entity.ServiceConsumable, error)
+	ConsumeStock(ctx context.Context, booking *entity.Booking) error
+}
+
+// LowStockReport lists the products a branch is running low on, lowest
+// against their level first.
+type LowStockReport struct {
+	BranchID   uuid.UUID            `json:"branch_id"`
+	BranchName string               `json:"branch_name"`
+	Items      []entity.BranchStock `json:"items"`
+}
+
+type inventoryUsecase struct {
+	repo repository.InventoryRepository
+}
+
+func NewInventoryUsecase(repo repository.InventoryRepository) InventoryUsecase {
+	return &inventoryUsecase{repo: repo}
+}
+
+func (u *inventoryUsecase) CreateProduct(ctx context.Context, req *request.CreateProductRequest) (*entity.Product, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	unit := req.Unit
+	if unit == "" {
+		unit = "piece"
+	}
+
+	product := &entity.Product{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		SKU:         req.SKU,
+		Description: req.Description,
+		Unit:        unit,
+		Price:       req.Price,
+		Currency:    money.NormalizeCurrency(req.Currency),
+		IsRetail:    req.IsRetail,
+		IsActive:    isActive,
+	}
+	if err := u.repo.CreateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetProductByID(ctx, product.ID)
+}
+
+func (u *inventoryUsecase) GetProductByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	return u.repo.GetProductByID(ctx, id)
+}
+
+func (u *inventoryUsecase) GetAllProducts(ctx context.Context, filter *params.ProductQueryParams) ([]entity.Product, *transport.PaginationResponse, error) {
+	return u.repo.GetProducts(ctx, filter)
+}
+
+func (u *inventoryUsecase) UpdateProduct(ctx context.Context, id uuid.UUID, req *request.UpdateProductRequest) (*entity.Product, error) {
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.SKU != nil {
+		updates["sku"] = *req.SKU
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Unit != nil {
+		updates["unit"] = *req.Unit
+	}
+	if req.Price != nil {
+		updates["price"] = *req.Price
+	}
+	if req.Currency != nil {
+		updates["currency"] = money.NormalizeCurrency(*req.Currency)
+	}
+	if req.IsRetail != nil {
+		updates["is_retail"] = *req.IsRetail
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := u.repo.UpdateProduct(ctx, id, updates); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.repo.GetProductByID(ctx, id)
+}
+
+func (u *inventoryUsecase) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	return u.repo.DeleteProduct(ctx, id)
+}
+
+func (u *inventoryUsecase) GetBranchStock(ctx context.Context, branchID uuid.UUID) ([]entity.BranchStock, error) {
+	return u.repo.GetBranchStock(ctx, branchID)
+}
+
+func (u *inventoryUsecase) SetLowStockLevel(ctx context.Context, branchID, productID uuid.UUID, req *request.SetLowStockLevelRequest) (*entity.BranchStock, error) {
+	return u.repo.SetLowStockLevel(ctx, branchID, productID, req.LowStockLevel)
+}
+
+func (u *inventoryUsecase) GetLowStockReport(ctx context.Context, branchID *uuid.UUID) ([]LowStockReport, error) {
+	stock, err := u.repo.GetLowStock(ctx, branchID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []LowStockReport{}
+	for _, item := range stock {
+		if len(reports) == 0 || reports[len(reports)-1].BranchID != item.BranchID {
+			report := LowStockReport{BranchID: item.BranchID}
+			if item.Branch != nil {
+				report.BranchName = item.Branch.Name
+			}
+			reports = append(reports, report)
+		}
+		item.Branch = nil
+		last := &reports[len(reports)-1]
+		last.Items = append(last.Items, item)
+	}
+	return reports, nil
+}
+
+// RecordMovement books stock in or out of a branch by hand. Restocks add the
+// quantity, sales take it away and adjustments apply it as signed.
+func (u *inventoryUsecase) RecordMovement(ctx context.Context, adminID string, req *request.RecordStockMovementRequest) (*entity.StockMovement, error) {
+	quantity := req.Quantity
+	switch req.MovementType {
+	case entity.StockMovementRestock:
+		if quantity <= 0 {
+			return nil, utils.ErrInvalidStockChange
+		}
+	case entity.StockMovementSale:
+		if quantity <= 0 {
+			return nil, utils.ErrInvalidStockChange
+		}
+		quantity = -quantity
+	default:
+		if quantity == 0 {
+			return nil, utils.ErrInvalidStockChange
+		}
+	}
+
+	movement := &entity.StockMovement{
+		ID:           uuid.New(),
+		BranchID:     req.BranchID,
+		ProductID:    req.ProductID,
+		MovementType: req.MovementType,
+		Quantity:     quantity,
+		Note:         req.Note,
+		CreatedBy:    &adminID,
+	}
+	if err := u.repo.RecordMovement(ctx, movement); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+func (u *inventoryUsecase) GetMovements(ctx context.Context, filter *params.StockMovementQueryParams) ([]entity.StockMovement, *transport.PaginationResponse, error) {
+	return u.repo.GetMovements(ctx, filter)
+}
+
+func (u *inventoryUsecase) GetServiceConsumables(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceConsumable, error) {
+	return u.repo.GetConsumables(ctx, serviceID)
+}
+
+// SetServiceConsumables replaces the bill of consumables of the service. A
+// product listed twice uses the sum of both quantities.
+func (u *inventoryUsecase) SetServiceConsumables(ctx context.Context, serviceID uuid.UUID, req *request.SetServiceConsumablesRequest) ([]entity.ServiceConsumable, error) {
+	consumables := []entity.ServiceConsumable{}
+	index := make(map[uuid.UUID]int)
+	for _, item := range req.Consumables {
+		if i, ok := index[item.ProductID]; ok {
+			consumables[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.ProductID] = len(consumables)
+		consumables = append(consumables, entity.ServiceConsumable{
+			ServiceID: serviceID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	if err := u.repo.SetConsumables(ctx, serviceID, consumables); err != nil {
+		return nil, err
+	}
+	return u.repo.GetConsumables(ctx, serviceID)
+}
+
+// ConsumeStock deducts what a completed booking's service used from the
+// stock of the branch it was done at.
+func (u *inventoryUsecase) ConsumeStock(ctx context.Context, booking *entity.Booking) error {
+	return u.repo.ConsumeForBooking(ctx, booking)
+}
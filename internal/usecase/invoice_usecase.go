@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/money"
+	"KaungHtetHein116/IVY-backend/pkg/pdf"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type InvoiceUsecase interface {
+	IssueInvoice(ctx context.Context, bookingID uuid.UUID) (*entity.Invoice, error)
+	GetInvoice(ctx context.Context, bookingID uuid.UUID) (*entity.Invoice, error)
+	RenderPDF(invoice *entity.Invoice) []byte
+}
+
+type invoiceUsecase struct {
+	repo        repository.InvoiceRepository
+	bookingRepo repository.BookingRepository
+	branchRepo  repository.BranchRepository
+	userRepo    repository.UserRepository
+}
+
+func NewInvoiceUsecase(repo repository.InvoiceRepository, bookingRepo repository.BookingRepository,
+	branchRepo repository.BranchRepository, userRepo repository.UserRepository) InvoiceUsecase {
+	return &invoiceUsecase{
+		repo:        repo,
+		bookingRepo: bookingRepo,
+		branchRepo:  branchRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// IssueInvoice creates the invoice of a completed or paid booking. A booking
+// is only ever invoiced once; issuing again returns the existing invoice.
+// The booking is looked up first so that callers only get the invoices of
+// bookings in their scope.
+func (u *invoiceUsecase) IssueInvoice(ctx context.Context, bookingID uuid.UUID) (*entity.Invoice, error) {
+	booking, err := u.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := u.repo.GetByBookingID(ctx, bookingID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if booking.Status != "COMPLETED" && booking.PaymentStatus != "PAID" {
+		return nil, utils.ErrInvoiceNotAvailable
+	}
+
+	branch, err := u.branchRepo.GetByIDWithDeleted(ctx, booking.BranchID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.userRepo.GetUserByID(ctx, booking.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := &entity.Invoice{
+		ID:             uuid.New(),
+		BranchID:       branch.ID,
+		BookingID:      booking.ID,
+		UserID:         booking.UserID,
+		BranchName:     branch.Name,
+		BranchLocation: branch.Location,
+		BranchPhone:    branch.PhoneNumber,
+		CustomerName:   strings.TrimSpace(user.FirstName + " " + user.LastName),
+		CustomerEmail:  user.Email,
+		Currency:       booking.Currency,
+		Subtotal:       booking.ServicePrice,
+		DiscountAmount: booking.MemberDiscount + booking.DiscountAmount + booking.PointsDiscount,
+		TaxAmount:      booking.TaxAmount,
+		TaxRate:        booking.TaxRate,
+		TaxInclusive:   booking.TaxInclusive,
+		Total:          booking.TotalPrice,
+		GiftCardAmount: booking.GiftCardAmount,
+		AmountPaid:     booking.AmountPaid,
+		AmountDue:      max(booking.AmountDue-booking.AmountPaid, 0),
+		IssuedAt:       time.Now(),
+		Lines:          invoiceLines(booking),
+	}
+	// A prepaid session leaves nothing due on the booking's total
+	if booking.PackageCreditID != nil || booking.SubscriptionUsageID != nil {
+		invoice.PrepaidAmount = max(booking.TotalPrice-booking.GiftCardAmount-booking.AmountDue, 0)
+		invoice.PrepaidBy = entity.InvoicePrepaidPackage
+		if booking.SubscriptionUsageID != nil {
+			invoice.PrepaidBy = entity.InvoicePrepaidMembership
+		}
+	}
+
+	if err := u.repo.Create(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// GetInvoice returns the invoice already issued for the booking. Reading an
+// invoice never issues one, so numbers are only used up by bookings that
+// are completed or paid. The booking is looked up first so that callers
+// only get the invoices of bookings in their scope.
+func (u *invoiceUsecase) GetInvoice(ctx context.Context, bookingID uuid.UUID) (*entity.Invoice, error) {
+	if _, err := u.bookingRepo.GetByID(ctx, bookingID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetByBookingID(ctx, bookingID)
+}
+
+// invoiceLines bills the service and add-ons as they were when the booking
+// was made.
+func invoiceLines(booking *entity.Booking) []entity.InvoiceLine {
+	description := booking.ServiceName
+	if booking.VariantName != "" {
+		description = fmt.Sprintf("%s (%s)", booking.ServiceName, booking.VariantName)
+	}
+	lines := []entity.InvoiceLine{{
+		LineType:    entity.InvoiceLineService,
+		Description: description,
+		Quantity:    1,
+		UnitPrice:   booking.ServicePrice - booking.AddOnsPrice,
+		Amount:      booking.ServicePrice - booking.AddOnsPrice,
+	}}
+
+	for _, addOn := range booking.AddOns {
+		lines = append(lines, entity.InvoiceLine{
+			LineType:    entity.InvoiceLineService,
+			Description: addOn.Name,
+			Quantity:    1,
+			UnitPrice:   addOn.Price,
+			Amount:      addOn.Price,
+		})
+	}
+
+	if booking.MemberDiscount > 0 {
+		lines = append(lines, entity.InvoiceLine{
+			LineType:    entity.InvoiceLineDiscount,
+			Description: "Member discount",
+			Quantity:    1,
+			UnitPrice:   -booking.MemberDiscount,
+			Amount:      -booking.MemberDiscount,
+		})
+	}
+
+	if booking.DiscountAmount > 0 {
+		description := "Promotion"
+		if booking.PromoCode != nil {
+			description = fmt.Sprintf("Promotion %s", *booking.PromoCode)
+		}
+		lines = append(lines, entity.InvoiceLine{
+			LineType:    entity.InvoiceLineDiscount,
+			Description: description,
+			Quantity:    1,
+			UnitPrice:   -booking.DiscountAmount,
+			Amount:      -booking.DiscountAmount,
+		})
+	}
+
+	if booking.PointsDiscount > 0 {
+		lines = append(lines, entity.InvoiceLine{
+			LineType:    entity.InvoiceLineDiscount,
+			Description: fmt.Sprintf("Loyalty points (%d)", booking.PointsRedeemed),
+			Quantity:    1,
+			UnitPrice:   -booking.PointsDiscount,
+			Amount:      -booking.PointsDiscount,
+		})
+	}
+
+	for i := range lines {
+		lines[i].Position = i + 1
+	}
+	return lines
+}
+
+// RenderPDF lays the invoice out on A4 pages.
+func (u *invoiceUsecase) RenderPDF(invoice *entity.Invoice) []byte {
+	const (
+		left       = 50.0
+		right      = pdf.PageWidth - 50
+		bottom     = 80.0
+		lineHeight = 16.0
+	)
+	format := func(amount int64) string {
+		return money.Format(amount, invoice.Currency)
+	}
+
+	doc := pdf.New()
+	page := doc.AddPage()
+	y := pdf.PageHeight - 60
+
+	page.Text(left, y, pdf.Bold, 18, invoice.BranchName)
+	page.Text(right-120, y, pdf.Bold, 18, "INVOICE")
+	y -= 20
+	page.Text(left, y, pdf.Regular, 10, invoice.BranchLocation)
+	page.Text(right-120, y, pdf.Regular, 10, invoice.Number)
+	y -= 14
+	if invoice.BranchPhone != "" {
+		page.Text(left, y, pdf.Regular, 10, "Tel: "+invoice.BranchPhone)
+	}
+	page.Text(right-120, y, pdf.Regular, 10, invoice.IssuedAt.Format("02 Jan 2006"))
+
+	y -= 36
+	page.Text(left, y, pdf.Bold, 10, "Billed to")
+	y -= 14
+	page.Text(left, y, pdf.Regular, 10, invoice.CustomerName)
+	y -= 14
+	page.Text(left, y, pdf.Regular, 10, invoice.CustomerEmail)
+
+	y -= 30
+	page.Text(left, y, pdf.Bold, 10, "Description")
+	page.Text(right-190, y, pdf.Bold, 10, "Qty")
+	page.Text(right-40, y, pdf.Bold, 10, "Amount")
+	y -= 6
+	page.Line(left, y, right, y)
+	y -= lineHeight
+
+	for _, line := range invoice.Lines {
+		if y < bottom {
+			page = doc.AddPage()
+			y = pdf.PageHeight - 60
+		}
+		page.Text(left, y, pdf.Regular, 10, line.Description)
+		page.TextRight(right-170, y, 10, fmt.Sprintf("%d", line.Quantity))
+		page.TextRight(right, y, 10, format(line.Amount))
+		y -= lineHeight
+	}
+
+	if y < bottom+9*lineHeight {
+		page = doc.AddPage()
+		y = pdf.PageHeight - 60
+	}
+	page.Line(left, y+lineHeight-6, right, y+lineHeight-6)
+
+	type totalRow struct {
+		label  string
+		amount int64
+		font   pdf.Font
+	}
+	totals := []totalRow{
+		{"Subtotal", invoice.Subtotal, pdf.Regular},
+		{"Discount", -invoice.DiscountAmount, pdf.Regular},
+	}
+	if invoice.TaxInclusive {
+		totals = append(totals, totalRow{"Total", invoice.Total, pdf.Bold},
+			totalRow{fmt.Sprintf("Includes tax %s", formatRate(invoice.TaxRate)), invoice.TaxAmount, pdf.Regular})
+	} else {
+		totals = append(totals, totalRow{fmt.Sprintf("Tax %s", formatRate(invoice.TaxRate)), invoice.TaxAmount, pdf.Regular},
+			totalRow{"Total", invoice.Total, pdf.Bold})
+	}
+	if invoice.GiftCardAmount > 0 {
+		totals = append(totals, totalRow{"Paid by gift card", -invoice.GiftCardAmount, pdf.Regular})
+	}
+	switch invoice.PrepaidBy {
+	case entity.InvoicePrepaidPackage:
+		totals = append(totals, totalRow{"Covered by package", -invoice.PrepaidAmount, pdf.Regular})
+	case entity.InvoicePrepaidMembership:
+		totals = append(totals, totalRow{"Included in membership", -invoice.PrepaidAmount, pdf.Regular})
+	}
+	if invoice.AmountPaid > 0 {
+		totals = append(totals, totalRow{"Amount paid", -invoice.AmountPaid, pdf.Regular})
+	}
+	totals = append(totals, totalRow{"Amount due", invoice.AmountDue, pdf.Bold})
+
+	for _, total := range totals {
+		page.Text(right-260, y, total.font, 10, total.label)
+		page.TextRight(right, y, 10, format(total.amount))
+		y -= lineHeight
+	}
+
+	page.Text(left, 50, pdf.Regular, 8, "Thank you for visiting IVY Hair Studio.")
+
+	return doc.Bytes()
+}
+
+// formatRate renders a basis-point rate as a percentage, e.g. 550 as "5.5%".
+func formatRate(rate int) string {
+	percent := strconv.FormatFloat(float64(rate)/100, 'f', -1, 64)
+	return percent + "%"
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type memoryInvoiceRepository struct {
+	invoices map[uuid.UUID]*entity.Invoice
+}
+
+func (r *memoryInvoiceRepository) Create(ctx context.Context, invoice *entity.Invoice) error {
+	invoice.Number = "INV-TEST"
+	r.invoices[invoice.BookingID] = invoice
+	return nil
+}
+
+func (r *memoryInvoiceRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) (*entity.Invoice, error) {
+	invoice, ok := r.invoices[bookingID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return invoice, nil
+}
+
+type memoryBookingRepository struct {
+	repository.BookingRepository
+	bookings map[uuid.UUID]*entity.Booking
+}
+
+func (r *memoryBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	booking, ok := r.bookings[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return booking, nil
+}
+
+type invoiceBranchRepository struct {
+	repository.BranchRepository
+}
+
+func (r *invoiceBranchRepository) GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entity.Branch, error) {
+	return &entity.Branch{ID: id, Name: "Downtown"}, nil
+}
+
+type invoiceUserRepository struct {
+	repository.UserRepository
+}
+
+func (r *invoiceUserRepository) GetUserByID(ctx context.Context, userID string) (*entity.User, error) {
+	return &entity.User{ID: userID, FirstName: "Aye", Email: "aye@example.com"}, nil
+}
+
+func invoiceFixture(bookings ...*entity.Booking) (InvoiceUsecase, *memoryInvoiceRepository) {
+	repo := &memoryInvoiceRepository{invoices: map[uuid.UUID]*entity.Invoice{}}
+	bookingRepo := &memoryBookingRepository{bookings: map[uuid.UUID]*entity.Booking{}}
+	for _, booking := range bookings {
+		bookingRepo.bookings[booking.ID] = booking
+	}
+	return NewInvoiceUsecase(repo, bookingRepo, &invoiceBranchRepository{}, &invoiceUserRepository{}), repo
+}
+
+func TestIssueInvoiceAmounts(t *testing.T) {
+	packageCredit, usage := uuid.New(), uuid.New()
+	tests := []struct {
+		name      string
+		booking   entity.Booking
+		paid      int64
+		due       int64
+		prepaid   int64
+		prepaidBy string
+	}{
+		{"paid at the counter", entity.Booking{TotalPrice: 20000, AmountDue: 20000, AmountPaid: 20000, PaymentStatus: "PAID"}, 20000, 0, 0, ""},
+		{"deposit paid", entity.Booking{TotalPrice: 20000, AmountDue: 20000, AmountPaid: 5000, Status: "COMPLETED"}, 5000, 15000, 0, ""},
+		{"partly by gift card", entity.Booking{TotalPrice: 20000, GiftCardAmount: 8000, AmountDue: 12000, Status: "COMPLETED"}, 0, 12000, 0, ""},
+		{"package credit", entity.Booking{TotalPrice: 20000, PackageCreditID: &packageCredit, Status: "COMPLETED"}, 0, 0, 20000, entity.InvoicePrepaidPackage},
+		{"membership session", entity.Booking{TotalPrice: 20000, SubscriptionUsageID: &usage, Status: "COMPLETED"}, 0, 0, 20000, entity.InvoicePrepaidMembership},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := tt.booking
+			booking.ID = uuid.New()
+			booking.BranchID = uuid.New()
+			u, _ := invoiceFixture(&booking)
+
+			invoice, err := u.IssueInvoice(context.Background(), booking.ID)
+			if err != nil {
+				t.Fatalf("IssueInvoice: %v", err)
+			}
+			if invoice.AmountPaid != tt.paid || invoice.AmountDue != tt.due {
+				t.Errorf("paid %d and due %d, want %d and %d", invoice.AmountPaid, invoice.AmountDue, tt.paid, tt.due)
+			}
+			if invoice.PrepaidAmount != tt.prepaid || invoice.PrepaidBy != tt.prepaidBy {
+				t.Errorf("prepaid %d by %q, want %d by %q", invoice.PrepaidAmount, invoice.PrepaidBy, tt.prepaid, tt.prepaidBy)
+			}
+		})
+	}
+}
+
+func TestGetInvoiceDoesNotIssue(t *testing.T) {
+	booking := &entity.Booking{ID: uuid.New(), BranchID: uuid.New(), TotalPrice: 20000, AmountDue: 20000, Status: "COMPLETED"}
+	u, repo := invoiceFixture(booking)
+
+	if _, err := u.GetInvoice(context.Background(), booking.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetInvoice error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+	if len(repo.invoices) != 0 {
+		t.Fatalf("reading the invoice issued one")
+	}
+
+	issued, err := u.IssueInvoice(context.Background(), booking.ID)
+	if err != nil {
+		t.Fatalf("IssueInvoice: %v", err)
+	}
+	if got, err := u.GetInvoice(context.Background(), booking.ID); err != nil || got != issued {
+		t.Errorf("GetInvoice = %v, %v; want the issued invoice", got, err)
+	}
+	if _, err := u.GetInvoice(context.Background(), uuid.New()); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetInvoice of an unknown booking error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+)
+
+type LedgerUsecase interface {
+	GetLedgerEntries(ctx context.Context, filter *params.LedgerQueryParams) ([]entity.LedgerEntry, *transport.PaginationResponse, error)
+}
+
+type ledgerUsecase struct {
+	repo repository.LedgerRepository
+}
+
+func NewLedgerUsecase(repo repository.LedgerRepository) LedgerUsecase {
+	return &ledgerUsecase{repo: repo}
+}
+
+func (u *ledgerUsecase) GetLedgerEntries(ctx context.Context, filter *params.LedgerQueryParams) ([]entity.LedgerEntry, *transport.PaginationResponse, error) {
+	return u.repo.GetAll(ctx, filter)
+}
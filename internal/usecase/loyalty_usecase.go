@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/config"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LoyaltyUsecase interface {
+	CreateRule(ctx context.Context, req *request.CreateLoyaltyRuleRequest) (*entity.LoyaltyRule, error)
+	GetRules(ctx context.Context) ([]entity.LoyaltyRule, error)
+	UpdateRule(ctx context.Context, id uuid.UUID, req *request.UpdateLoyaltyRuleRequest) (*entity.LoyaltyRule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	GetBalance(ctx context.Context, userID string) (*PointsBalance, error)
+	GetHistory(ctx context.Context, filter *params.PointsQueryParams) ([]entity.PointsEntry, *transport.PaginationResponse, error)
+	AdjustPoints(ctx context.Context, userID string, adminID string, req *request.AdjustPointsRequest) (*entity.PointsEntry, error)
+	AwardPoints(ctx context.Context, booking *entity.Booking, categoryID uuid.UUID) error
+	PointsDiscount(ctx context.Context, userID string, points int64, total int64) (int64, int64, error)
+	ExpirePoints(ctx context.Context) (int, error)
+}
+
+// PointsBalance is a user's redeemable points and what they are worth.
+type PointsBalance struct {
+	UserID     string `json:"user_id"`
+	Points     int64  `json:"points"`
+	PointValue int64  `json:"point_value"`
+	Value      int64  `json:"value"`
+}
+
+type loyaltyUsecase struct {
+	repo repository.LoyaltyRepository
+}
+
+func NewLoyaltyUsecase(repo repository.LoyaltyRepository) LoyaltyUsecase {
+	return &loyaltyUsecase{repo: repo}
+}
+
+func (u *loyaltyUsecase) CreateRule(ctx context.Context, req *request.CreateLoyaltyRuleRequest) (*entity.LoyaltyRule, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	rule := &entity.LoyaltyRule{
+		ID:          uuid.New(),
+		CategoryID:  req.CategoryID,
+		Points:      req.Points,
+		SpendAmount: req.SpendAmount,
+		IsActive:    isActive,
+	}
+	if err := u.repo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetRuleByID(ctx, rule.ID)
+}
+
+func (u *loyaltyUsecase) GetRules(ctx context.Context) ([]entity.LoyaltyRule, error) {
+	return u.repo.GetRules(ctx)
+}
+
+func (u *loyaltyUsecase) UpdateRule(ctx context.Context, id uuid.UUID, req *request.UpdateLoyaltyRuleRequest) (*entity.LoyaltyRule, error) {
+	updates := make(map[string]interface{})
+	if req.Points != nil {
+		updates["points"] = *req.Points
+	}
+	if req.SpendAmount != nil {
+		updates["spend_amount"] = *req.SpendAmount
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := u.repo.UpdateRule(ctx, id, updates); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.repo.GetRuleByID(ctx, id)
+}
+
+func (u *loyaltyUsecase) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	return u.repo.DeleteRule(ctx, id)
+}
+
+func (u *loyaltyUsecase) GetBalance(ctx context.Context, userID string) (*PointsBalance, error) {
+	points, err := u.repo.GetBalance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pointValue := config.LoyaltyPointValue()
+	return &PointsBalance{
+		UserID:     userID,
+		Points:     points,
+		PointValue: pointValue,
+		Value:      points * pointValue,
+	}, nil
+}
+
+func (u *loyaltyUsecase) GetHistory(ctx context.Context, filter *params.PointsQueryParams) ([]entity.PointsEntry, *transport.PaginationResponse, error) {
+	return u.repo.GetHistory(ctx, filter)
+}
+
+// AdjustPoints applies a manual correction by an admin. Positive adjustments
+// are a new lot that expires like earned points.
+func (u *loyaltyUsecase) AdjustPoints(ctx context.Context, userID string, adminID string, req *request.AdjustPointsRequest) (*entity.PointsEntry, error) {
+	if req.Points == 0 {
+		return nil, utils.ErrInvalidPoints
+	}
+
+	entry := &entity.PointsEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		EntryType: entity.PointsEntryAdjust,
+		Points:    req.Points,
+		Reason:    req.Reason,
+		CreatedBy: &adminID,
+	}
+
+	var err error
+	if req.Points > 0 {
+		err = u.repo.AddPoints(ctx, entry)
+	} else {
+		err = u.repo.DeductPoints(ctx, entry)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// AwardPoints credits the points a completed booking earns under the rule of
+// its service category. Awarding the same booking twice is a no-op.
+func (u *loyaltyUsecase) AwardPoints(ctx context.Context, booking *entity.Booking, categoryID uuid.UUID) error {
+	rule, err := u.repo.GetRuleForCategory(ctx, categoryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// No rule, no points
+			return nil
+		}
+		return err
+	}
+
+	points := booking.TotalPrice / rule.SpendAmount * rule.Points
+	if points <= 0 {
+		return nil
+	}
+
+	return u.repo.AddPoints(ctx, &entity.PointsEntry{
+		ID:        uuid.New(),
+		UserID:    booking.UserID,
+		EntryType: entity.PointsEntryEarn,
+		Points:    points,
+		BookingID: &booking.ID,
+	})
+}
+
+// PointsDiscount works out how many of the requested points can be spent on
+// a booking of the given total and the discount they give. Points beyond
+// what the total can absorb are not spent.
+func (u *loyaltyUsecase) PointsDiscount(ctx context.Context, userID string, points int64, total int64) (int64, int64, error) {
+	if points <= 0 {
+		return 0, 0, utils.ErrInvalidPoints
+	}
+
+	balance, err := u.repo.GetBalance(ctx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if points > balance {
+		return 0, 0, utils.ErrInsufficientPoints
+	}
+
+	pointValue := config.LoyaltyPointValue()
+	if maxPoints := total / pointValue; points > maxPoints {
+		points = maxPoints
+	}
+
+	return points, points * pointValue, nil
+}
+
+func (u *loyaltyUsecase) ExpirePoints(ctx context.Context) (int, error) {
+	return u.repo.ExpirePoints(ctx, time.Now())
+}
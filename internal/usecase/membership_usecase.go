@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/config"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/payment"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/money"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MembershipUsecase interface {
+	CreatePlan(ctx context.Context, req *request.CreateMembershipPlanRequest) (*entity.MembershipPlan, error)
+	GetPlanByID(ctx context.Context, id uuid.UUID) (*entity.MembershipPlan, error)
+	GetAllPlans(ctx context.Context, filter *params.MembershipPlanQueryParams) ([]entity.MembershipPlan, *transport.PaginationResponse, error)
+	UpdatePlan(ctx context.Context, id uuid.UUID, req *request.UpdateMembershipPlanRequest) (*entity.MembershipPlan, error)
+	DeletePlan(ctx context.Context, id uuid.UUID) error
+	Subscribe(ctx context.Context, userID string, planID uuid.UUID) (*entity.Subscription, error)
+	GetUserSubscription(ctx context.Context, userID string) (*entity.Subscription, error)
+	CancelSubscription(ctx context.Context, userID string) (*entity.Subscription, error)
+	GetAllSubscriptions(ctx context.Context, filter *params.SubscriptionQueryParams) ([]entity.Subscription, *transport.PaginationResponse, error)
+	RenewSubscriptions(ctx context.Context) (int, error)
+}
+
+type membershipUsecase struct {
+	repo     repository.MembershipRepository
+	provider payment.Provider
+}
+
+func NewMembershipUsecase(repo repository.MembershipRepository, provider payment.Provider) MembershipUsecase {
+	return &membershipUsecase{repo: repo, provider: provider}
+}
+
+func (u *membershipUsecase) CreatePlan(ctx context.Context, req *request.CreateMembershipPlanRequest) (*entity.MembershipPlan, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	plan := &entity.MembershipPlan{
+		ID:                  uuid.New(),
+		Name:                req.Name,
+		Description:         req.Description,
+		Price:               req.Price,
+		Currency:            money.NormalizeCurrency(req.Currency),
+		BillingPeriodMonths: req.BillingPeriodMonths,
+		DiscountPercent:     req.DiscountPercent,
+		IsActive:            isActive,
+		Benefits:            toMembershipBenefits(req.Benefits),
+	}
+
+	if err := u.repo.CreatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetPlanByID(ctx, plan.ID)
+}
+
+func (u *membershipUsecase) GetPlanByID(ctx context.Context, id uuid.UUID) (*entity.MembershipPlan, error) {
+	return u.repo.GetPlanByID(ctx, id)
+}
+
+func (u *membershipUsecase) GetAllPlans(ctx context.Context, filter *params.MembershipPlanQueryParams) ([]entity.MembershipPlan, *transport.PaginationResponse, error) {
+	return u.repo.GetPlans(ctx, filter)
+}
+
+func (u *membershipUsecase) UpdatePlan(ctx context.Context, id uuid.UUID, req *request.UpdateMembershipPlanRequest) (*entity.MembershipPlan, error) {
+	plan, err := u.repo.GetPlanByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		plan.Name = *req.Name
+	}
+	if req.Description != nil {
+		plan.Description = *req.Description
+	}
+	if req.Price != nil {
+		plan.Price = *req.Price
+	}
+	if req.Currency != nil {
+		plan.Currency = money.NormalizeCurrency(*req.Currency)
+	}
+	if req.BillingPeriodMonths != nil {
+		plan.BillingPeriodMonths = *req.BillingPeriodMonths
+	}
+	if req.DiscountPercent != nil {
+		plan.DiscountPercent = *req.DiscountPercent
+	}
+	if req.IsActive != nil {
+		plan.IsActive = *req.IsActive
+	}
+
+	replaceBenefits := req.Benefits != nil
+	if replaceBenefits {
+		plan.Benefits = toMembershipBenefits(req.Benefits)
+	}
+
+	if err := u.repo.UpdatePlan(ctx, plan, replaceBenefits); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetPlanByID(ctx, id)
+}
+
+func (u *membershipUsecase) DeletePlan(ctx context.Context, id uuid.UUID) error {
+	return u.repo.DeletePlan(ctx, id)
+}
+
+// Subscribe charges the first period of the plan and starts the
+// subscription. The charge is refunded if the subscription cannot be stored.
+func (u *membershipUsecase) Subscribe(ctx context.Context, userID string, planID uuid.UUID) (*entity.Subscription, error) {
+	plan, err := u.repo.GetPlanByID(ctx, planID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrMembershipPlanNotFound
+		}
+		return nil, err
+	}
+	if !plan.IsActive {
+		return nil, utils.ErrMembershipPlanNotFound
+	}
+
+	if _, err := u.repo.GetUserSubscription(ctx, userID); err == nil {
+		return nil, utils.ErrAlreadySubscribed
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	subscription := &entity.Subscription{
+		ID:                 uuid.New(),
+		UserID:             userID,
+		PlanID:             plan.ID,
+		Status:             entity.SubscriptionActive,
+		CurrentPeriodStart: now,
+		CurrentPeriodEnd:   now.AddDate(0, plan.BillingPeriodMonths, 0),
+	}
+	subscription.Usages = periodUsages(subscription, plan)
+
+	paid, err := u.charge(ctx, subscription, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.CreateSubscription(ctx, subscription, paid); err != nil {
+		if refundErr := u.refund(ctx, paid, "subscription could not be started"); refundErr != nil {
+			return nil, fmt.Errorf("%w; refunding charge %s failed: %v", err, paid.ChargeID, refundErr)
+		}
+		return nil, err
+	}
+
+	return u.repo.GetSubscriptionByID(ctx, subscription.ID)
+}
+
+// charge bills the plan price for the subscription's current period and
+// returns the payment to record. Free plans are not sent to the provider.
+func (u *membershipUsecase) charge(ctx context.Context, subscription *entity.Subscription, plan *entity.MembershipPlan) (*entity.SubscriptionPayment, error) {
+	paid := &entity.SubscriptionPayment{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		Amount:         plan.Price,
+		Currency:       plan.Currency,
+		PeriodStart:    subscription.CurrentPeriodStart,
+		PeriodEnd:      subscription.CurrentPeriodEnd,
+		Status:         entity.SubscriptionPaymentSucceeded,
+	}
+	if plan.Price == 0 {
+		return paid, nil
+	}
+
+	charge, err := u.provider.Charge(ctx, payment.ChargeRequest{
+		CustomerID:     subscription.UserID,
+		Amount:         plan.Price,
+		Currency:       plan.Currency,
+		Description:    fmt.Sprintf("%s membership", plan.Name),
+		IdempotencyKey: fmt.Sprintf("subscription:%s:%d", subscription.ID, subscription.CurrentPeriodStart.Unix()),
+	})
+	if err != nil {
+		paid.Status = entity.SubscriptionPaymentFailed
+		paid.FailureReason = err.Error()
+		if errors.Is(err, payment.ErrChargeDeclined) {
+			return paid, utils.ErrPaymentDeclined
+		}
+		return paid, err
+	}
+
+	paid.ChargeID = charge.ID
+	subscription.LastChargeID = charge.ID
+	return paid, nil
+}
+
+func (u *membershipUsecase) refund(ctx context.Context, paid *entity.SubscriptionPayment, reason string) error {
+	if paid.ChargeID == "" {
+		return nil
+	}
+	_, err := u.provider.Refund(ctx, payment.RefundRequest{
+		ChargeID:       paid.ChargeID,
+		Amount:         paid.Amount,
+		Reason:         reason,
+		IdempotencyKey: "refund:" + paid.ChargeID,
+	})
+	return err
+}
+
+func (u *membershipUsecase) GetUserSubscription(ctx context.Context, userID string) (*entity.Subscription, error) {
+	subscription, err := u.repo.GetUserSubscription(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrSubscriptionNotFound
+	}
+	return subscription, err
+}
+
+// CancelSubscription stops the subscription from renewing. Benefits stay
+// available until the end of the paid period.
+func (u *membershipUsecase) CancelSubscription(ctx context.Context, userID string) (*entity.Subscription, error) {
+	subscription, err := u.GetUserSubscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{"cancel_at_period_end": true}
+	// A renewal that never went through leaves nothing to wait for
+	if subscription.Status == entity.SubscriptionPastDue {
+		updates["status"] = entity.SubscriptionCancelled
+	}
+	if err := u.repo.UpdateSubscription(ctx, subscription.ID, updates); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetSubscriptionByID(ctx, subscription.ID)
+}
+
+func (u *membershipUsecase) GetAllSubscriptions(ctx context.Context, filter *params.SubscriptionQueryParams) ([]entity.Subscription, *transport.PaginationResponse, error) {
+	return u.repo.GetSubscriptions(ctx, filter)
+}
+
+// RenewSubscriptions charges every subscription whose period has ended for
+// the next period. A declined charge leaves the subscription PAST_DUE and is
+// retried until the grace period runs out, then it expires. It returns the
+// number of subscriptions renewed.
+func (u *membershipUsecase) RenewSubscriptions(ctx context.Context) (int, error) {
+	now := time.Now()
+	subscriptions, err := u.repo.GetDueSubscriptions(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	renewed := 0
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+
+		if subscription.CancelAtPeriodEnd {
+			if err := u.repo.UpdateSubscription(ctx, subscription.ID, map[string]interface{}{
+				"status": entity.SubscriptionCancelled,
+			}); err != nil {
+				return renewed, err
+			}
+			continue
+		}
+
+		lapsedAt := subscription.CurrentPeriodEnd
+		err := u.renew(ctx, subscription, now)
+		if err == nil {
+			renewed++
+			continue
+		}
+		if !errors.Is(err, utils.ErrPaymentDeclined) {
+			return renewed, err
+		}
+
+		status := entity.SubscriptionPastDue
+		if now.After(lapsedAt.AddDate(0, 0, config.MembershipGraceDays())) {
+			status = entity.SubscriptionExpired
+		}
+		if err := u.repo.UpdateSubscription(ctx, subscription.ID, map[string]interface{}{
+			"status": status,
+		}); err != nil {
+			return renewed, err
+		}
+	}
+
+	return renewed, nil
+}
+
+// renew charges the next period of the subscription and moves it there.
+func (u *membershipUsecase) renew(ctx context.Context, subscription *entity.Subscription, now time.Time) error {
+	plan := &subscription.Plan
+
+	// The next period follows on from the last one, unless the renewal is so
+	// late that it would already be over
+	start := subscription.CurrentPeriodEnd
+	end := start.AddDate(0, plan.BillingPeriodMonths, 0)
+	if !end.After(now) {
+		start = now
+		end = now.AddDate(0, plan.BillingPeriodMonths, 0)
+	}
+	subscription.CurrentPeriodStart = start
+	subscription.CurrentPeriodEnd = end
+	subscription.Usages = periodUsages(subscription, plan)
+
+	paid, err := u.charge(ctx, subscription, plan)
+	if err != nil {
+		if err := u.repo.RecordPayment(ctx, paid); err != nil {
+			return err
+		}
+		return err
+	}
+
+	return u.repo.RenewSubscription(ctx, subscription, paid)
+}
+
+// periodUsages opens the usage counters of the plan's benefits for the
+// subscription's current period.
+func periodUsages(subscription *entity.Subscription, plan *entity.MembershipPlan) []entity.SubscriptionUsage {
+	usages := make([]entity.SubscriptionUsage, 0, len(plan.Benefits))
+	for _, benefit := range plan.Benefits {
+		usages = append(usages, entity.SubscriptionUsage{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			ServiceID:      benefit.ServiceID,
+			PeriodStart:    subscription.CurrentPeriodStart,
+			Included:       benefit.Quantity,
+		})
+	}
+	return usages
+}
+
+// toMembershipBenefits merges benefits for the same service into one.
+func toMembershipBenefits(reqs []request.MembershipBenefitRequest) []entity.MembershipBenefit {
+	benefits := make([]entity.MembershipBenefit, 0, len(reqs))
+	index := make(map[uuid.UUID]int, len(reqs))
+	for _, req := range reqs {
+		if i, ok := index[req.ServiceID]; ok {
+			benefits[i].Quantity += req.Quantity
+			continue
+		}
+		index[req.ServiceID] = len(benefits)
+		benefits = append(benefits, entity.MembershipBenefit{
+			ID:        uuid.New(),
+			ServiceID: req.ServiceID,
+			Quantity:  req.Quantity,
+		})
+	}
+	return benefits
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/payment"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryMembershipRepository keeps plans, subscriptions and payments in
+// memory. Methods the tests don't reach are left to the embedded interface.
+type memoryMembershipRepository struct {
+	repository.MembershipRepository
+	plans         map[uuid.UUID]*entity.MembershipPlan
+	subscriptions map[uuid.UUID]*entity.Subscription
+	payments      []entity.SubscriptionPayment
+}
+
+func newMemoryMembershipRepository(plans ...*entity.MembershipPlan) *memoryMembershipRepository {
+	r := &memoryMembershipRepository{
+		plans:         map[uuid.UUID]*entity.MembershipPlan{},
+		subscriptions: map[uuid.UUID]*entity.Subscription{},
+	}
+	for _, plan := range plans {
+		r.plans[plan.ID] = plan
+	}
+	return r
+}
+
+func (r *memoryMembershipRepository) GetPlanByID(ctx context.Context, id uuid.UUID) (*entity.MembershipPlan, error) {
+	plan, ok := r.plans[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return plan, nil
+}
+
+func (r *memoryMembershipRepository) CreateSubscription(ctx context.Context, subscription *entity.Subscription, payment *entity.SubscriptionPayment) error {
+	r.subscriptions[subscription.ID] = subscription
+	payment.SubscriptionID = subscription.ID
+	r.payments = append(r.payments, *payment)
+	return nil
+}
+
+func (r *memoryMembershipRepository) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*entity.Subscription, error) {
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *subscription
+	found.Plan = *r.plans[subscription.PlanID]
+	return &found, nil
+}
+
+func (r *memoryMembershipRepository) GetUserSubscription(ctx context.Context, userID string) (*entity.Subscription, error) {
+	for _, subscription := range r.subscriptions {
+		if subscription.UserID == userID &&
+			(subscription.Status == entity.SubscriptionActive || subscription.Status == entity.SubscriptionPastDue) {
+			return r.GetSubscriptionByID(ctx, subscription.ID)
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryMembershipRepository) GetDueSubscriptions(ctx context.Context, now time.Time) ([]entity.Subscription, error) {
+	due := []entity.Subscription{}
+	for id, subscription := range r.subscriptions {
+		if subscription.Status != entity.SubscriptionActive && subscription.Status != entity.SubscriptionPastDue {
+			continue
+		}
+		if subscription.CurrentPeriodEnd.After(now) {
+			continue
+		}
+		found, _ := r.GetSubscriptionByID(ctx, id)
+		due = append(due, *found)
+	}
+	return due, nil
+}
+
+func (r *memoryMembershipRepository) UpdateSubscription(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if status, ok := updates["status"].(string); ok {
+		subscription.Status = status
+	}
+	if cancel, ok := updates["cancel_at_period_end"].(bool); ok {
+		subscription.CancelAtPeriodEnd = cancel
+	}
+	return nil
+}
+
+func (r *memoryMembershipRepository) RenewSubscription(ctx context.Context, subscription *entity.Subscription, payment *entity.SubscriptionPayment) error {
+	stored := r.subscriptions[subscription.ID]
+	stored.Status = entity.SubscriptionActive
+	stored.CurrentPeriodStart = subscription.CurrentPeriodStart
+	stored.CurrentPeriodEnd = subscription.CurrentPeriodEnd
+	stored.LastChargeID = subscription.LastChargeID
+	stored.Usages = subscription.Usages
+	r.payments = append(r.payments, *payment)
+	return nil
+}
+
+func (r *memoryMembershipRepository) RecordPayment(ctx context.Context, payment *entity.SubscriptionPayment) error {
+	r.payments = append(r.payments, *payment)
+	return nil
+}
+
+func testPlan() *entity.MembershipPlan {
+	serviceID := uuid.New()
+	return &entity.MembershipPlan{
+		ID:                  uuid.New(),
+		Name:                "Gold",
+		Price:               30000,
+		Currency:            "MMK",
+		BillingPeriodMonths: 1,
+		IsActive:            true,
+		Benefits:            []entity.MembershipBenefit{{ServiceID: serviceID, Quantity: 2}},
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	plan := testPlan()
+	repo := newMemoryMembershipRepository(plan)
+	u := NewMembershipUsecase(repo, payment.NewFakeProvider())
+
+	subscription, err := u.Subscribe(context.Background(), "user_1", plan.ID)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if subscription.Status != entity.SubscriptionActive || subscription.LastChargeID == "" {
+		t.Errorf("subscription = %s charged %q, want ACTIVE and charged", subscription.Status, subscription.LastChargeID)
+	}
+	if want := subscription.CurrentPeriodStart.AddDate(0, 1, 0); !subscription.CurrentPeriodEnd.Equal(want) {
+		t.Errorf("period ends %v, want %v", subscription.CurrentPeriodEnd, want)
+	}
+	if len(subscription.Usages) != 1 || subscription.Usages[0].Included != 2 {
+		t.Errorf("usages = %+v, want the plan's 2 included sessions", subscription.Usages)
+	}
+	if len(repo.payments) != 1 || repo.payments[0].Status != entity.SubscriptionPaymentSucceeded || repo.payments[0].Amount != plan.Price {
+		t.Errorf("payments = %+v, want one succeeded payment of the plan price", repo.payments)
+	}
+
+	if _, err := u.Subscribe(context.Background(), "user_1", plan.ID); !errors.Is(err, utils.ErrAlreadySubscribed) {
+		t.Errorf("second Subscribe error = %v, want %v", err, utils.ErrAlreadySubscribed)
+	}
+}
+
+func TestSubscribeDeclined(t *testing.T) {
+	plan := testPlan()
+	repo := newMemoryMembershipRepository(plan)
+	provider := payment.NewFakeProvider()
+	provider.Decline = true
+	u := NewMembershipUsecase(repo, provider)
+
+	if _, err := u.Subscribe(context.Background(), "user_1", plan.ID); !errors.Is(err, utils.ErrPaymentDeclined) {
+		t.Fatalf("Subscribe error = %v, want %v", err, utils.ErrPaymentDeclined)
+	}
+	if len(repo.subscriptions) != 0 {
+		t.Errorf("a declined subscription was stored")
+	}
+}
+
+// dueSubscription stores a subscription of the plan whose period ended at
+// periodEnd.
+func dueSubscription(repo *memoryMembershipRepository, plan *entity.MembershipPlan, periodEnd time.Time) *entity.Subscription {
+	subscription := &entity.Subscription{
+		ID:                 uuid.New(),
+		UserID:             "user_1",
+		PlanID:             plan.ID,
+		Status:             entity.SubscriptionActive,
+		CurrentPeriodStart: periodEnd.AddDate(0, -plan.BillingPeriodMonths, 0),
+		CurrentPeriodEnd:   periodEnd,
+	}
+	repo.subscriptions[subscription.ID] = subscription
+	return subscription
+}
+
+func TestRenewSubscriptions(t *testing.T) {
+	plan := testPlan()
+	repo := newMemoryMembershipRepository(plan)
+	u := NewMembershipUsecase(repo, payment.NewFakeProvider())
+	periodEnd := time.Now().Add(-time.Hour)
+	subscription := dueSubscription(repo, plan, periodEnd)
+
+	renewed, err := u.RenewSubscriptions(context.Background())
+	if err != nil {
+		t.Fatalf("RenewSubscriptions: %v", err)
+	}
+	if renewed != 1 {
+		t.Errorf("renewed %d, want 1", renewed)
+	}
+	if subscription.Status != entity.SubscriptionActive || subscription.LastChargeID == "" {
+		t.Errorf("subscription = %s charged %q, want ACTIVE and charged", subscription.Status, subscription.LastChargeID)
+	}
+	if !subscription.CurrentPeriodStart.Equal(periodEnd) || !subscription.CurrentPeriodEnd.Equal(periodEnd.AddDate(0, 1, 0)) {
+		t.Errorf("period = %v to %v, want it to follow on from %v", subscription.CurrentPeriodStart, subscription.CurrentPeriodEnd, periodEnd)
+	}
+	if len(repo.payments) != 1 || repo.payments[0].Status != entity.SubscriptionPaymentSucceeded {
+		t.Errorf("payments = %+v, want one succeeded payment", repo.payments)
+	}
+}
+
+func TestRenewSubscriptionsDeclined(t *testing.T) {
+	tests := []struct {
+		name      string
+		periodEnd time.Time
+		status    string
+	}{
+		{"within grace period", time.Now().Add(-time.Hour), entity.SubscriptionPastDue},
+		{"after grace period", time.Now().AddDate(0, 0, -4), entity.SubscriptionExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MEMBERSHIP_GRACE_DAYS", "3")
+			plan := testPlan()
+			repo := newMemoryMembershipRepository(plan)
+			provider := payment.NewFakeProvider()
+			provider.Decline = true
+			u := NewMembershipUsecase(repo, provider)
+			subscription := dueSubscription(repo, plan, tt.periodEnd)
+
+			renewed, err := u.RenewSubscriptions(context.Background())
+			if err != nil {
+				t.Fatalf("RenewSubscriptions: %v", err)
+			}
+			if renewed != 0 {
+				t.Errorf("renewed %d, want 0", renewed)
+			}
+			if subscription.Status != tt.status {
+				t.Errorf("status = %s, want %s", subscription.Status, tt.status)
+			}
+			if !subscription.CurrentPeriodEnd.Equal(tt.periodEnd) {
+				t.Errorf("period end moved to %v", subscription.CurrentPeriodEnd)
+			}
+			if len(repo.payments) != 1 || repo.payments[0].Status != entity.SubscriptionPaymentFailed {
+				t.Errorf("payments = %+v, want one failed payment", repo.payments)
+			}
+		})
+	}
+}
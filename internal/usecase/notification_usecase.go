@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/config"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+type NotificationUsecase interface {
+	Notify(ctx context.Context, notification *entity.Notification) error
+	NotifyBookingCancelled(ctx context.Context, booking *entity.Booking) error
+	GetUserNotifications(ctx context.Context, userID string, filter *params.NotificationQueryParams) ([]entity.Notification, *transport.PaginationResponse, error)
+	MarkRead(ctx context.Context, id uuid.UUID, userID string) error
+}
+
+type notificationUsecase struct {
+	repo repository.NotificationRepository
+}
+
+func NewNotificationUsecase(repo repository.NotificationRepository) NotificationUsecase {
+	return &notificationUsecase{repo: repo}
+}
+
+func (u *notificationUsecase) Notify(ctx context.Context, notification *entity.Notification) error {
+	if notification.ID == uuid.Nil {
+		notification.ID = uuid.New()
+	}
+	return u.repo.Create(ctx, notification)
+}
+
+// NotifyBookingCancelled tells the customer the salon cancelled their
+// booking. Users have no language of their own yet, so the message is in the
+// default locale.
+func (u *notificationUsecase) NotifyBookingCancelled(ctx context.Context, booking *entity.Booking) error {
+	message := i18n.Render(config.DefaultLocale(), i18n.CodeNotifyBookingCancelled, i18n.Params{
+		"service": booking.ServiceName,
+		"date":    booking.BookedDate,
+		"time":    booking.BookedTime,
+	})
+	return u.Notify(ctx, &entity.Notification{
+		UserID:    booking.UserID,
+		Type:      entity.NotificationBookingCancelled,
+		BookingID: &booking.ID,
+		Message:   message,
+	})
+}
+
+func (u *notificationUsecase) GetUserNotifications(ctx context.Context, userID string, filter *params.NotificationQueryParams) ([]entity.Notification, *transport.PaginationResponse, error) {
+	return u.repo.GetByUserID(ctx, userID, filter.Limit, filter.Offset)
+}
+
+func (u *notificationUsecase) MarkRead(ctx context.Context, id uuid.UUID, userID string) error {
+	return u.repo.MarkRead(ctx, id, userID)
+}
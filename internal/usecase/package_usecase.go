@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/money"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PackageUsecase interface {
+	CreatePackage(ctx context.Context, req *request.CreatePackageRequest) (*entity.Package, error)
+	GetPackageByID(ctx context.Context, id uuid.UUID) (*entity.Package, error)
+	GetAllPackages(ctx context.Context, filter *params.PackageQueryParams) ([]entity.Package, *transport.PaginationResponse, error)
+	UpdatePackage(ctx context.Context, id uuid.UUID, req *request.UpdatePackageRequest) (*entity.Package, error)
+	DeletePackage(ctx context.Context, id uuid.UUID) error
+	SellPackage(ctx context.Context, id uuid.UUID, req *request.SellPackageRequest) (*entity.CustomerPackage, error)
+	GetUserPackages(ctx context.Context, userID string) ([]entity.CustomerPackage, error)
+	ExpirePackages(ctx context.Context) (int, error)
+}
+
+type packageUsecase struct {
+	repo repository.PackageRepository
+}
+
+func NewPackageUsecase(repo repository.PackageRepository) PackageUsecase {
+	return &packageUsecase{repo: repo}
+}
+
+func (u *packageUsecase) CreatePackage(ctx context.Context, req *request.CreatePackageRequest) (*entity.Package, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	pkg := &entity.Package{
+		ID:           uuid.New(),
+		Name:         req.Name,
+		Description:  req.Description,
+		Price:        req.Price,
+		Currency:     money.NormalizeCurrency(req.Currency),
+		ValidityDays: req.ValidityDays,
+		IsActive:     isActive,
+		Items:        toPackageItems(req.Items),
+	}
+
+	if err := u.repo.Create(ctx, pkg); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, pkg.ID)
+}
+
+func (u *packageUsecase) GetPackageByID(ctx context.Context, id uuid.UUID) (*entity.Package, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *packageUsecase) GetAllPackages(ctx context.Context, filter *params.PackageQueryParams) ([]entity.Package, *transport.PaginationResponse, error) {
+	return u.repo.GetAll(ctx, filter)
+}
+
+func (u *packageUsecase) UpdatePackage(ctx context.Context, id uuid.UUID, req *request.UpdatePackageRequest) (*entity.Package, error) {
+	pkg, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		pkg.Name = *req.Name
+	}
+	if req.Description != nil {
+		pkg.Description = *req.Description
+	}
+	if req.Price != nil {
+		pkg.Price = *req.Price
+	}
+	if req.Currency != nil {
+		pkg.Currency = money.NormalizeCurrency(*req.Currency)
+	}
+	if req.ValidityDays != nil {
+		pkg.ValidityDays = *req.ValidityDays
+	}
+	if req.IsActive != nil {
+		pkg.IsActive = *req.IsActive
+	}
+
+	replaceItems := len(req.Items) > 0
+	if replaceItems {
+		pkg.Items = toPackageItems(req.Items)
+	}
+
+	if err := u.repo.Update(ctx, pkg, replaceItems); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *packageUsecase) DeletePackage(ctx context.Context, id uuid.UUID) error {
+	return u.repo.Delete(ctx, id)
+}
+
+// SellPackage records the sale of a package to a user and grants its credits.
+// Payment is taken at the counter before the sale is recorded.
+func (u *packageUsecase) SellPackage(ctx context.Context, id uuid.UUID, req *request.SellPackageRequest) (*entity.CustomerPackage, error) {
+	pkg, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrPackageNotFound
+		}
+		return nil, err
+	}
+	if !pkg.IsActive {
+		return nil, utils.ErrPackageNotFound
+	}
+
+	customerPackage := &entity.CustomerPackage{
+		ID:          uuid.New(),
+		UserID:      req.UserID,
+		PackageID:   pkg.ID,
+		PackageName: pkg.Name,
+		PricePaid:   pkg.Price,
+		Currency:    pkg.Currency,
+		Status:      entity.CustomerPackageActive,
+		ExpiresAt:   time.Now().AddDate(0, 0, pkg.ValidityDays),
+	}
+
+	// Merge items for the same service into one credit
+	sessions := make(map[uuid.UUID]int)
+	order := make([]uuid.UUID, 0, len(pkg.Items))
+	for _, item := range pkg.Items {
+		if _, ok := sessions[item.ServiceID]; !ok {
+			order = append(order, item.ServiceID)
+		}
+		sessions[item.ServiceID] += item.Sessions
+	}
+	for _, serviceID := range order {
+		customerPackage.Credits = append(customerPackage.Credits, entity.CustomerPackageCredit{
+			ID:                uuid.New(),
+			CustomerPackageID: customerPackage.ID,
+			ServiceID:         serviceID,
+			Total:             sessions[serviceID],
+			Remaining:         sessions[serviceID],
+		})
+	}
+
+	if err := u.repo.Sell(ctx, customerPackage); err != nil {
+		return nil, err
+	}
+
+	return customerPackage, nil
+}
+
+func (u *packageUsecase) GetUserPackages(ctx context.Context, userID string) ([]entity.CustomerPackage, error) {
+	return u.repo.GetCustomerPackagesByUserID(ctx, userID)
+}
+
+func (u *packageUsecase) ExpirePackages(ctx context.Context) (int, error) {
+	return u.repo.ExpireCustomerPackages(ctx, time.Now())
+}
+
+func toPackageItems(reqs []request.PackageItemRequest) []entity.PackageItem {
+	items := make([]entity.PackageItem, len(reqs))
+	for i, item := range reqs {
+		items[i] = entity.PackageItem{
+			ID:        uuid.New(),
+			ServiceID: item.ServiceID,
+			Sessions:  item.Sessions,
+		}
+	}
+	return items
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+type PricingRuleUsecase interface {
+	CreatePricingRule(ctx context.Context, req *request.CreatePricingRuleRequest) (*entity.PricingRule, error)
+	GetPricingRuleByID(ctx context.Context, id uuid.UUID) (*entity.PricingRule, error)
+	GetAllPricingRules(ctx context.Context, filter *params.PricingRuleQueryParams) ([]entity.PricingRule, *transport.PaginationResponse, error)
+	UpdatePricingRule(ctx context.Context, id uuid.UUID, req *request.UpdatePricingRuleRequest) (*entity.PricingRule, error)
+	DeletePricingRule(ctx context.Context, id uuid.UUID) error
+	GetActiveRules(ctx context.Context) (PricingRules, error)
+}
+
+// PricingRules is the set of active rules ordered by priority, loaded once
+// to price many slots.
+type PricingRules []entity.PricingRule
+
+// Price applies the highest-priority rule matching an appointment of the
+// service at the branch at `at`, booked at `now`. It returns the base price
+// and a nil rule when none matches.
+func (rules PricingRules) Price(basePrice int64, service *entity.Service, branchID uuid.UUID, at, now time.Time) (int64, *entity.PricingRule) {
+	for i := range rules {
+		if rules[i].Matches(service.ID, service.CategoryID, branchID, at, now) {
+			return rules[i].Apply(basePrice), &rules[i]
+		}
+	}
+	return basePrice, nil
+}
+
+type pricingRuleUsecase struct {
+	repo repository.PricingRuleRepository
+}
+
+func NewPricingRuleUsecase(repo repository.PricingRuleRepository) PricingRuleUsecase {
+	return &pricingRuleUsecase{repo: repo}
+}
+
+var weekdays = map[string]bool{"MON": true, "TUE": true, "WED": true, "THU": true, "FRI": true, "SAT": true, "SUN": true}
+
+// normalizeDaysOfWeek upper-cases and checks a list such as "sat, sun".
+func normalizeDaysOfWeek(days string) (string, error) {
+	if strings.TrimSpace(days) == "" {
+		return "", nil
+	}
+
+	parts := strings.Split(days, ",")
+	for i, part := range parts {
+		part = strings.ToUpper(strings.TrimSpace(part))
+		if !weekdays[part] {
+			return "", utils.ErrPricingRuleInvalid
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ","), nil
+}
+
+func validatePricingRule(rule *entity.PricingRule) error {
+	if rule.StartTime != "" && rule.EndTime != "" && rule.StartTime >= rule.EndTime {
+		return utils.ErrPricingRuleInvalid
+	}
+	if rule.MinLeadHours != nil && rule.MaxLeadHours != nil && *rule.MinLeadHours > *rule.MaxLeadHours {
+		return utils.ErrPricingRuleInvalid
+	}
+	if rule.AdjustmentType == entity.DiscountTypePercentage && rule.AdjustmentValue < -100 {
+		return utils.ErrPricingRuleInvalid
+	}
+	return nil
+}
+
+func (u *pricingRuleUsecase) CreatePricingRule(ctx context.Context, req *request.CreatePricingRuleRequest) (*entity.PricingRule, error) {
+	days, err := normalizeDaysOfWeek(req.DaysOfWeek)
+	if err != nil {
+		return nil, err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	rule := &entity.PricingRule{
+		ID:              uuid.New(),
+		Name:            req.Name,
+		ServiceID:       req.ServiceID,
+		CategoryID:      req.CategoryID,
+		BranchID:        req.BranchID,
+		DaysOfWeek:      days,
+		StartTime:       req.StartTime,
+		EndTime:         req.EndTime,
+		MinLeadHours:    req.MinLeadHours,
+		MaxLeadHours:    req.MaxLeadHours,
+		AdjustmentType:  req.AdjustmentType,
+		AdjustmentValue: req.AdjustmentValue,
+		Priority:        req.Priority,
+		IsActive:        isActive,
+	}
+	if err := validatePricingRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (u *pricingRuleUsecase) GetPricingRuleByID(ctx context.Context, id uuid.UUID) (*entity.PricingRule, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *pricingRuleUsecase) GetAllPricingRules(ctx context.Context, filter *params.PricingRuleQueryParams) ([]entity.PricingRule, *transport.PaginationResponse, error) {
+	return u.repo.GetAll(ctx, filter)
+}
+
+func (u *pricingRuleUsecase) UpdatePricingRule(ctx context.Context, id uuid.UUID, req *request.UpdatePricingRuleRequest) (*entity.PricingRule, error) {
+	rule, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.DaysOfWeek != nil {
+		days, err := normalizeDaysOfWeek(*req.DaysOfWeek)
+		if err != nil {
+			return nil, err
+		}
+		rule.DaysOfWeek = days
+		updates["days_of_week"] = days
+	}
+	if req.StartTime != nil {
+		rule.StartTime = *req.StartTime
+		updates["start_time"] = *req.StartTime
+	}
+	if req.EndTime != nil {
+		rule.EndTime = *req.EndTime
+		updates["end_time"] = *req.EndTime
+	}
+	if req.MinLeadHours != nil {
+		rule.MinLeadHours = req.MinLeadHours
+		updates["min_lead_hours"] = *req.MinLeadHours
+	}
+	if req.MaxLeadHours != nil {
+		rule.MaxLeadHours = req.MaxLeadHours
+		updates["max_lead_hours"] = *req.MaxLeadHours
+	}
+	if req.AdjustmentType != nil {
+		rule.AdjustmentType = *req.AdjustmentType
+		updates["adjustment_type"] = *req.AdjustmentType
+	}
+	if req.AdjustmentValue != nil {
+		rule.AdjustmentValue = *req.AdjustmentValue
+		updates["adjustment_value"] = *req.AdjustmentValue
+	}
+	if req.Priority != nil {
+		updates["priority"] = *req.Priority
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if err := validatePricingRule(rule); err != nil {
+		return nil, err
+	}
+
+	if len(updates) > 0 {
+		if err := u.repo.Update(ctx, id, updates); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *pricingRuleUsecase) DeletePricingRule(ctx context.Context, id uuid.UUID) error {
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *pricingRuleUsecase) GetActiveRules(ctx context.Context) (PricingRules, error) {
+	return u.repo.GetActive(ctx)
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/money"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PromotionUsecase interface {
+	CreatePromotion(ctx context.Context, req *request.CreatePromotionRequest) (*entity.Promotion, error)
+	GetPromotionByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error)
+	GetAllPromotions(ctx context.Context, filter *params.PromotionQueryParams) ([]entity.Promotion, *transport.PaginationResponse, error)
+	UpdatePromotion(ctx context.Context, id uuid.UUID, req *request.UpdatePromotionRequest) (*entity.Promotion, error)
+	DeletePromotion(ctx context.Context, id uuid.UUID) error
+	ApplyPromotion(ctx context.Context, code string, userID string, line PromotionLine) (*entity.Promotion, int64, error)
+}
+
+// PromotionLine describes what a promo code is being applied to.
+type PromotionLine struct {
+	ServiceID  uuid.UUID
+	CategoryID uuid.UUID
+	BranchID   uuid.UUID
+	Price      int64
+	Currency   string
+}
+
+type promotionUsecase struct {
+	repo repository.PromotionRepository
+}
+
+func NewPromotionUsecase(repo repository.PromotionRepository) PromotionUsecase {
+	return &promotionUsecase{repo: repo}
+}
+
+func (u *promotionUsecase) CreatePromotion(ctx context.Context, req *request.CreatePromotionRequest) (*entity.Promotion, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	promotion := &entity.Promotion{
+		ID:             uuid.New(),
+		Code:           strings.ToUpper(strings.TrimSpace(req.Code)),
+		Name:           req.Name,
+		Description:    req.Description,
+		DiscountType:   req.DiscountType,
+		DiscountValue:  req.DiscountValue,
+		MaxDiscount:    req.MaxDiscount,
+		Currency:       money.NormalizeCurrency(req.Currency),
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+		IsActive:       isActive,
+		Services:       toServices(req.ServiceIDs),
+		Categories:     toCategories(req.CategoryIDs),
+		Branches:       toBranches(req.BranchIDs),
+	}
+
+	if err := validatePromotion(promotion); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Create(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, promotion.ID)
+}
+
+func (u *promotionUsecase) GetPromotionByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *promotionUsecase) GetAllPromotions(ctx context.Context, filter *params.PromotionQueryParams) ([]entity.Promotion, *transport.PaginationResponse, error) {
+	return u.repo.GetAll(ctx, filter)
+}
+
+func (u *promotionUsecase) UpdatePromotion(ctx context.Context, id uuid.UUID, req *request.UpdatePromotionRequest) (*entity.Promotion, error) {
+	promotion, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		promotion.Name = *req.Name
+	}
+	if req.Description != nil {
+		promotion.Description = *req.Description
+	}
+	if req.DiscountType != nil {
+		promotion.DiscountType = *req.DiscountType
+	}
+	if req.DiscountValue != nil {
+		promotion.DiscountValue = *req.DiscountValue
+	}
+	if req.MaxDiscount != nil {
+		promotion.MaxDiscount = req.MaxDiscount
+	}
+	if req.Currency != nil {
+		promotion.Currency = money.NormalizeCurrency(*req.Currency)
+	}
+	if req.StartsAt != nil {
+		promotion.StartsAt = req.StartsAt
+	}
+	if req.EndsAt != nil {
+		promotion.EndsAt = req.EndsAt
+	}
+	if req.MaxUses != nil {
+		promotion.MaxUses = req.MaxUses
+	}
+	if req.MaxUsesPerUser != nil {
+		promotion.MaxUsesPerUser = req.MaxUsesPerUser
+	}
+	if req.IsActive != nil {
+		promotion.IsActive = *req.IsActive
+	}
+
+	// Restrictions are replaced only when at least one list is sent
+	replaceRestrictions := req.ServiceIDs != nil || req.CategoryIDs != nil || req.BranchIDs != nil
+	if replaceRestrictions {
+		promotion.Services = toServices(req.ServiceIDs)
+		promotion.Categories = toCategories(req.CategoryIDs)
+		promotion.Branches = toBranches(req.BranchIDs)
+	}
+
+	if err := validatePromotion(promotion); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Update(ctx, promotion, replaceRestrictions); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *promotionUsecase) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	return u.repo.Delete(ctx, id)
+}
+
+// ApplyPromotion checks that the code is usable for the line and returns the
+// promotion with the discount it gives. Usage counters are only updated when
+// the booking is stored.
+func (u *promotionUsecase) ApplyPromotion(ctx context.Context, code string, userID string, line PromotionLine) (*entity.Promotion, int64, error) {
+	promotion, err := u.repo.GetByCode(ctx, strings.TrimSpace(code))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, 0, utils.ErrPromotionNotFound
+		}
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	if !promotion.IsActive ||
+		(promotion.StartsAt != nil && now.Before(*promotion.StartsAt)) ||
+		(promotion.EndsAt != nil && now.After(*promotion.EndsAt)) {
+		return nil, 0, utils.ErrPromotionInactive
+	}
+
+	if !promotionAppliesTo(promotion, line) {
+		return nil, 0, utils.ErrPromotionNotApplicable
+	}
+
+	if promotion.MaxUses != nil && promotion.UsedCount >= *promotion.MaxUses {
+		return nil, 0, utils.ErrPromotionUsageLimitReached
+	}
+
+	if promotion.MaxUsesPerUser != nil {
+		used, err := u.repo.CountUserRedemptions(ctx, promotion.ID, userID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if used >= int64(*promotion.MaxUsesPerUser) {
+			return nil, 0, utils.ErrPromotionUsageLimitReached
+		}
+	}
+
+	return promotion, promotionDiscount(promotion, line.Price), nil
+}
+
+func validatePromotion(promotion *entity.Promotion) error {
+	if promotion.DiscountType == entity.DiscountTypePercentage && promotion.DiscountValue > 100 {
+		return utils.ErrPromotionInvalid
+	}
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return utils.ErrPromotionInvalid
+	}
+	return nil
+}
+
+func promotionAppliesTo(promotion *entity.Promotion, line PromotionLine) bool {
+	if promotion.DiscountType == entity.DiscountTypeFixed && promotion.Currency != line.Currency {
+		return false
+	}
+
+	if len(promotion.Services) > 0 {
+		found := false
+		for _, service := range promotion.Services {
+			if service.ID == line.ServiceID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(promotion.Categories) > 0 {
+		found := false
+		for _, category := range promotion.Categories {
+			if category.ID == line.CategoryID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(promotion.Branches) > 0 {
+		found := false
+		for _, branch := range promotion.Branches {
+			if branch.ID == line.BranchID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// promotionDiscount returns the discount in minor units, never more than the price.
+func promotionDiscount(promotion *entity.Promotion, price int64) int64 {
+	var discount int64
+	switch promotion.DiscountType {
+	case entity.DiscountTypePercentage:
+		discount = price * promotion.DiscountValue / 100
+		if promotion.MaxDiscount != nil && discount > *promotion.MaxDiscount {
+			discount = *promotion.MaxDiscount
+		}
+	case entity.DiscountTypeFixed:
+		discount = promotion.DiscountValue
+	}
+
+	if discount > price {
+		discount = price
+	}
+	return discount
+}
+
+func toServices(ids []uuid.UUID) []entity.Service {
+	services := make([]entity.Service, len(ids))
+	for i, id := range ids {
+		services[i] = entity.Service{ID: id}
+	}
+	return services
+}
+
+func toCategories(ids []uuid.UUID) []entity.Category {
+	categories := make([]entity.Category, len(ids))
+	for i, id := range ids {
+		categories[i] = entity.Category{ID: id}
+	}
+	return categories
+}
+
+func toBranches(ids []uuid.UUID) []entity.Branch {
+	branches := make([]entity.Branch, len(ids))
+	for i, id := range ids {
+		branches[i] = entity.Branch{ID: id}
+	}
+	return branches
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/payment"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefundUsecase interface {
+	CreateRule(ctx context.Context, req *request.CreateRefundRuleRequest) (*entity.RefundRule, error)
+	GetRules(ctx context.Context) ([]entity.RefundRule, error)
+	UpdateRule(ctx context.Context, id uuid.UUID, req *request.UpdateRefundRuleRequest) (*entity.RefundRule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	RefundFor(ctx context.Context) (repository.RefundFor, error)
+	GetRefundByID(ctx context.Context, id uuid.UUID) (*entity.Refund, error)
+	GetAllRefunds(ctx context.Context, filter *params.RefundQueryParams) ([]entity.Refund, *transport.PaginationResponse, error)
+	ApproveRefund(ctx context.Context, id uuid.UUID, reviewerID string, req *request.ApproveRefundRequest) (*entity.Refund, error)
+	RejectRefund(ctx context.Context, id uuid.UUID, reviewerID string, req *request.RejectRefundRequest) (*entity.Refund, error)
+}
+
+// RefundRules is the set of active rules, longest notice first.
+type RefundRules []entity.RefundRule
+
+// Refundable is the part of paid that the first rule the notice qualifies for
+// gives back. Without a matching rule nothing is refundable.
+func (rules RefundRules) Refundable(paid int64, noticeHours int) (int64, *entity.RefundRule) {
+	for i := range rules {
+		if noticeHours >= rules[i].MinNoticeHours {
+			return paid * int64(rules[i].Percent) / 100, &rules[i]
+		}
+	}
+	return 0, nil
+}
+
+type refundUsecase struct {
+	repo        repository.RefundRepository
+	bookingRepo repository.BookingRepository
+	provider    payment.Provider
+}
+
+func NewRefundUsecase(repo repository.RefundRepository, bookingRepo repository.BookingRepository,
+	provider payment.Provider) RefundUsecase {
+	return &refundUsecase{repo: repo, bookingRepo: bookingRepo, provider: provider}
+}
+
+func (u *refundUsecase) CreateRule(ctx context.Context, req *request.CreateRefundRuleRequest) (*entity.RefundRule, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	rule := &entity.RefundRule{
+		ID:             uuid.New(),
+		Name:           req.Name,
+		MinNoticeHours: req.MinNoticeHours,
+		Percent:        req.Percent,
+		IsActive:       isActive,
+	}
+	if err := u.repo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (u *refundUsecase) GetRules(ctx context.Context) ([]entity.RefundRule, error) {
+	return u.repo.GetRules(ctx)
+}
+
+func (u *refundUsecase) UpdateRule(ctx context.Context, id uuid.UUID, req *request.UpdateRefundRuleRequest) (*entity.RefundRule, error) {
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.MinNoticeHours != nil {
+		updates["min_notice_hours"] = *req.MinNoticeHours
+	}
+	if req.Percent != nil {
+		updates["percent"] = *req.Percent
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := u.repo.UpdateRule(ctx, id, updates); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.repo.GetRuleByID(ctx, id)
+}
+
+func (u *refundUsecase) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	return u.repo.DeleteRule(ctx, id)
+}
+
+// RefundFor works out refunds under the rules active now, for cancellations
+// that record them in their own transaction. The notice given before the
+// appointment picks the rule, and nothing is owed back when nothing was paid.
+func (u *refundUsecase) RefundFor(ctx context.Context) (repository.RefundFor, error) {
+	rules, err := u.repo.GetActiveRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(booking *entity.Booking) *entity.Refund {
+		paid := booking.AmountPaid - booking.AmountRefunded
+		if paid <= 0 {
+			return nil
+		}
+
+		noticeHours := 0
+		if at, err := utils.ParseBookingTime(booking.BookedDate, booking.BookedTime); err == nil {
+			if notice := time.Until(at); notice > 0 {
+				noticeHours = int(notice.Hours())
+			}
+		}
+		refundable, rule := RefundRules(rules).Refundable(paid, noticeHours)
+
+		refund := &entity.Refund{
+			ID:               uuid.New(),
+			BookingID:        booking.ID,
+			UserID:           booking.UserID,
+			Currency:         booking.Currency,
+			PaidAmount:       paid,
+			NoticeHours:      noticeHours,
+			RefundableAmount: refundable,
+			Status:           entity.RefundPending,
+		}
+		if rule != nil {
+			refund.RefundRuleID = &rule.ID
+		}
+		return refund
+	}, nil
+}
+
+func (u *refundUsecase) GetRefundByID(ctx context.Context, id uuid.UUID) (*entity.Refund, error) {
+	refund, err := u.repo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrRefundNotFound
+	}
+	return refund, err
+}
+
+func (u *refundUsecase) GetAllRefunds(ctx context.Context, filter *params.RefundQueryParams) ([]entity.Refund, *transport.PaginationResponse, error) {
+	return u.repo.GetAll(ctx, filter)
+}
+
+// ApproveRefund pays back the refundable amount, or the amount the admin
+// chose, against the booking's payments, newest first. Card payments are
+// refunded through the payment provider and counter payments are handed back
+// in cash. The refund is PROCESSING while it is paid out, which only one
+// approval can move it to. A refund that failed part way can be approved
+// again to finish it.
+func (u *refundUsecase) ApproveRefund(ctx context.Context, id uuid.UUID, reviewerID string, req *request.ApproveRefundRequest) (*entity.Refund, error) {
+	refund, err := u.GetRefundByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if refund.Status != entity.RefundPending && refund.Status != entity.RefundFailed {
+		return nil, utils.ErrRefundProcessed
+	}
+
+	amount := refund.RefundableAmount
+	if refund.Status == entity.RefundFailed {
+		amount = refund.Amount
+	}
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+	if amount <= 0 || amount > refund.PaidAmount || amount < refund.RefundedAmount {
+		return nil, utils.ErrInvalidRefundAmount
+	}
+
+	if err := u.repo.UpdateIfUnchanged(ctx, refund, map[string]interface{}{
+		"status":         entity.RefundProcessing,
+		"amount":         amount,
+		"note":           req.Note,
+		"reviewed_by":    reviewerID,
+		"reviewed_at":    time.Now(),
+		"failure_reason": "",
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := u.payOut(ctx, refund, amount-refund.RefundedAmount); err != nil {
+		if updateErr := u.repo.Update(ctx, id, map[string]interface{}{
+			"status":         entity.RefundFailed,
+			"failure_reason": err.Error(),
+		}); updateErr != nil {
+			return nil, updateErr
+		}
+		return nil, err
+	}
+
+	if err := u.repo.Update(ctx, id, map[string]interface{}{"status": entity.RefundSucceeded}); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+// payOut spreads amount over the payments that still have money to give back.
+func (u *refundUsecase) payOut(ctx context.Context, refund *entity.Refund, amount int64) error {
+	payments, err := u.bookingRepo.GetPayments(ctx, refund.BookingID)
+	if err != nil {
+		return err
+	}
+
+	for i := len(payments) - 1; i >= 0 && amount > 0; i-- {
+		p := payments[i]
+		share := p.Amount - p.RefundedAmount
+		if share <= 0 {
+			continue
+		}
+		if share > amount {
+			share = amount
+		}
+
+		allocation := &entity.RefundAllocation{
+			ID:        uuid.New(),
+			RefundID:  refund.ID,
+			PaymentID: p.ID,
+			Amount:    share,
+		}
+
+		if p.Method == entity.PaymentMethodCard && p.ChargeID != nil {
+			issued, err := u.provider.Refund(ctx, payment.RefundRequest{
+				ChargeID:       *p.ChargeID,
+				Amount:         share,
+				Reason:         "booking cancelled",
+				IdempotencyKey: fmt.Sprintf("refund:%s:%s", refund.ID, p.ID),
+			})
+			if err != nil {
+				return fmt.Errorf("%w: %v", utils.ErrRefundFailed, err)
+			}
+			allocation.ProviderRefundID = &issued.ID
+		}
+
+		if err := u.repo.Allocate(ctx, allocation); err != nil {
+			return err
+		}
+		amount -= share
+	}
+
+	if amount > 0 {
+		return utils.ErrInvalidRefundAmount
+	}
+	return nil
+}
+
+func (u *refundUsecase) RejectRefund(ctx context.Context, id uuid.UUID, reviewerID string, req *request.RejectRefundRequest) (*entity.Refund, error) {
+	refund, err := u.GetRefundByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Money already paid back cannot be taken back by rejecting
+	if refund.Status != entity.RefundPending {
+		return nil, utils.ErrRefundProcessed
+	}
+
+	if err := u.repo.UpdateIfUnchanged(ctx, refund, map[string]interface{}{
+		"status":      entity.RefundRejected,
+		"note":        req.Note,
+		"reviewed_by": reviewerID,
+		"reviewed_at": time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
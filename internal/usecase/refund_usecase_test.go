@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/payment"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestRefundRulesRefundable(t *testing.T) {
+	rules := RefundRules{
+		{Name: "full", MinNoticeHours: 48, Percent: 100},
+		{Name: "half", MinNoticeHours: 24, Percent: 50},
+	}
+	withFloor := append(RefundRules{}, rules...)
+	withFloor = append(withFloor, entity.RefundRule{Name: "late", MinNoticeHours: 0, Percent: 10})
+
+	tests := []struct {
+		name        string
+		rules       RefundRules
+		paid        int64
+		noticeHours int
+		want        int64
+		rule        string
+	}{
+		{"well ahead", rules, 10000, 72, 10000, "full"},
+		{"exactly the longest notice", rules, 10000, 48, 10000, "full"},
+		{"an hour short of it", rules, 10000, 47, 5000, "half"},
+		{"exactly the shorter notice", rules, 10000, 24, 5000, "half"},
+		{"rounds down", rules, 333, 24, 166, "half"},
+		{"no rule met", rules, 10000, 23, 0, ""},
+		{"zero notice rule", withFloor, 10000, 0, 1000, "late"},
+		{"no rules", nil, 10000, 72, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rule := tt.rules.Refundable(tt.paid, tt.noticeHours)
+			name := ""
+			if rule != nil {
+				name = rule.Name
+			}
+			if got != tt.want || name != tt.rule {
+				t.Errorf("Refundable(%d, %d) = %d by %q, want %d by %q", tt.paid, tt.noticeHours, got, name, tt.want, tt.rule)
+			}
+		})
+	}
+}
+
+// memoryRefundRepository keeps refunds and the booking payments they are
+// paid back against in memory, with the checks of the real repository.
+type memoryRefundRepository struct {
+	repository.RefundRepository
+	refunds  map[uuid.UUID]*entity.Refund
+	payments []*entity.BookingPayment
+}
+
+func (r *memoryRefundRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Refund, error) {
+	refund, ok := r.refunds[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *refund
+	return &found, nil
+}
+
+func (r *memoryRefundRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	refund, ok := r.refunds[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	applyRefundUpdates(refund, updates)
+	return nil
+}
+
+func (r *memoryRefundRepository) UpdateIfUnchanged(ctx context.Context, refund *entity.Refund, updates map[string]interface{}) error {
+	stored := r.refunds[refund.ID]
+	if stored.Status != refund.Status || stored.RefundedAmount != refund.RefundedAmount {
+		return utils.ErrRefundProcessed
+	}
+	applyRefundUpdates(stored, updates)
+	return nil
+}
+
+func applyRefundUpdates(refund *entity.Refund, updates map[string]interface{}) {
+	if status, ok := updates["status"].(string); ok {
+		refund.Status = status
+	}
+	if amount, ok := updates["amount"].(int64); ok {
+		refund.Amount = amount
+	}
+	if reason, ok := updates["failure_reason"].(string); ok {
+		refund.FailureReason = reason
+	}
+}
+
+func (r *memoryRefundRepository) Allocate(ctx context.Context, allocation *entity.RefundAllocation) error {
+	refund := r.refunds[allocation.RefundID]
+	if refund.Status != entity.RefundProcessing {
+		return utils.ErrRefundProcessed
+	}
+	if refund.RefundedAmount+allocation.Amount > refund.Amount {
+		return utils.ErrInvalidRefundAmount
+	}
+	for _, p := range r.payments {
+		if p.ID != allocation.PaymentID {
+			continue
+		}
+		if p.RefundedAmount+allocation.Amount > p.Amount {
+			return utils.ErrInvalidRefundAmount
+		}
+		p.RefundedAmount += allocation.Amount
+	}
+	refund.RefundedAmount += allocation.Amount
+	refund.Allocations = append(refund.Allocations, *allocation)
+	return nil
+}
+
+// paymentsBookingRepository serves the payments of the refund repository.
+type paymentsBookingRepository struct {
+	repository.BookingRepository
+	refunds *memoryRefundRepository
+}
+
+func (r *paymentsBookingRepository) GetPayments(ctx context.Context, bookingID uuid.UUID) ([]entity.BookingPayment, error) {
+	payments := []entity.BookingPayment{}
+	for _, p := range r.refunds.payments {
+		if p.BookingID == bookingID {
+			payments = append(payments, *p)
+		}
+	}
+	return payments, nil
+}
+
+// refundFixture opens a pending refund of a booking paid with a card charge
+// of 20000 and then 10000 at the counter, and returns the usecase over it.
+func refundFixture(t *testing.T, provider *payment.FakeProvider) (RefundUsecase, *memoryRefundRepository, *entity.Refund) {
+	t.Helper()
+	bookingID := uuid.New()
+	charge, err := provider.Charge(context.Background(), payment.ChargeRequest{Amount: 20000, Currency: "MMK"})
+	if err != nil {
+		t.Fatalf("Charge: %v", err)
+	}
+
+	refund := &entity.Refund{
+		ID:               uuid.New(),
+		BookingID:        bookingID,
+		PaidAmount:       30000,
+		RefundableAmount: 30000,
+		Status:           entity.RefundPending,
+	}
+	repo := &memoryRefundRepository{
+		refunds: map[uuid.UUID]*entity.Refund{refund.ID: refund},
+		payments: []*entity.BookingPayment{
+			{ID: uuid.New(), BookingID: bookingID, Amount: 20000, Method: entity.PaymentMethodCard, ChargeID: &charge.ID},
+			{ID: uuid.New(), BookingID: bookingID, Amount: 10000, Method: entity.PaymentMethodCounter},
+		},
+	}
+	u := NewRefundUsecase(repo, &paymentsBookingRepository{refunds: repo}, provider)
+	return u, repo, refund
+}
+
+func TestApproveRefundPartial(t *testing.T) {
+	u, repo, refund := refundFixture(t, payment.NewFakeProvider())
+	amount := int64(25000)
+
+	approved, err := u.ApproveRefund(context.Background(), refund.ID, "admin_1", &request.ApproveRefundRequest{Amount: &amount})
+	if err != nil {
+		t.Fatalf("ApproveRefund: %v", err)
+	}
+	if approved.Status != entity.RefundSucceeded || approved.Amount != amount || approved.RefundedAmount != amount {
+		t.Errorf("refund = %s, amount %d, refunded %d; want SUCCEEDED with %d refunded", approved.Status, approved.Amount, approved.RefundedAmount, amount)
+	}
+
+	// Newest payments are paid back first
+	counter, card := repo.payments[1], repo.payments[0]
+	if counter.RefundedAmount != 10000 || card.RefundedAmount != 15000 {
+		t.Errorf("refunded counter %d and card %d, want 10000 and 15000", counter.RefundedAmount, card.RefundedAmount)
+	}
+	if len(approved.Allocations) != 2 || approved.Allocations[0].ProviderRefundID != nil || approved.Allocations[1].ProviderRefundID == nil {
+		t.Errorf("allocations = %+v, want a cash and then a card refund", approved.Allocations)
+	}
+
+	if _, err := u.ApproveRefund(context.Background(), refund.ID, "admin_1", &request.ApproveRefundRequest{}); !errors.Is(err, utils.ErrRefundProcessed) {
+		t.Errorf("second approval error = %v, want %v", err, utils.ErrRefundProcessed)
+	}
+}
+
+func TestApproveRefundInvalidAmount(t *testing.T) {
+	u, repo, refund := refundFixture(t, payment.NewFakeProvider())
+
+	for _, amount := range []int64{0, -1, 30001} {
+		if _, err := u.ApproveRefund(context.Background(), refund.ID, "admin_1", &request.ApproveRefundRequest{Amount: &amount}); !errors.Is(err, utils.ErrInvalidRefundAmount) {
+			t.Errorf("amount %d: error = %v, want %v", amount, err, utils.ErrInvalidRefundAmount)
+		}
+	}
+	if stored := repo.refunds[refund.ID]; stored.Status != entity.RefundPending {
+		t.Errorf("status = %s after invalid approvals, want PENDING", stored.Status)
+	}
+}
+
+func TestApproveRefundDeclinedThenRetried(t *testing.T) {
+	provider := payment.NewFakeProvider()
+	u, repo, refund := refundFixture(t, provider)
+
+	provider.DeclineRefunds = true
+	if _, err := u.ApproveRefund(context.Background(), refund.ID, "admin_1", &request.ApproveRefundRequest{}); !errors.Is(err, utils.ErrRefundFailed) {
+		t.Fatalf("ApproveRefund error = %v, want %v", err, utils.ErrRefundFailed)
+	}
+	failed := repo.refunds[refund.ID]
+	if failed.Status != entity.RefundFailed || failed.RefundedAmount != 10000 || failed.FailureReason == "" {
+		t.Fatalf("refund = %s with %d refunded, want FAILED after the counter payment was refunded", failed.Status, failed.RefundedAmount)
+	}
+
+	provider.DeclineRefunds = false
+	retried, err := u.ApproveRefund(context.Background(), refund.ID, "admin_1", &request.ApproveRefundRequest{})
+	if err != nil {
+		t.Fatalf("retried ApproveRefund: %v", err)
+	}
+	if retried.Status != entity.RefundSucceeded || retried.RefundedAmount != 30000 {
+		t.Errorf("refund = %s with %d refunded, want SUCCEEDED with 30000", retried.Status, retried.RefundedAmount)
+	}
+	for _, p := range repo.payments {
+		if p.RefundedAmount != p.Amount {
+			t.Errorf("payment %s refunded %d of %d", p.Method, p.RefundedAmount, p.Amount)
+		}
+	}
+	if len(retried.Allocations) != 2 {
+		t.Errorf("allocations = %d, want one per payment", len(retried.Allocations))
+	}
+}
+
+func TestRejectRefundAfterApproval(t *testing.T) {
+	u, _, refund := refundFixture(t, payment.NewFakeProvider())
+
+	if _, err := u.ApproveRefund(context.Background(), refund.ID, "admin_1", &request.ApproveRefundRequest{}); err != nil {
+		t.Fatalf("ApproveRefund: %v", err)
+	}
+	if _, err := u.RejectRefund(context.Background(), refund.ID, "admin_2", &request.RejectRefundRequest{}); !errors.Is(err, utils.ErrRefundProcessed) {
+		t.Errorf("RejectRefund error = %v, want %v", err, utils.ErrRefundProcessed)
+	}
+}
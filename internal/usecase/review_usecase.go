@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReviewUsecase interface {
+	CreateReview(ctx context.Context, userID string, bookingID uuid.UUID, req *request.CreateReviewRequest) (*entity.Review, error)
+	GetReviewByID(ctx context.Context, id uuid.UUID) (*entity.Review, error)
+	GetAllReviews(ctx context.Context, filter *params.ReviewQueryParams) ([]entity.Review, *transport.PaginationResponse, error)
+	GetServiceReviews(ctx context.Context, serviceID uuid.UUID, filter *params.ReviewQueryParams) ([]entity.Review, *transport.PaginationResponse, error)
+	GetUserReviews(ctx context.Context, userID string, filter *params.ReviewQueryParams) ([]entity.Review, *transport.PaginationResponse, error)
+	ApproveReview(ctx context.Context, id uuid.UUID, adminID string, req *request.ModerateReviewRequest) (*entity.Review, error)
+	RejectReview(ctx context.Context, id uuid.UUID, adminID string, req *request.ModerateReviewRequest) (*entity.Review, error)
+	DeleteReview(ctx context.Context, id uuid.UUID) error
+}
+
+type reviewUsecase struct {
+	repo        repository.ReviewRepository
+	bookingRepo repository.BookingRepository
+}
+
+func NewReviewUsecase(repo repository.ReviewRepository, bookingRepo repository.BookingRepository) ReviewUsecase {
+	return &reviewUsecase{repo: repo, bookingRepo: bookingRepo}
+}
+
+// CreateReview lets the customer review their own booking once it is
+// COMPLETED. The review waits for an admin to approve it.
+func (u *reviewUsecase) CreateReview(ctx context.Context, userID string, bookingID uuid.UUID, req *request.CreateReviewRequest) (*entity.Review, error) {
+	if (req.StaffID == nil) != (req.StaffRating == nil) {
+		return nil, utils.ErrStaffRatingInvalid
+	}
+
+	booking, err := u.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if booking.Status != "COMPLETED" {
+		return nil, utils.ErrBookingNotCompleted
+	}
+
+	review := &entity.Review{
+		ID:          uuid.New(),
+		BookingID:   booking.ID,
+		UserID:      userID,
+		ServiceID:   booking.ServiceID,
+		BranchID:    booking.BranchID,
+		Rating:      req.Rating,
+		Comment:     req.Comment,
+		StaffID:     req.StaffID,
+		StaffRating: req.StaffRating,
+		Status:      entity.ReviewPending,
+	}
+	if err := u.repo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, review.ID)
+}
+
+func (u *reviewUsecase) GetReviewByID(ctx context.Context, id uuid.UUID) (*entity.Review, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *reviewUsecase) GetAllReviews(ctx context.Context, filter *params.ReviewQueryParams) ([]entity.Review, *transport.PaginationResponse, error) {
+	return u.repo.GetAll(ctx, filter)
+}
+
+// GetServiceReviews lists the approved reviews of a service.
+func (u *reviewUsecase) GetServiceReviews(ctx context.Context, serviceID uuid.UUID, filter *params.ReviewQueryParams) ([]entity.Review, *transport.PaginationResponse, error) {
+	filter.ServiceID = serviceID.String()
+	filter.Status = entity.ReviewApproved
+	return u.repo.GetAll(ctx, filter)
+}
+
+func (u *reviewUsecase) GetUserReviews(ctx context.Context, userID string, filter *params.ReviewQueryParams) ([]entity.Review, *transport.PaginationResponse, error) {
+	filter.UserID = userID
+	return u.repo.GetAll(ctx, filter)
+}
+
+func (u *reviewUsecase) ApproveReview(ctx context.Context, id uuid.UUID, adminID string, req *request.ModerateReviewRequest) (*entity.Review, error) {
+	return u.moderate(ctx, id, adminID, entity.ReviewApproved, req.Note)
+}
+
+func (u *reviewUsecase) RejectReview(ctx context.Context, id uuid.UUID, adminID string, req *request.ModerateReviewRequest) (*entity.Review, error) {
+	return u.moderate(ctx, id, adminID, entity.ReviewRejected, req.Note)
+}
+
+// moderate approves or rejects a review. Either decision can be reversed
+// later, e.g. to take down an approved review.
+func (u *reviewUsecase) moderate(ctx context.Context, id uuid.UUID, adminID, status, note string) (*entity.Review, error) {
+	if err := u.repo.Update(ctx, id, map[string]interface{}{
+		"status":          status,
+		"moderation_note": note,
+		"moderated_by":    adminID,
+		"moderated_at":    time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *reviewUsecase) DeleteReview(ctx context.Context, id uuid.UUID) error {
+	return u.repo.Delete(ctx, id)
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ServiceOptionUsecase interface {
+	CreateVariant(ctx context.Context, serviceID uuid.UUID, req *request.CreateServiceVariantRequest) (*entity.ServiceVariant, error)
+	GetVariants(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceVariant, error)
+	UpdateVariant(ctx context.Context, serviceID, id uuid.UUID, req *request.UpdateServiceVariantRequest) (*entity.ServiceVariant, error)
+	DeleteVariant(ctx context.Context, serviceID, id uuid.UUID) error
+	CreateAddOn(ctx context.Context, serviceID uuid.UUID, req *request.CreateServiceAddOnRequest) (*entity.ServiceAddOn, error)
+	GetAddOns(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceAddOn, error)
+	UpdateAddOn(ctx context.Context, serviceID, id uuid.UUID, req *request.UpdateServiceAddOnRequest) (*entity.ServiceAddOn, error)
+	DeleteAddOn(ctx context.Context, serviceID, id uuid.UUID) error
+}
+
+type serviceOptionUsecase struct {
+	repo repository.ServiceOptionRepository
+}
+
+func NewServiceOptionUsecase(repo repository.ServiceOptionRepository) ServiceOptionUsecase {
+	return &serviceOptionUsecase{repo: repo}
+}
+
+func (u *serviceOptionUsecase) CreateVariant(ctx context.Context, serviceID uuid.UUID, req *request.CreateServiceVariantRequest) (*entity.ServiceVariant, error) {
+	if err := u.checkService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	variant := &entity.ServiceVariant{
+		ID:             uuid.New(),
+		ServiceID:      serviceID,
+		Name:           req.Name,
+		DurationMinute: req.DurationMinute,
+		Price:          req.Price,
+		SortOrder:      req.SortOrder,
+		IsActive:       isActive,
+	}
+	if err := u.repo.CreateVariant(ctx, variant); err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func (u *serviceOptionUsecase) GetVariants(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceVariant, error) {
+	if err := u.checkService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetVariants(ctx, serviceID)
+}
+
+func (u *serviceOptionUsecase) UpdateVariant(ctx context.Context, serviceID, id uuid.UUID, req *request.UpdateServiceVariantRequest) (*entity.ServiceVariant, error) {
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.DurationMinute != nil {
+		updates["duration_minute"] = *req.DurationMinute
+	}
+	if req.Price != nil {
+		updates["price"] = *req.Price
+	}
+	if req.SortOrder != nil {
+		updates["sort_order"] = *req.SortOrder
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := u.repo.UpdateVariant(ctx, serviceID, id, updates); err != nil {
+			return nil, variantError(err)
+		}
+	}
+
+	variant, err := u.repo.GetVariant(ctx, serviceID, id)
+	if err != nil {
+		return nil, variantError(err)
+	}
+	return variant, nil
+}
+
+func (u *serviceOptionUsecase) DeleteVariant(ctx context.Context, serviceID, id uuid.UUID) error {
+	return variantError(u.repo.DeleteVariant(ctx, serviceID, id))
+}
+
+func (u *serviceOptionUsecase) CreateAddOn(ctx context.Context, serviceID uuid.UUID, req *request.CreateServiceAddOnRequest) (*entity.ServiceAddOn, error) {
+	if err := u.checkService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	addOn := &entity.ServiceAddOn{
+		ID:             uuid.New(),
+		ServiceID:      serviceID,
+		Name:           req.Name,
+		DurationMinute: req.DurationMinute,
+		Price:          req.Price,
+		SortOrder:      req.SortOrder,
+		IsActive:       isActive,
+	}
+	if err := u.repo.CreateAddOn(ctx, addOn); err != nil {
+		return nil, err
+	}
+
+	return addOn, nil
+}
+
+func (u *serviceOptionUsecase) GetAddOns(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceAddOn, error) {
+	if err := u.checkService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetAddOns(ctx, serviceID)
+}
+
+func (u *serviceOptionUsecase) UpdateAddOn(ctx context.Context, serviceID, id uuid.UUID, req *request.UpdateServiceAddOnRequest) (*entity.ServiceAddOn, error) {
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.DurationMinute != nil {
+		updates["duration_minute"] = *req.DurationMinute
+	}
+	if req.Price != nil {
+		updates["price"] = *req.Price
+	}
+	if req.SortOrder != nil {
+		updates["sort_order"] = *req.SortOrder
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := u.repo.UpdateAddOn(ctx, serviceID, id, updates); err != nil {
+			return nil, addOnError(err)
+		}
+	}
+
+	addOn, err := u.repo.GetAddOn(ctx, serviceID, id)
+	if err != nil {
+		return nil, addOnError(err)
+	}
+	return addOn, nil
+}
+
+func (u *serviceOptionUsecase) DeleteAddOn(ctx context.Context, serviceID, id uuid.UUID) error {
+	return addOnError(u.repo.DeleteAddOn(ctx, serviceID, id))
+}
+
+func (u *serviceOptionUsecase) checkService(ctx context.Context, serviceID uuid.UUID) error {
+	exists, err := u.repo.ServiceExists(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return utils.ErrServiceNotFound
+	}
+	return nil
+}
+
+func variantError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.ErrVariantNotFound
+	}
+	return err
+}
+
+func addOnError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.ErrAddOnNotFound
+	}
+	return err
+}
+
+// selectOptions resolves the variant and add-ons chosen for a booking against
+// the service, which must have its variants and add-ons loaded. A service with
+// active variants can only be booked as one of them.
+func selectOptions(service *entity.Service, variantID *uuid.UUID, addOnIDs []uuid.UUID) (*entity.ServiceVariant, []entity.ServiceAddOn, error) {
+	var variant *entity.ServiceVariant
+	hasVariants := false
+	for i := range service.Variants {
+		if !service.Variants[i].IsActive {
+			continue
+		}
+		hasVariants = true
+		if variantID != nil && service.Variants[i].ID == *variantID {
+			variant = &service.Variants[i]
+		}
+	}
+	if variantID != nil && variant == nil {
+		return nil, nil, utils.ErrVariantNotFound
+	}
+	if hasVariants && variant == nil {
+		return nil, nil, utils.ErrVariantRequired
+	}
+
+	addOns := make([]entity.ServiceAddOn, 0, len(addOnIDs))
+	seen := make(map[uuid.UUID]bool, len(addOnIDs))
+	for _, id := range addOnIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		found := false
+		for _, addOn := range service.AddOns {
+			if addOn.ID == id && addOn.IsActive {
+				addOns = append(addOns, addOn)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, utils.ErrAddOnNotFound
+		}
+	}
+
+	return variant, addOns, nil
+}
@@ -9,24 +9,39 @@ import (
 	"KaungHtetHein116/IVY-backend/api/v1/request"
 	"KaungHtetHein116/IVY-backend/internal/entity"
 	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/money"
+	"KaungHtetHein116/IVY-backend/utils"
 
 	"github.com/google/uuid"
 )
 
 type ServiceUsecase interface {
 	CreateService(ctx context.Context, req *request.CreateServiceRequest) (*entity.Service, error)
-	GetServiceByID(ctx context.Context, id uuid.UUID) (*entity.Service, error)
+	GetServiceByID(ctx context.Context, id uuid.UUID, branchID uuid.UUID) (*entity.Service, error)
 	GetAllServices(ctx context.Context, filter *params.ServiceQueryParams) ([]entity.Service, *transport.PaginationResponse, error)
+	SearchServices(ctx context.Context, filter *params.ServiceSearchQueryParams) ([]entity.Service, *transport.PaginationResponse, error)
+	SuggestServices(ctx context.Context, filter *params.ServiceSearchQueryParams) ([]entity.ServiceSuggestion, error)
 	UpdateService(ctx context.Context, id uuid.UUID, req *request.UpdateServiceRequest) (*entity.Service, error)
-	DeleteService(ctx context.Context, id uuid.UUID) error
+	GetDeleteImpact(ctx context.Context, id uuid.UUID) (*DeleteImpact, error)
+	DeleteService(ctx context.Context, id uuid.UUID, opts *params.DeleteQueryParams) (*DeleteImpact, error)
+	GetDeletedServices(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Service, *transport.PaginationResponse, error)
+	RestoreService(ctx context.Context, id uuid.UUID) (*entity.Service, error)
+	UploadServiceImage(ctx context.Context, id uuid.UUID, data []byte) (*entity.Service, error)
+	GetServiceVersions(ctx context.Context, id uuid.UUID) ([]entity.ServiceVersion, error)
 }
 
 type serviceUsecase struct {
-	repo repository.ServiceRepository
+	repo               repository.ServiceRepository
+	taxRateUsecase     TaxRateUsecase
+	imageUsecase       ImageUsecase
+	translationUsecase TranslationUsecase
+	safeguardUsecase   DeleteSafeguardUsecase
 }
 
-func NewServiceUsecase(repo repository.ServiceRepository) ServiceUsecase {
-	return &serviceUsecase{repo: repo}
+func NewServiceUsecase(repo repository.ServiceRepository, taxRateUsecase TaxRateUsecase, imageUsecase ImageUsecase, translationUsecase TranslationUsecase,
+	safeguardUsecase DeleteSafeguardUsecase) ServiceUsecase {
+	return &serviceUsecase{repo: repo, taxRateUsecase: taxRateUsecase, imageUsecase: imageUsecase, translationUsecase: translationUsecase,
+		safeguardUsecase: safeguardUsecase}
 }
 
 func (u *serviceUsecase) CreateService(ctx context.Context, req *request.CreateServiceRequest) (*entity.Service, error) {
@@ -43,27 +58,150 @@ func (u *serviceUsecase) CreateService(ctx context.Context, req *request.CreateS
 		Description:    req.Description,
 		DurationMinute: req.DurationMinute,
 		Price:          req.Price,
+		Currency:       money.NormalizeCurrency(req.Currency),
 		CategoryID:     req.CategoryID,
 		Branches:       branches,
 		Image:          req.Image,
 		IsActive:       req.IsActive,
 	}
 
-	err := u.repo.Create(ctx, service)
+	err := u.repo.Create(ctx, service, toBranchPrices(req.BranchPrices))
 	return service, err
 }
 
-func (u *serviceUsecase) GetServiceByID(ctx context.Context, id uuid.UUID) (*entity.Service, error) {
-	return u.repo.GetByID(ctx, id)
+func (u *serviceUsecase) GetServiceByID(ctx context.Context, id uuid.UUID, branchID uuid.UUID) (*entity.Service, error) {
+	service, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	services := []entity.Service{*service}
+	if branchID != uuid.Nil {
+		if err := u.applyBranchSettings(ctx, branchID, services); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.applyPriceBreakdowns(ctx, branchID, services); err != nil {
+		return nil, err
+	}
+	if err := u.translationUsecase.LocalizeServices(ctx, services); err != nil {
+		return nil, err
+	}
+
+	return &services[0], nil
 }
 
 func (u *serviceUsecase) GetAllServices(ctx context.Context, filter *params.ServiceQueryParams) ([]entity.Service, *transport.PaginationResponse, error) {
-	return u.repo.GetAll(ctx, filter)
+	services, pagination, err := u.repo.GetAll(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	branchID, err := uuid.Parse(filter.BranchID)
+	if err != nil {
+		branchID = uuid.Nil
+	}
+	if branchID != uuid.Nil {
+		if err := u.applyBranchSettings(ctx, branchID, services); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := u.applyPriceBreakdowns(ctx, branchID, services); err != nil {
+		return nil, nil, err
+	}
+	if err := u.translationUsecase.LocalizeServices(ctx, services); err != nil {
+		return nil, nil, err
+	}
+
+	return services, pagination, nil
+}
+
+// SearchServices ranks active services by how well their name, description
+// and category match the search text. Prices are those at the requested
+// branch, as in GetAllServices.
+func (u *serviceUsecase) SearchServices(ctx context.Context, filter *params.ServiceSearchQueryParams) ([]entity.Service, *transport.PaginationResponse, error) {
+	services, pagination, err := u.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	branchID, err := uuid.Parse(filter.BranchID)
+	if err != nil {
+		branchID = uuid.Nil
+	}
+	if branchID != uuid.Nil {
+		if err := u.applyBranchSettings(ctx, branchID, services); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := u.applyPriceBreakdowns(ctx, branchID, services); err != nil {
+		return nil, nil, err
+	}
+	if err := u.translationUsecase.LocalizeServices(ctx, services); err != nil {
+		return nil, nil, err
+	}
+	return services, pagination, nil
+}
+
+func (u *serviceUsecase) SuggestServices(ctx context.Context, filter *params.ServiceSearchQueryParams) ([]entity.ServiceSuggestion, error) {
+	suggestions, err := u.repo.Suggest(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.translationUsecase.LocalizeSuggestions(ctx, suggestions); err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+// applyBranchSettings fills EffectivePrice and EffectiveDurationMinute with
+// the price charged and the time taken at the given branch. Services not
+// offered at the branch are left untouched.
+func (u *serviceUsecase) applyBranchSettings(ctx context.Context, branchID uuid.UUID, services []entity.Service) error {
+	serviceIDs := make([]uuid.UUID, len(services))
+	for i, service := range services {
+		serviceIDs[i] = service.ID
+	}
+
+	branchServices, err := u.repo.GetBranchServices(ctx, branchID, serviceIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range services {
+		if bs, ok := branchServices[services[i].ID]; ok {
+			price := bs.EffectivePrice(services[i].Price)
+			duration := bs.EffectiveDuration(services[i].DurationMinute)
+			services[i].EffectivePrice = &price
+			services[i].EffectiveDurationMinute = &duration
+		}
+	}
+	return nil
+}
+
+// applyPriceBreakdowns fills PriceBreakdown using the tax rate of the branch
+// and service category. Without a branch only category and default rates apply.
+func (u *serviceUsecase) applyPriceBreakdowns(ctx context.Context, branchID uuid.UUID, services []entity.Service) error {
+	rates, err := u.taxRateUsecase.GetActiveRates(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range services {
+		price := services[i].Price
+		if services[i].EffectivePrice != nil {
+			price = *services[i].EffectivePrice
+		}
+		breakdown := rates.Breakdown(price, branchID, services[i].CategoryID)
+		services[i].PriceBreakdown = &breakdown
+	}
+	return nil
 }
 
 func (u *serviceUsecase) UpdateService(ctx context.Context, id uuid.UUID, req *request.UpdateServiceRequest) (*entity.Service, error) {
 	// Check if service exists
-	_, err := u.repo.GetByID(ctx, id)
+	existing, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -85,14 +223,22 @@ func (u *serviceUsecase) UpdateService(ctx context.Context, id uuid.UUID, req *r
 	if req.DurationMinute > 0 {
 		updates["duration_minute"] = req.DurationMinute
 	}
-	if req.Price >= 0 { // Allow zero price
-		updates["price"] = req.Price
+	// Zero is a valid price, so only a missing price leaves it as it is
+	if req.Price != nil {
+		updates["price"] = *req.Price
+	}
+	if req.Currency != "" {
+		updates["currency"] = money.NormalizeCurrency(req.Currency)
 	}
 	if req.CategoryID != uuid.Nil {
 		updates["category_id"] = req.CategoryID
 	}
-	if req.Image != "" {
+	// An image URL set by hand replaces the uploaded image
+	replacedImage := false
+	if req.Image != "" && req.Image != existing.Image {
 		updates["image"] = req.Image
+		updates["image_id"] = nil
+		replacedImage = existing.ImageID != nil
 	}
 
 	// Only include is_active if it was in the request
@@ -110,15 +256,123 @@ func (u *serviceUsecase) UpdateService(ctx context.Context, id uuid.UUID, req *r
 	}
 
 	// Update the service with only the provided fields
-	err = u.repo.Update(ctx, id, updates, branches)
+	err = u.repo.Update(ctx, id, updates, branches, toBranchPrices(req.BranchPrices))
 	if err != nil {
 		return nil, err
 	}
 
+	if replacedImage {
+		u.removeImage(ctx, *existing.ImageID)
+	}
+
 	// Fetch and return the updated service
 	return u.repo.GetByID(ctx, id)
 }
 
-func (u *serviceUsecase) DeleteService(ctx context.Context, id uuid.UUID) error {
-	return u.repo.Delete(ctx, id)
+func (u *serviceUsecase) GetDeleteImpact(ctx context.Context, id uuid.UUID) (*DeleteImpact, error) {
+	impact, err := u.deleteImpact(ctx, id)
+	return impact, err
+}
+
+// DeleteService moves the service to the trash, keeping its image for a
+// restore. By default it refuses while the service has upcoming bookings;
+// cancel cancels them along with the delete.
+func (u *serviceUsecase) DeleteService(ctx context.Context, id uuid.UUID, opts *params.DeleteQueryParams) (*DeleteImpact, error) {
+	strategy, err := deleteStrategy(opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	impact, err := u.deleteImpact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if strategy == DeleteStrategyBlock && impact.Blocked() {
+		return impact, utils.ErrDeleteBlocked
+	}
+
+	refundFor, err := u.safeguardUsecase.RefundFor(ctx, strategy)
+	if err != nil {
+		return nil, err
+	}
+	cancelled, err := u.repo.Delete(ctx, id, refundFor)
+	if err != nil {
+		return nil, err
+	}
+	u.safeguardUsecase.NotifyCancelled(ctx, cancelled)
+	return nil, nil
+}
+
+func (u *serviceUsecase) deleteImpact(ctx context.Context, id uuid.UUID) (*DeleteImpact, error) {
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	bookings, err := u.safeguardUsecase.GetServiceBookings(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteImpact{FutureBookings: len(bookings)}, nil
+}
+
+func (u *serviceUsecase) GetDeletedServices(ctx context.Context, filter *params.TrashQueryParams) ([]entity.Service, *transport.PaginationResponse, error) {
+	return u.repo.GetDeleted(ctx, filter)
+}
+
+func (u *serviceUsecase) RestoreService(ctx context.Context, id uuid.UUID) (*entity.Service, error) {
+	if err := u.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+// GetServiceVersions returns the catalog history of the service, including
+// one that has been moved to the trash.
+func (u *serviceUsecase) GetServiceVersions(ctx context.Context, id uuid.UUID) ([]entity.ServiceVersion, error) {
+	if _, err := u.repo.GetByIDWithDeleted(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.repo.GetVersions(ctx, id)
+}
+
+// UploadServiceImage stores the uploaded image with its thumbnails, shows it
+// on the service and removes the image it replaces.
+func (u *serviceUsecase) UploadServiceImage(ctx context.Context, id uuid.UUID, data []byte) (*entity.Service, error) {
+	service, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := u.imageUsecase.Upload(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{"image": image.URL, "image_id": image.ID}
+	if err := u.repo.Update(ctx, id, updates, nil, nil); err != nil {
+		u.removeImage(ctx, image.ID)
+		return nil, err
+	}
+
+	if service.ImageID != nil {
+		u.removeImage(ctx, *service.ImageID)
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+// removeImage deletes an image nothing points at any more. If that fails the
+// orphan cleanup job removes it later, so the error is not returned.
+func (u *serviceUsecase) removeImage(ctx context.Context, imageID uuid.UUID) {
+	_ = u.imageUsecase.Remove(context.WithoutCancel(ctx), imageID)
+}
+
+func toBranchPrices(reqs []request.BranchPriceRequest) []entity.BranchService {
+	branchPrices := make([]entity.BranchService, len(reqs))
+	for i, bp := range reqs {
+		branchPrices[i] = entity.BranchService{
+			BranchID:      bp.BranchID,
+			PriceOverride: bp.Price,
+		}
+	}
+	return branchPrices
 }
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/api/v1/params"
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/tax"
+
+	"github.com/google/uuid"
+)
+
+type TaxRateUsecase interface {
+	CreateTaxRate(ctx context.Context, req *request.CreateTaxRateRequest) (*entity.TaxRate, error)
+	GetTaxRateByID(ctx context.Context, id uuid.UUID) (*entity.TaxRate, error)
+	GetAllTaxRates(ctx context.Context, filter *params.TaxRateQueryParams) ([]entity.TaxRate, *transport.PaginationResponse, error)
+	UpdateTaxRate(ctx context.Context, id uuid.UUID, req *request.UpdateTaxRateRequest) (*entity.TaxRate, error)
+	DeleteTaxRate(ctx context.Context, id uuid.UUID) error
+	GetActiveRates(ctx context.Context) (TaxRates, error)
+}
+
+// TaxRates is the set of active rates, loaded once to price many lines.
+type TaxRates []entity.TaxRate
+
+// Breakdown applies the most specific rate for the branch and category to
+// amount. Pass uuid.Nil as branchID when no branch is known.
+func (rates TaxRates) Breakdown(amount int64, branchID, categoryID uuid.UUID) tax.Breakdown {
+	var match *entity.TaxRate
+	best := -1
+	for i := range rates {
+		if score := rates[i].Matches(branchID, categoryID); score > best {
+			best = score
+			match = &rates[i]
+		}
+	}
+
+	if match == nil {
+		return tax.Calculate(amount, 0, false)
+	}
+	return tax.Calculate(amount, match.Rate, match.Inclusive)
+}
+
+type taxRateUsecase struct {
+	repo repository.TaxRateRepository
+}
+
+func NewTaxRateUsecase(repo repository.TaxRateRepository) TaxRateUsecase {
+	return &taxRateUsecase{repo: repo}
+}
+
+func (u *taxRateUsecase) CreateTaxRate(ctx context.Context, req *request.CreateTaxRateRequest) (*entity.TaxRate, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	taxRate := &entity.TaxRate{
+		ID:         uuid.New(),
+		Name:       req.Name,
+		BranchID:   req.BranchID,
+		CategoryID: req.CategoryID,
+		Rate:       req.Rate,
+		Inclusive:  req.Inclusive,
+		IsActive:   isActive,
+	}
+	if err := u.repo.Create(ctx, taxRate); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, taxRate.ID)
+}
+
+func (u *taxRateUsecase) GetTaxRateByID(ctx context.Context, id uuid.UUID) (*entity.TaxRate, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *taxRateUsecase) GetAllTaxRates(ctx context.Context, filter *params.TaxRateQueryParams) ([]entity.TaxRate, *transport.PaginationResponse, error) {
+	return u.repo.GetAll(ctx, filter)
+}
+
+func (u *taxRateUsecase) UpdateTaxRate(ctx context.Context, id uuid.UUID, req *request.UpdateTaxRateRequest) (*entity.TaxRate, error) {
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Rate != nil {
+		updates["rate"] = *req.Rate
+	}
+	if req.Inclusive != nil {
+		updates["inclusive"] = *req.Inclusive
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := u.repo.Update(ctx, id, updates); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *taxRateUsecase) DeleteTaxRate(ctx context.Context, id uuid.UUID) error {
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *taxRateUsecase) GetActiveRates(ctx context.Context) (TaxRates, error) {
+	return u.repo.GetActive(ctx)
+}
@@ -0,0 +1,351 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"KaungHtetHein116/IVY-backend/api/v1/request"
+	"KaungHtetHein116/IVY-backend/config"
+	"KaungHtetHein116/IVY-backend/internal/entity"
+	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/locale"
+	"KaungHtetHein116/IVY-backend/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TranslationUsecase manages the translations of the catalog and swaps them
+// into services, categories and branches for the locale of the request.
+type TranslationUsecase interface {
+	GetServiceTranslations(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceTranslation, error)
+	SaveServiceTranslation(ctx context.Context, serviceID uuid.UUID, code string, req *request.ServiceTranslationRequest) (*entity.ServiceTranslation, error)
+	DeleteServiceTranslation(ctx context.Context, serviceID uuid.UUID, code string) error
+	GetCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]entity.CategoryTranslation, error)
+	SaveCategoryTranslation(ctx context.Context, categoryID uuid.UUID, code string, req *request.CategoryTranslationRequest) (*entity.CategoryTranslation, error)
+	DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, code string) error
+	GetBranchTranslations(ctx context.Context, branchID uuid.UUID) ([]entity.BranchTranslation, error)
+	SaveBranchTranslation(ctx context.Context, branchID uuid.UUID, code string, req *request.BranchTranslationRequest) (*entity.BranchTranslation, error)
+	DeleteBranchTranslation(ctx context.Context, branchID uuid.UUID, code string) error
+
+	LocalizeServices(ctx context.Context, services []entity.Service) error
+	LocalizeCategories(ctx context.Context, categories []entity.Category) error
+	LocalizeBranches(ctx context.Context, branches []entity.Branch) error
+	LocalizeSuggestions(ctx context.Context, suggestions []entity.ServiceSuggestion) error
+}
+
+type translationUsecase struct {
+	repo repository.TranslationRepository
+}
+
+func NewTranslationUsecase(repo repository.TranslationRepository) TranslationUsecase {
+	return &translationUsecase{repo: repo}
+}
+
+func (u *translationUsecase) GetServiceTranslations(ctx context.Context, serviceID uuid.UUID) ([]entity.ServiceTranslation, error) {
+	if err := u.checkExists(ctx, &entity.Service{}, serviceID, utils.ErrServiceNotFound); err != nil {
+		return nil, err
+	}
+	return u.repo.GetServiceTranslations(ctx, serviceID)
+}
+
+func (u *translationUsecase) SaveServiceTranslation(ctx context.Context, serviceID uuid.UUID, code string, req *request.ServiceTranslationRequest) (*entity.ServiceTranslation, error) {
+	if err := checkTranslationLocale(code); err != nil {
+		return nil, err
+	}
+	if err := u.checkExists(ctx, &entity.Service{}, serviceID, utils.ErrServiceNotFound); err != nil {
+		return nil, err
+	}
+
+	translation := &entity.ServiceTranslation{
+		ID:          uuid.New(),
+		ServiceID:   serviceID,
+		Locale:      code,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err := u.repo.SaveServiceTranslation(ctx, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (u *translationUsecase) DeleteServiceTranslation(ctx context.Context, serviceID uuid.UUID, code string) error {
+	return translationDeleteError(u.repo.DeleteServiceTranslation(ctx, serviceID, code))
+}
+
+func (u *translationUsecase) GetCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]entity.CategoryTranslation, error) {
+	if err := u.checkExists(ctx, &entity.Category{}, categoryID, utils.ErrCategoryNotFound); err != nil {
+		return nil, err
+	}
+	return u.repo.GetCategoryTranslations(ctx, categoryID)
+}
+
+func (u *translationUsecase) SaveCategoryTranslation(ctx context.Context, categoryID uuid.UUID, code string, req *request.CategoryTranslationRequest) (*entity.CategoryTranslation, error) {
+	if err := checkTranslationLocale(code); err != nil {
+		return nil, err
+	}
+	if err := u.checkExists(ctx, &entity.Category{}, categoryID, utils.ErrCategoryNotFound); err != nil {
+		return nil, err
+	}
+
+	translation := &entity.CategoryTranslation{
+		ID:         uuid.New(),
+		CategoryID: categoryID,
+		Locale:     code,
+		Name:       req.Name,
+	}
+	if err := u.repo.SaveCategoryTranslation(ctx, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (u *translationUsecase) DeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, code string) error {
+	return translationDeleteError(u.repo.DeleteCategoryTranslation(ctx, categoryID, code))
+}
+
+func (u *translationUsecase) GetBranchTranslations(ctx context.Context, branchID uuid.UUID) ([]entity.BranchTranslation, error) {
+	if err := u.checkExists(ctx, &entity.Branch{}, branchID, utils.ErrBranchNotFound); err != nil {
+		return nil, err
+	}
+	return u.repo.GetBranchTranslations(ctx, branchID)
+}
+
+func (u *translationUsecase) SaveBranchTranslation(ctx context.Context, branchID uuid.UUID, code string, req *request.BranchTranslationRequest) (*entity.BranchTranslation, error) {
+	if err := checkTranslationLocale(code); err != nil {
+		return nil, err
+	}
+	if err := u.checkExists(ctx, &entity.Branch{}, branchID, utils.ErrBranchNotFound); err != nil {
+		return nil, err
+	}
+
+	translation := &entity.BranchTranslation{
+		ID:       uuid.New(),
+		BranchID: branchID,
+		Locale:   code,
+		Name:     req.Name,
+		Location: req.Location,
+	}
+	if err := u.repo.SaveBranchTranslation(ctx, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (u *translationUsecase) DeleteBranchTranslation(ctx context.Context, branchID uuid.UUID, code string) error {
+	return translationDeleteError(u.repo.DeleteBranchTranslation(ctx, branchID, code))
+}
+
+// LocalizeServices replaces the names and descriptions of the services, and
+// of their category and branches when loaded, with their translations for
+// the request's locale. Anything without a translation keeps its own text.
+func (u *translationUsecase) LocalizeServices(ctx context.Context, services []entity.Service) error {
+	code, ok := requestLocale(ctx)
+	if !ok || len(services) == 0 {
+		return nil
+	}
+
+	serviceIDs := make([]uuid.UUID, 0, len(services))
+	var categories []*entity.Category
+	var branches []*entity.Branch
+	for i := range services {
+		serviceIDs = append(serviceIDs, services[i].ID)
+		if services[i].Category.ID != uuid.Nil {
+			categories = append(categories, &services[i].Category)
+		}
+		for j := range services[i].Branches {
+			branches = append(branches, &services[i].Branches[j])
+		}
+	}
+
+	translations, err := u.repo.FindServiceTranslations(ctx, code, serviceIDs)
+	if err != nil {
+		return err
+	}
+	byService := make(map[uuid.UUID]entity.ServiceTranslation, len(translations))
+	for _, translation := range translations {
+		byService[translation.ServiceID] = translation
+	}
+	for i := range services {
+		if translation, ok := byService[services[i].ID]; ok {
+			services[i].Name = translatedOr(translation.Name, services[i].Name)
+			services[i].Description = translatedOr(translation.Description, services[i].Description)
+		}
+	}
+
+	if err := u.localizeCategories(ctx, code, categories); err != nil {
+		return err
+	}
+	return u.localizeBranches(ctx, code, branches)
+}
+
+// LocalizeCategories translates the category names, including those of
+// nested subcategories.
+func (u *translationUsecase) LocalizeCategories(ctx context.Context, categories []entity.Category) error {
+	code, ok := requestLocale(ctx)
+	if !ok {
+		return nil
+	}
+
+	var all []*entity.Category
+	var collect func(categories []entity.Category)
+	collect = func(categories []entity.Category) {
+		for i := range categories {
+			all = append(all, &categories[i])
+			collect(categories[i].Children)
+		}
+	}
+	collect(categories)
+
+	return u.localizeCategories(ctx, code, all)
+}
+
+func (u *translationUsecase) LocalizeBranches(ctx context.Context, branches []entity.Branch) error {
+	code, ok := requestLocale(ctx)
+	if !ok {
+		return nil
+	}
+
+	all := make([]*entity.Branch, len(branches))
+	for i := range branches {
+		all[i] = &branches[i]
+	}
+	return u.localizeBranches(ctx, code, all)
+}
+
+func (u *translationUsecase) LocalizeSuggestions(ctx context.Context, suggestions []entity.ServiceSuggestion) error {
+	code, ok := requestLocale(ctx)
+	if !ok || len(suggestions) == 0 {
+		return nil
+	}
+
+	serviceIDs := make([]uuid.UUID, len(suggestions))
+	categoryIDs := make([]uuid.UUID, len(suggestions))
+	for i, suggestion := range suggestions {
+		serviceIDs[i] = suggestion.ID
+		categoryIDs[i] = suggestion.CategoryID
+	}
+
+	serviceTranslations, err := u.repo.FindServiceTranslations(ctx, code, serviceIDs)
+	if err != nil {
+		return err
+	}
+	categoryTranslations, err := u.repo.FindCategoryTranslations(ctx, code, categoryIDs)
+	if err != nil {
+		return err
+	}
+
+	serviceNames := make(map[uuid.UUID]string, len(serviceTranslations))
+	for _, translation := range serviceTranslations {
+		serviceNames[translation.ServiceID] = translation.Name
+	}
+	categoryNames := make(map[uuid.UUID]string, len(categoryTranslations))
+	for _, translation := range categoryTranslations {
+		categoryNames[translation.CategoryID] = translation.Name
+	}
+	for i := range suggestions {
+		suggestions[i].Name = translatedOr(serviceNames[suggestions[i].ID], suggestions[i].Name)
+		suggestions[i].CategoryName = translatedOr(categoryNames[suggestions[i].CategoryID], suggestions[i].CategoryName)
+	}
+	return nil
+}
+
+func (u *translationUsecase) localizeCategories(ctx context.Context, code string, categories []*entity.Category) error {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+	translations, err := u.repo.FindCategoryTranslations(ctx, code, ids)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[uuid.UUID]string, len(translations))
+	for _, translation := range translations {
+		names[translation.CategoryID] = translation.Name
+	}
+	for _, category := range categories {
+		category.Name = translatedOr(names[category.ID], category.Name)
+	}
+	return nil
+}
+
+func (u *translationUsecase) localizeBranches(ctx context.Context, code string, branches []*entity.Branch) error {
+	if len(branches) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(branches))
+	for i, branch := range branches {
+		ids[i] = branch.ID
+	}
+	translations, err := u.repo.FindBranchTranslations(ctx, code, ids)
+	if err != nil {
+		return err
+	}
+
+	byBranch := make(map[uuid.UUID]entity.BranchTranslation, len(translations))
+	for _, translation := range translations {
+		byBranch[translation.BranchID] = translation
+	}
+	for _, branch := range branches {
+		if translation, ok := byBranch[branch.ID]; ok {
+			branch.Name = translatedOr(translation.Name, branch.Name)
+			branch.Location = translatedOr(translation.Location, branch.Location)
+		}
+	}
+	return nil
+}
+
+func (u *translationUsecase) checkExists(ctx context.Context, model interface{}, id uuid.UUID, notFound error) error {
+	exists, err := u.repo.Exists(ctx, model, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return notFound
+	}
+	return nil
+}
+
+// requestLocale returns the locale negotiated for the request, and false
+// when it is the default locale the catalog is written in.
+func requestLocale(ctx context.Context) (string, bool) {
+	code := locale.FromContext(ctx)
+	if code == "" || code == config.DefaultLocale() {
+		return "", false
+	}
+	return code, true
+}
+
+// checkTranslationLocale allows translations only into the supported
+// locales other than the default one.
+func checkTranslationLocale(code string) error {
+	if !locale.IsSupported(code) {
+		return utils.ErrUnsupportedLocale
+	}
+	if code == config.DefaultLocale() {
+		return utils.ErrDefaultLocale
+	}
+	return nil
+}
+
+func translationDeleteError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.ErrTranslationNotFound
+	}
+	return err
+}
+
+// translatedOr returns translated unless it is empty.
+func translatedOr(translated, original string) string {
+	if translated == "" {
+		return original
+	}
+	return translated
+}
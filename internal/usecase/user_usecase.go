@@ -6,8 +6,12 @@ import (
 	"KaungHtetHein116/IVY-backend/api/v1/request"
 	"KaungHtetHein116/IVY-backend/internal/entity"
 	"KaungHtetHein116/IVY-backend/internal/repository"
+	"KaungHtetHein116/IVY-backend/pkg/scope"
 	"KaungHtetHein116/IVY-backend/utils"
 	"context"
+	"errors"
+
+	"gorm.io/gorm"
 )
 
 type UserUsecase interface {
@@ -16,15 +20,18 @@ type UserUsecase interface {
 	UpdateUser(c context.Context, userID string, req *request.UserUpdateRequest) error
 	HandleClerkWebhook(c context.Context, req *request.ClerkWebhookRequest) error
 	GetUserByID(c context.Context, userID string) (*entity.User, error)
+	GetAccess(c context.Context, userID string) (string, scope.Scope, error)
 }
 
 type userUsecase struct {
-	userRepo repository.UserRepository
+	userRepo   repository.UserRepository
+	memberRepo repository.BranchMemberRepository
 }
 
-func NewUserUsecase(userRepo repository.UserRepository) UserUsecase {
+func NewUserUsecase(userRepo repository.UserRepository, memberRepo repository.BranchMemberRepository) UserUsecase {
 	return &userUsecase{
-		userRepo: userRepo,
+		userRepo:   userRepo,
+		memberRepo: memberRepo,
 	}
 }
 
@@ -127,3 +134,31 @@ func (u *userUsecase) UpdateUser(c context.Context, userID string, req *request.
 	// Get existing user
 	return err
 }
+
+// GetAccess returns the user's role and the scope of what they may see:
+// admins see everything, staff and branch managers their branches, and
+// customers their own bookings. Users the Clerk webhook hasn't synced yet
+// are customers.
+func (u *userUsecase) GetAccess(c context.Context, userID string) (string, scope.Scope, error) {
+	role := entity.RoleUser
+	user, err := u.userRepo.GetUserByID(c, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", scope.Scope{}, err
+	}
+	if err == nil && user.Role != nil {
+		role = *user.Role
+	}
+
+	switch {
+	case role == entity.RoleAdmin:
+		return role, scope.Scope{}, nil
+	case entity.IsBranchRole(role):
+		branchIDs, err := u.memberRepo.GetBranchIDs(c, userID)
+		if err != nil {
+			return "", scope.Scope{}, err
+		}
+		return role, scope.Scope{UserID: userID, Branches: true, BranchIDs: branchIDs}, nil
+	default:
+		return role, scope.Scope{UserID: userID}, nil
+	}
+}
@@ -0,0 +1,562 @@
+package i18n
+
+import "KaungHtetHein116/IVY-backend/pkg/locale"
+
+// Response message codes.
+const (
+	CodeValidationFailed       = "VALIDATION_FAILED"
+	CodeInvalidRequest         = "INVALID_REQUEST"
+	CodeInvalidQuery           = "INVALID_QUERY"
+	CodeUnauthorized           = "UNAUTHORIZED"
+	CodeInvalidToken           = "INVALID_TOKEN"
+	CodeForbidden              = "FORBIDDEN"
+	CodeRecordNotFound         = "RECORD_NOT_FOUND"
+	CodeDuplicateEntry         = "DUPLICATE_ENTRY"
+	CodeEmailAlreadyRegistered = "EMAIL_ALREADY_REGISTERED"
+	CodeUserNotFound           = "USER_NOT_FOUND"
+	CodeInternalError          = "INTERNAL_ERROR"
+	CodeSuccessful             = "SUCCESSFUL"
+
+	CodeInvalidID = "INVALID_ID"
+	CodeNotFound  = "NOT_FOUND"
+	CodeRetrieved = "RETRIEVED"
+	CodeCreated   = "CREATED"
+	CodeUpdated   = "UPDATED"
+	CodeDeleted   = "DELETED"
+	CodeRestored  = "RESTORED"
+	CodeSaved     = "SAVED"
+	CodeUploaded  = "UPLOADED"
+	CodeCancelled = "CANCELLED"
+)
+
+// Error codes of the sentinel errors in utils, see utils.ErrorCode.
+const (
+	CodeInvalidData                = "INVALID_DATA"
+	CodeDatabaseError              = "DATABASE_ERROR"
+	CodeInvalidCredentials         = "INVALID_CREDENTIALS"
+	CodeForeignKeyViolation        = "FOREIGN_KEY_VIOLATION"
+	CodeUniqueConstraint           = "UNIQUE_CONSTRAINT"
+	CodeCheckConstraint            = "CHECK_CONSTRAINT"
+	CodeNotNullConstraint          = "NOT_NULL_CONSTRAINT"
+	CodeTransactionConflict        = "TRANSACTION_CONFLICT"
+	CodeDeadlock                   = "DEADLOCK"
+	CodeLockTimeout                = "LOCK_TIMEOUT"
+	CodeConnectionFailed           = "CONNECTION_FAILED"
+	CodeConnectionTimeout          = "CONNECTION_TIMEOUT"
+	CodeTooManyConnections         = "TOO_MANY_CONNECTIONS"
+	CodeInvalidDatabaseQuery       = "INVALID_DATABASE_QUERY"
+	CodeQueryTimeout               = "QUERY_TIMEOUT"
+	CodeRowScanError               = "ROW_SCAN_ERROR"
+	CodeBranchNotFound             = "BRANCH_NOT_FOUND"
+	CodeServiceNotFound            = "SERVICE_NOT_FOUND"
+	CodeCategoryNotFound           = "CATEGORY_NOT_FOUND"
+	CodeAlreadyBooked              = "ALREADY_BOOKED"
+	CodeBookingCancelled           = "BOOKING_CANCELLED"
+	CodeBookingCompleted           = "BOOKING_COMPLETED"
+	CodeBookingHasPayments         = "BOOKING_HAS_PAYMENTS"
+	CodeServiceNotAtBranch         = "SERVICE_NOT_AT_BRANCH"
+	CodeSlotFull                   = "SLOT_FULL"
+	CodePromotionNotFound          = "PROMOTION_NOT_FOUND"
+	CodePromotionInactive          = "PROMOTION_INACTIVE"
+	CodePromotionNotApplicable     = "PROMOTION_NOT_APPLICABLE"
+	CodePromotionUsageLimitReached = "PROMOTION_USAGE_LIMIT_REACHED"
+	CodePromotionInvalid           = "PROMOTION_INVALID"
+	CodePackageNotFound            = "PACKAGE_NOT_FOUND"
+	CodePackageCreditNotFound      = "PACKAGE_CREDIT_NOT_FOUND"
+	CodePackageCreditExhausted     = "PACKAGE_CREDIT_EXHAUSTED"
+	CodeGiftCardNotFound           = "GIFT_CARD_NOT_FOUND"
+	CodeGiftCardUnusable           = "GIFT_CARD_UNUSABLE"
+	CodeRedemptionConflict         = "REDEMPTION_CONFLICT"
+	CodeInsufficientPoints         = "INSUFFICIENT_POINTS"
+	CodeInvalidPoints              = "INVALID_POINTS"
+	CodeInvoiceNotAvailable        = "INVOICE_NOT_AVAILABLE"
+	CodeTaxRateConflict            = "TAX_RATE_CONFLICT"
+	CodePricingRuleInvalid         = "PRICING_RULE_INVALID"
+	CodePriceChanged               = "PRICE_CHANGED"
+	CodeMembershipPlanNotFound     = "MEMBERSHIP_PLAN_NOT_FOUND"
+	CodeMembershipPlanInUse        = "MEMBERSHIP_PLAN_IN_USE"
+	CodeSubscriptionNotFound       = "SUBSCRIPTION_NOT_FOUND"
+	CodeAlreadySubscribed          = "ALREADY_SUBSCRIBED"
+	CodeMembershipCreditsExhausted = "MEMBERSHIP_CREDITS_EXHAUSTED"
+	CodePaymentDeclined            = "PAYMENT_DECLINED"
+	CodeBookingNotPayable          = "BOOKING_NOT_PAYABLE"
+	CodeInvalidPaymentAmount       = "INVALID_PAYMENT_AMOUNT"
+	CodeRefundNotFound             = "REFUND_NOT_FOUND"
+	CodeRefundProcessed            = "REFUND_PROCESSED"
+	CodeInvalidRefundAmount        = "INVALID_REFUND_AMOUNT"
+	CodeRefundFailed               = "REFUND_FAILED"
+	CodeVariantNotFound            = "VARIANT_NOT_FOUND"
+	CodeVariantRequired            = "VARIANT_REQUIRED"
+	CodeAddOnNotFound              = "ADD_ON_NOT_FOUND"
+	CodeParentCategoryNotFound     = "PARENT_CATEGORY_NOT_FOUND"
+	CodeCategoryCycle              = "CATEGORY_CYCLE"
+	CodeCategoryHasChildren        = "CATEGORY_HAS_CHILDREN"
+	CodeCategoryNotSibling         = "CATEGORY_NOT_SIBLING"
+	CodeParentCategoryInTrash      = "PARENT_CATEGORY_IN_TRASH"
+	CodeCategoryInTrash            = "CATEGORY_IN_TRASH"
+	CodeDeleteBlocked              = "DELETE_BLOCKED"
+	CodeInvalidDeleteStrategy      = "INVALID_DELETE_STRATEGY"
+	CodeReassignNotAllowed         = "REASSIGN_NOT_ALLOWED"
+	CodeReassignTargetInvalid      = "REASSIGN_TARGET_INVALID"
+	CodeProductNotFound            = "PRODUCT_NOT_FOUND"
+	CodeProductInUse               = "PRODUCT_IN_USE"
+	CodeInsufficientStock          = "INSUFFICIENT_STOCK"
+	CodeInvalidStockChange         = "INVALID_STOCK_CHANGE"
+	CodeReviewNotFound             = "REVIEW_NOT_FOUND"
+	CodeReviewExists               = "REVIEW_EXISTS"
+	CodeBookingNotCompleted        = "BOOKING_NOT_COMPLETED"
+	CodeStaffRatingInvalid         = "STAFF_RATING_INVALID"
+	CodeNotBranchStaff             = "NOT_BRANCH_STAFF"
+	CodeNotBranchRole              = "NOT_BRANCH_ROLE"
+	CodeInvalidCoordinates         = "INVALID_COORDINATES"
+	CodeInvalidRadius              = "INVALID_RADIUS"
+	CodeImageTooLarge              = "IMAGE_TOO_LARGE"
+	CodeUnsupportedImageType       = "UNSUPPORTED_IMAGE_TYPE"
+	CodeUnsupportedLocale          = "UNSUPPORTED_LOCALE"
+	CodeDefaultLocale              = "DEFAULT_LOCALE"
+	CodeTranslationNotFound        = "TRANSLATION_NOT_FOUND"
+)
+
+// Codes of the other fixed messages handlers answer with.
+const (
+	CodeUserIDRequired           = "USER_ID_REQUIRED"
+	CodeProfileNotEditable       = "PROFILE_NOT_EDITABLE"
+	CodeCategoryExists           = "CATEGORY_EXISTS"
+	CodeProductSKUExists         = "PRODUCT_SKU_EXISTS"
+	CodePromotionCodeExists      = "PROMOTION_CODE_EXISTS"
+	CodeLoyaltyRuleExists        = "LOYALTY_RULE_EXISTS"
+	CodeImageRequired            = "IMAGE_REQUIRED"
+	CodeSearchTextRequired       = "SEARCH_TEXT_REQUIRED"
+	CodeSlotQueryRequired        = "SLOT_QUERY_REQUIRED"
+	CodeBookingOptionUnavailable = "BOOKING_OPTION_UNAVAILABLE"
+
+	CodeBookingPaid           = "BOOKING_PAID"
+	CodeSubscribed            = "SUBSCRIBED"
+	CodeSubscriptionCancelled = "SUBSCRIPTION_CANCELLED"
+	CodePackageSold           = "PACKAGE_SOLD"
+	CodeGiftCardIssued        = "GIFT_CARD_ISSUED"
+	CodePointsAdjusted        = "POINTS_ADJUSTED"
+	CodeRefundIssued          = "REFUND_ISSUED"
+	CodeRefundRejected        = "REFUND_REJECTED"
+	CodeCategoriesReordered   = "CATEGORIES_REORDERED"
+	CodeWebhookReceived       = "WEBHOOK_RECEIVED"
+)
+
+// Validation message codes, one per validator tag. Min and max read
+// differently for text, lists and numbers.
+const (
+	CodeFieldRequired = "validation.required"
+	CodeFieldMin      = "validation.min"
+	CodeFieldMinLen   = "validation.min.length"
+	CodeFieldMinItems = "validation.min.items"
+	CodeFieldMax      = "validation.max"
+	CodeFieldMaxLen   = "validation.max.length"
+	CodeFieldMaxItems = "validation.max.items"
+	CodeFieldEmail    = "validation.email"
+	CodeFieldOneOf    = "validation.oneof"
+	CodeFieldUUID     = "validation.uuid"
+	CodeFieldCurrency = "validation.iso4217"
+	CodeFieldDatetime = "validation.datetime"
+	CodeFieldNumber   = "validation.number"
+	CodeFieldBoolean  = "validation.boolean"
+	CodeFieldInvalid  = "validation.invalid"
+)
+
+// Notification message codes.
+const (
+	CodeNotifyBookingCancelled = "notification.booking_cancelled"
+)
+
+var catalog = map[string]map[string]string{
+	locale.English: {
+		CodeValidationFailed:       "Validation failed",
+		CodeInvalidRequest:         "Invalid request format",
+		CodeInvalidQuery:           "Invalid query parameters",
+		CodeUnauthorized:           "Unauthorized",
+		CodeInvalidToken:           "Invalid Token",
+		CodeForbidden:              "You do not have permission to do this",
+		CodeRecordNotFound:         "Record not found",
+		CodeDuplicateEntry:         "Duplicated entry found.",
+		CodeEmailAlreadyRegistered: "Email is already registered.",
+		CodeUserNotFound:           "User not found",
+		CodeInternalError:          "Something went wrong",
+		CodeSuccessful:             "Successful",
+
+		CodeInvalidID: "Invalid {resource} ID",
+		CodeNotFound:  "{resource} not found",
+		CodeRetrieved: "{resource} retrieved successfully",
+		CodeCreated:   "{resource} created successfully",
+		CodeUpdated:   "{resource} updated successfully",
+		CodeDeleted:   "{resource} deleted successfully",
+		CodeRestored:  "{resource} restored successfully",
+		CodeSaved:     "{resource} saved successfully",
+		CodeUploaded:  "{resource} uploaded successfully",
+		CodeCancelled: "{resource} cancelled successfully",
+
+		CodeInvalidData:                "Invalid data provided",
+		CodeDatabaseError:              "Database error",
+		CodeInvalidCredentials:         "Invalid credentials",
+		CodeForeignKeyViolation:        "A related record does not exist",
+		CodeUniqueConstraint:           "A value that must be unique is already taken",
+		CodeCheckConstraint:            "A value is outside what is allowed",
+		CodeNotNullConstraint:          "A required value is missing",
+		CodeTransactionConflict:        "The request conflicted with another one, please try again",
+		CodeDeadlock:                   "The request conflicted with another one, please try again",
+		CodeLockTimeout:                "The record is busy, please try again",
+		CodeConnectionFailed:           "The database is unavailable",
+		CodeConnectionTimeout:          "The database took too long to answer",
+		CodeTooManyConnections:         "The server is busy, please try again",
+		CodeInvalidDatabaseQuery:       "The database query is invalid",
+		CodeQueryTimeout:               "The database took too long to answer",
+		CodeRowScanError:               "A record could not be read",
+		CodeBranchNotFound:             "Branch not found",
+		CodeServiceNotFound:            "Service not found",
+		CodeCategoryNotFound:           "Category not found",
+		CodeAlreadyBooked:              "You already have a booking for this service at this time. If you wish to book, please cancel the first booking.",
+		CodeBookingCancelled:           "Cancelled bookings cannot be reopened",
+		CodeBookingCompleted:           "Completed bookings cannot be cancelled or deleted",
+		CodeBookingHasPayments:         "The booking has payments, cancel it so they are refunded",
+		CodeServiceNotAtBranch:         "The service is not offered at this branch",
+		CodeSlotFull:                   "The branch takes no more bookings of this service at this time",
+		CodePromotionNotFound:          "Promo code not found",
+		CodePromotionInactive:          "The promo code is not active",
+		CodePromotionNotApplicable:     "The promo code cannot be applied to this booking",
+		CodePromotionUsageLimitReached: "Promo code usage limit reached",
+		CodePromotionInvalid:           "Invalid promotion rule",
+		CodePackageNotFound:            "Package not found",
+		CodePackageCreditNotFound:      "No package credit for this service",
+		CodePackageCreditExhausted:     "Package credit is used up or expired",
+		CodeGiftCardNotFound:           "Gift card not found",
+		CodeGiftCardUnusable:           "Gift card is inactive, expired or has no balance",
+		CodeRedemptionConflict:         "A package credit cannot be combined with a promo code, gift card or points",
+		CodeInsufficientPoints:         "Not enough loyalty points",
+		CodeInvalidPoints:              "Points must not be zero",
+		CodeInvoiceNotAvailable:        "An invoice is issued once the booking is completed or paid",
+		CodeTaxRateConflict:            "An active tax rate already exists for this branch and category",
+		CodePricingRuleInvalid:         "Invalid pricing rule: check days of week, time window and lead hours",
+		CodePriceChanged:               "The price has changed since it was quoted, please check the slot price again",
+		CodeMembershipPlanNotFound:     "Membership plan not found",
+		CodeMembershipPlanInUse:        "Membership plan has subscriptions, deactivate it instead",
+		CodeSubscriptionNotFound:       "No active membership subscription",
+		CodeAlreadySubscribed:          "User already has a membership subscription",
+		CodeMembershipCreditsExhausted: "Included membership sessions are used up",
+		CodePaymentDeclined:            "Payment was declined",
+		CodeBookingNotPayable:          "Booking is cancelled or already paid",
+		CodeInvalidPaymentAmount:       "Payment amount must be positive and at most the amount due",
+		CodeRefundNotFound:             "Refund not found",
+		CodeRefundProcessed:            "Refund has already been processed",
+		CodeInvalidRefundAmount:        "Refund amount exceeds what was paid",
+		CodeRefundFailed:               "The payment provider could not issue the refund",
+		CodeVariantNotFound:            "Variant not found for this service",
+		CodeVariantRequired:            "This service has variants, choose one",
+		CodeAddOnNotFound:              "Add-on not found for this service",
+		CodeParentCategoryNotFound:     "Parent category not found",
+		CodeCategoryCycle:              "A category cannot be moved under itself or its descendants",
+		CodeCategoryHasChildren:        "Category has subcategories, move or delete them first",
+		CodeCategoryNotSibling:         "Categories to reorder must all belong to the same parent",
+		CodeParentCategoryInTrash:      "The parent category is in the trash, restore it first",
+		CodeCategoryInTrash:            "The service's category is in the trash, restore it first",
+		CodeDeleteBlocked:              "Other records depend on this one, choose a delete strategy",
+		CodeInvalidDeleteStrategy:      "strategy must be block, reassign or cancel",
+		CodeReassignNotAllowed:         "Only a category's services can be reassigned",
+		CodeReassignTargetInvalid:      "reassign_to must be another category that is not in the trash",
+		CodeProductNotFound:            "Product not found",
+		CodeProductInUse:               "Product has stock movements, deactivate it instead",
+		CodeInsufficientStock:          "Not enough stock at this branch",
+		CodeInvalidStockChange:         "Restocks and sales take a positive quantity, adjustments a non-zero one",
+		CodeReviewNotFound:             "Review not found",
+		CodeReviewExists:               "This booking has already been reviewed",
+		CodeBookingNotCompleted:        "Only completed bookings can be reviewed",
+		CodeStaffRatingInvalid:         "staff_id and staff_rating must be given together",
+		CodeNotBranchStaff:             "staff_id must be a staff member of the booking's branch",
+		CodeNotBranchRole:              "Only STAFF and BRANCH_MANAGER users can be branch members",
+		CodeInvalidCoordinates:         "lat must be between -90 and 90 and lng between -180 and 180",
+		CodeInvalidRadius:              "radius_km must be greater than 0 and at most 100",
+		CodeImageTooLarge:              "The image exceeds the maximum upload size",
+		CodeUnsupportedImageType:       "Only JPEG, PNG and GIF images are accepted",
+		CodeUnsupportedLocale:          "The locale is not supported",
+		CodeDefaultLocale:              "The default locale is edited on the record itself, not as a translation",
+		CodeTranslationNotFound:        "Translation not found for this locale",
+
+		CodeUserIDRequired:           "User ID is required",
+		CodeProfileNotEditable:       "Only admins can update other users' profiles or roles",
+		CodeCategoryExists:           "Category already exists",
+		CodeProductSKUExists:         "A product with this SKU already exists",
+		CodePromotionCodeExists:      "Promotion code already exists",
+		CodeLoyaltyRuleExists:        "A rule already exists for this category",
+		CodeImageRequired:            "An image file is required",
+		CodeSearchTextRequired:       "Search text q is required",
+		CodeSlotQueryRequired:        "Branch ID and booked date are required",
+		CodeBookingOptionUnavailable: "The option booked is no longer offered, please choose another",
+
+		CodeBookingPaid:           "Booking paid successfully",
+		CodeSubscribed:            "Subscribed successfully",
+		CodeSubscriptionCancelled: "Subscription cancelled successfully",
+		CodePackageSold:           "Package sold successfully",
+		CodeGiftCardIssued:        "Gift card issued successfully",
+		CodePointsAdjusted:        "Points adjusted successfully",
+		CodeRefundIssued:          "Refund issued successfully",
+		CodeRefundRejected:        "Refund rejected successfully",
+		CodeCategoriesReordered:   "Categories reordered successfully",
+		CodeWebhookReceived:       "Clerk webhook received successfully",
+
+		CodeFieldRequired: "{field} is required",
+		CodeFieldMin:      "{field} must be at least {param}",
+		CodeFieldMinLen:   "{field} must be at least {param} characters long",
+		CodeFieldMinItems: "{field} must contain at least {param} items",
+		CodeFieldMax:      "{field} must be at most {param}",
+		CodeFieldMaxLen:   "{field} must be at most {param} characters long",
+		CodeFieldMaxItems: "{field} must contain at most {param} items",
+		CodeFieldEmail:    "{field} must be a valid email address",
+		CodeFieldOneOf:    "{field} must be one of: {param}",
+		CodeFieldUUID:     "{field} must be a valid UUID",
+		CodeFieldCurrency: "{field} must be an ISO 4217 currency code such as MMK",
+		CodeFieldDatetime: "{field} must match the format {param}",
+		CodeFieldNumber:   "{field} must be a number",
+		CodeFieldBoolean:  "{field} must be true or false",
+		CodeFieldInvalid:  "{field} is invalid",
+
+		CodeNotifyBookingCancelled: "Your {service} booking on {date} at {time} has been cancelled because it is no longer offered. Any payment will be refunded.",
+	},
+	locale.Myanmar: {
+		CodeValidationFailed:       "ထည့်သွင်းထားသော အချက်အလက်များ မမှန်ကန်ပါ",
+		CodeInvalidRequest:         "တောင်းဆိုမှုပုံစံ မမှန်ကန်ပါ",
+		CodeInvalidQuery:           "ရှာဖွေမှု သတ်မှတ်ချက်များ မမှန်ကန်ပါ",
+		CodeUnauthorized:           "ခွင့်ပြုချက် မရှိပါ",
+		CodeInvalidToken:           "တိုကင် မမှန်ကန်ပါ",
+		CodeForbidden:              "ဤလုပ်ဆောင်ချက်ကို လုပ်ဆောင်ခွင့် မရှိပါ",
+		CodeRecordNotFound:         "မှတ်တမ်း မတွေ့ပါ",
+		CodeDuplicateEntry:         "ဤမှတ်တမ်း ရှိပြီးသား ဖြစ်ပါသည်",
+		CodeEmailAlreadyRegistered: "ဤအီးမေးလ်ဖြင့် စာရင်းသွင်းပြီး ဖြစ်ပါသည်",
+		CodeUserNotFound:           "အသုံးပြုသူ မတွေ့ပါ",
+		CodeInternalError:          "တစ်ခုခု မှားယွင်းနေပါသည်",
+		CodeSuccessful:             "အောင်မြင်ပါသည်",
+
+		CodeInvalidID: "{resource} ID မမှန်ကန်ပါ",
+		CodeNotFound:  "{resource} မတွေ့ပါ",
+		CodeRetrieved: "{resource} ကို ရယူပြီးပါပြီ",
+		CodeCreated:   "{resource} ကို ဖန်တီးပြီးပါပြီ",
+		CodeUpdated:   "{resource} ကို ပြင်ဆင်ပြီးပါပြီ",
+		CodeDeleted:   "{resource} ကို ဖျက်ပြီးပါပြီ",
+		CodeRestored:  "{resource} ကို ပြန်လည်ရယူပြီးပါပြီ",
+		CodeSaved:     "{resource} ကို သိမ်းဆည်းပြီးပါပြီ",
+		CodeUploaded:  "{resource} ကို တင်ပြီးပါပြီ",
+		CodeCancelled: "{resource} ကို ပယ်ဖျက်ပြီးပါပြီ",
+
+		CodeInvalidData:                "ပေးပို့သော အချက်အလက် မမှန်ကန်ပါ",
+		CodeDatabaseError:              "ဒေတာဘေ့စ် အမှား ဖြစ်ပေါ်ခဲ့ပါသည်",
+		CodeInvalidCredentials:         "အထောက်အထား မမှန်ကန်ပါ",
+		CodeForeignKeyViolation:        "ဆက်စပ်နေသော မှတ်တမ်း မရှိပါ",
+		CodeUniqueConstraint:           "တစ်ခုတည်း ဖြစ်ရမည့် တန်ဖိုးကို အသုံးပြုပြီး ဖြစ်ပါသည်",
+		CodeCheckConstraint:            "တန်ဖိုးတစ်ခုသည် ခွင့်ပြုထားသည့် အတိုင်းအတာ ပြင်ပတွင် ရှိနေပါသည်",
+		CodeNotNullConstraint:          "လိုအပ်သော တန်ဖိုးတစ်ခု မပါဝင်ပါ",
+		CodeTransactionConflict:        "အခြား တောင်းဆိုမှုနှင့် တိုက်ဆိုင်နေသဖြင့် ထပ်မံ ကြိုးစားပါ",
+		CodeDeadlock:                   "အခြား တောင်းဆိုမှုနှင့် တိုက်ဆိုင်နေသဖြင့် ထပ်မံ ကြိုးစားပါ",
+		CodeLockTimeout:                "မှတ်တမ်းကို အသုံးပြုနေဆဲ ဖြစ်သဖြင့် ထပ်မံ ကြိုးစားပါ",
+		CodeConnectionFailed:           "ဒေတာဘေ့စ်ကို အသုံးပြု၍ မရနိုင်ပါ",
+		CodeConnectionTimeout:          "ဒေတာဘေ့စ်က တုံ့ပြန်ရန် အချိန်ကြာလွန်းပါသည်",
+		CodeTooManyConnections:         "ဆာဗာ အလုပ်များနေသဖြင့် ထပ်မံ ကြိုးစားပါ",
+		CodeInvalidDatabaseQuery:       "ဒေတာဘေ့စ် ရှာဖွေမှု မမှန်ကန်ပါ",
+		CodeQueryTimeout:               "ဒေတာဘေ့စ်က တုံ့ပြန်ရန် အချိန်ကြာလွန်းပါသည်",
+		CodeRowScanError:               "မှတ်တမ်းကို ဖတ်၍ မရပါ",
+		CodeBranchNotFound:             "ဆိုင်ခွဲ မတွေ့ပါ",
+		CodeServiceNotFound:            "ဝန်ဆောင်မှု မတွေ့ပါ",
+		CodeCategoryNotFound:           "အမျိုးအစား မတွေ့ပါ",
+		CodeAlreadyBooked:              "ဤအချိန်တွင် ဤဝန်ဆောင်မှုအတွက် ဘိုကင် ရှိပြီးသား ဖြစ်ပါသည်။ ထပ်မံ ဘိုကင်လုပ်လိုပါက ပထမ ဘိုကင်ကို ပယ်ဖျက်ပါ။",
+		CodeBookingCancelled:           "ပယ်ဖျက်ထားသော ဘိုကင်ကို ပြန်ဖွင့်၍ မရပါ",
+		CodeBookingCompleted:           "ပြီးဆုံးသွားသော ဘိုကင်ကို ပယ်ဖျက်၍ သို့မဟုတ် ဖျက်၍ မရပါ",
+		CodeBookingHasPayments:         "ငွေပေးချေထားသော ဘိုကင် ဖြစ်သည်၊ ငွေပြန်အမ်းရန် ပယ်ဖျက်ပါ",
+		CodeServiceNotAtBranch:         "ဤဆိုင်ခွဲတွင် ဤဝန်ဆောင်မှုကို မပေးပါ",
+		CodeSlotFull:                   "ဤအချိန်အတွက် ဤဝန်ဆောင်မှု၏ ဘိုကင်များ ပြည့်သွားပါပြီ",
+		CodePromotionNotFound:          "ပရိုမိုးရှင်းကုဒ် မတွေ့ပါ",
+		CodePromotionInactive:          "ပရိုမိုးရှင်းကုဒ်ကို ယခု အသုံးပြု၍ မရပါ",
+		CodePromotionNotApplicable:     "ဤဘိုကင်တွင် ပရိုမိုးရှင်းကုဒ်ကို အသုံးပြု၍ မရပါ",
+		CodePromotionUsageLimitReached: "ပရိုမိုးရှင်းကုဒ် အသုံးပြုနိုင်သည့် အကြိမ်ရေ ပြည့်သွားပါပြီ",
+		CodePromotionInvalid:           "ပရိုမိုးရှင်း စည်းမျဉ်း မမှန်ကန်ပါ",
+		CodePackageNotFound:            "ပက်ကေ့ချ် မတွေ့ပါ",
+		CodePackageCreditNotFound:      "ဤဝန်ဆောင်မှုအတွက် ပက်ကေ့ချ် ခရက်ဒစ် မရှိပါ",
+		CodePackageCreditExhausted:     "ပက်ကေ့ချ် ခရက်ဒစ် ကုန်သွားပါပြီ သို့မဟုတ် သက်တမ်းကုန်သွားပါပြီ",
+		CodeGiftCardNotFound:           "လက်ဆောင်ကတ် မတွေ့ပါ",
+		CodeGiftCardUnusable:           "လက်ဆောင်ကတ်ကို ပိတ်ထားသည်၊ သက်တမ်းကုန်သွားသည် သို့မဟုတ် လက်ကျန်ငွေ မရှိပါ",
+		CodeRedemptionConflict:         "ပက်ကေ့ချ် ခရက်ဒစ်ကို ပရိုမိုးရှင်းကုဒ်၊ လက်ဆောင်ကတ် သို့မဟုတ် အမှတ်များနှင့် တွဲသုံး၍ မရပါ",
+		CodeInsufficientPoints:         "အမှတ် မလုံလောက်ပါ",
+		CodeInvalidPoints:              "အမှတ်သည် သုည မဖြစ်ရပါ",
+		CodeInvoiceNotAvailable:        "ဘိုကင် ပြီးဆုံးပြီး သို့မဟုတ် ငွေပေးချေပြီးမှ ပြေစာ ထုတ်ပေးပါသည်",
+		CodeTaxRateConflict:            "ဤဆိုင်ခွဲနှင့် အမျိုးအစားအတွက် အသုံးပြုနေသော အခွန်နှုန်း ရှိပြီးသား ဖြစ်ပါသည်",
+		CodePricingRuleInvalid:         "ဈေးနှုန်း စည်းမျဉ်း မမှန်ကန်ပါ၊ ရက်များ၊ အချိန်အပိုင်းအခြားနှင့် ကြိုတင်နာရီများကို စစ်ဆေးပါ",
+		CodePriceChanged:               "ဈေးနှုန်း ပြောင်းလဲသွားပါပြီ၊ အချိန်အလိုက် ဈေးနှုန်းကို ပြန်စစ်ပါ",
+		CodeMembershipPlanNotFound:     "အသင်းဝင် အစီအစဉ် မတွေ့ပါ",
+		CodeMembershipPlanInUse:        "အသင်းဝင် အစီအစဉ်တွင် အသင်းဝင်ထားသူများ ရှိသဖြင့် ဖျက်မည့်အစား ပိတ်ထားပါ",
+		CodeSubscriptionNotFound:       "အသက်ဝင်နေသော အသင်းဝင်မှု မရှိပါ",
+		CodeAlreadySubscribed:          "အသုံးပြုသူတွင် အသင်းဝင်မှု ရှိပြီးသား ဖြစ်ပါသည်",
+		CodeMembershipCreditsExhausted: "အသင်းဝင်မှုတွင် ပါဝင်သော အကြိမ်များ ကုန်သွားပါပြီ",
+		CodePaymentDeclined:            "ငွေပေးချေမှုကို ငြင်းပယ်လိုက်ပါသည်",
+		CodeBookingNotPayable:          "ဘိုကင်ကို ပယ်ဖျက်ထားပြီ သို့မဟုတ် ငွေပေးချေပြီး ဖြစ်ပါသည်",
+		CodeInvalidPaymentAmount:       "ပေးချေငွေသည် သုညထက် များရမည်ဖြစ်ပြီး ပေးရန်ကျန်ငွေထက် မများရပါ",
+		CodeRefundNotFound:             "ငွေပြန်အမ်းမှု မတွေ့ပါ",
+		CodeRefundProcessed:            "ငွေပြန်အမ်းမှုကို ဆောင်ရွက်ပြီး ဖြစ်ပါသည်",
+		CodeInvalidRefundAmount:        "ပြန်အမ်းငွေသည် ပေးချေထားသော ငွေထက် များနေပါသည်",
+		CodeRefundFailed:               "ငွေပေးချေမှု ဝန်ဆောင်မှုပေးသူက ငွေပြန်အမ်း၍ မရပါ",
+		CodeVariantNotFound:            "ဤဝန်ဆောင်မှုအတွက် အမျိုးကွဲ မတွေ့ပါ",
+		CodeVariantRequired:            "ဤဝန်ဆောင်မှုတွင် အမျိုးကွဲများ ရှိသဖြင့် တစ်ခု ရွေးပါ",
+		CodeAddOnNotFound:              "ဤဝန်ဆောင်မှုအတွက် အပိုဝန်ဆောင်မှု မတွေ့ပါ",
+		CodeParentCategoryNotFound:     "မိခင် အမျိုးအစား မတွေ့ပါ",
+		CodeCategoryCycle:              "အမျိုးအစားကို ၎င်းကိုယ်တိုင် သို့မဟုတ် ၎င်း၏ အမျိုးအစားခွဲများအောက်သို့ ရွှေ့၍ မရပါ",
+		CodeCategoryHasChildren:        "အမျိုးအစားတွင် အမျိုးအစားခွဲများ ရှိသဖြင့် ၎င်းတို့ကို ဦးစွာ ရွှေ့ပါ သို့မဟုတ် ဖျက်ပါ",
+		CodeCategoryNotSibling:         "နေရာပြန်စီမည့် အမျိုးအစားများသည် မိခင် အမျိုးအစား တစ်ခုတည်းအောက်တွင် ရှိရပါမည်",
+		CodeParentCategoryInTrash:      "မိခင် အမျိုးအစားကို ဖျက်ထားသဖြင့် ၎င်းကို ဦးစွာ ပြန်လည်ရယူပါ",
+		CodeCategoryInTrash:            "ဝန်ဆောင်မှု၏ အမျိုးအစားကို ဖျက်ထားသဖြင့် ၎င်းကို ဦးစွာ ပြန်လည်ရယူပါ",
+		CodeDeleteBlocked:              "ဤမှတ်တမ်းကို အခြားမှတ်တမ်းများက အသုံးပြုနေသဖြင့် ဖျက်နည်း တစ်ခု ရွေးပါ",
+		CodeInvalidDeleteStrategy:      "strategy သည် block၊ reassign သို့မဟုတ် cancel ဖြစ်ရပါမည်",
+		CodeReassignNotAllowed:         "အမျိုးအစား၏ ဝန်ဆောင်မှုများကိုသာ အခြားနေရာသို့ ပြောင်းရွှေ့နိုင်ပါသည်",
+		CodeReassignTargetInvalid:      "reassign_to သည် ဖျက်မထားသော အခြား အမျိုးအစား ဖြစ်ရပါမည်",
+		CodeProductNotFound:            "ကုန်ပစ္စည်း မတွေ့ပါ",
+		CodeProductInUse:               "ကုန်ပစ္စည်းတွင် လက်ကျန် အဝင်အထွက်များ ရှိသဖြင့် ဖျက်မည့်အစား ပိတ်ထားပါ",
+		CodeInsufficientStock:          "ဤဆိုင်ခွဲတွင် လက်ကျန်ပစ္စည်း မလုံလောက်ပါ",
+		CodeInvalidStockChange:         "ပစ္စည်းဖြည့်ခြင်းနှင့် ရောင်းချခြင်းသည် အပေါင်း အရေအတွက်၊ ချိန်ညှိခြင်းသည် သုည မဟုတ်သော အရေအတွက် ဖြစ်ရပါမည်",
+		CodeReviewNotFound:             "သုံးသပ်ချက် မတွေ့ပါ",
+		CodeReviewExists:               "ဤဘိုကင်ကို သုံးသပ်ပြီး ဖြစ်ပါသည်",
+		CodeBookingNotCompleted:        "ပြီးဆုံးသွားသော ဘိုကင်များကိုသာ သုံးသပ်နိုင်ပါသည်",
+		CodeStaffRatingInvalid:         "staff_id နှင့် staff_rating ကို အတူတကွ ပေးရပါမည်",
+		CodeNotBranchStaff:             "staff_id သည် ဘိုကင်၏ ဆိုင်ခွဲမှ ဝန်ထမ်း ဖြစ်ရပါမည်",
+		CodeNotBranchRole:              "STAFF နှင့် BRANCH_MANAGER အသုံးပြုသူများသာ ဆိုင်ခွဲ ဝန်ထမ်း ဖြစ်နိုင်ပါသည်",
+		CodeInvalidCoordinates:         "lat သည် -90 နှင့် 90 ကြား၊ lng သည် -180 နှင့် 180 ကြား ဖြစ်ရပါမည်",
+		CodeInvalidRadius:              "radius_km သည် 0 ထက် ကြီးရမည်ဖြစ်ပြီး 100 ထက် မများရပါ",
+		CodeImageTooLarge:              "ပုံသည် တင်နိုင်သည့် အရွယ်အစားထက် ကြီးနေပါသည်",
+		CodeUnsupportedImageType:       "JPEG၊ PNG နှင့် GIF ပုံများကိုသာ လက်ခံပါသည်",
+		CodeUnsupportedLocale:          "ဤဘာသာစကားကို မပံ့ပိုးပါ",
+		CodeDefaultLocale:              "မူလ ဘာသာစကားကို ဘာသာပြန်အဖြစ် မဟုတ်ဘဲ မှတ်တမ်းပေါ်တွင် တိုက်ရိုက် ပြင်ဆင်ပါ",
+		CodeTranslationNotFound:        "ဤဘာသာစကားအတွက် ဘာသာပြန် မတွေ့ပါ",
+
+		CodeUserIDRequired:           "အသုံးပြုသူ ID လိုအပ်ပါသည်",
+		CodeProfileNotEditable:       "အခြား အသုံးပြုသူများ၏ ပရိုဖိုင် သို့မဟုတ် အခန်းကဏ္ဍကို အက်ဒမင်များသာ ပြင်ဆင်နိုင်ပါသည်",
+		CodeCategoryExists:           "အမျိုးအစား ရှိပြီးသား ဖြစ်ပါသည်",
+		CodeProductSKUExists:         "ဤ SKU ဖြင့် ကုန်ပစ္စည်း ရှိပြီးသား ဖြစ်ပါသည်",
+		CodePromotionCodeExists:      "ပရိုမိုးရှင်းကုဒ် ရှိပြီးသား ဖြစ်ပါသည်",
+		CodeLoyaltyRuleExists:        "ဤအမျိုးအစားအတွက် စည်းမျဉ်း ရှိပြီးသား ဖြစ်ပါသည်",
+		CodeImageRequired:            "ပုံဖိုင် လိုအပ်ပါသည်",
+		CodeSearchTextRequired:       "ရှာဖွေမည့် စာသား q လိုအပ်ပါသည်",
+		CodeSlotQueryRequired:        "ဆိုင်ခွဲ ID နှင့် ဘိုကင် ရက်စွဲ လိုအပ်ပါသည်",
+		CodeBookingOptionUnavailable: "ဘိုကင်လုပ်ခဲ့သော ရွေးချယ်မှုကို မပေးတော့သဖြင့် အခြား တစ်ခု ရွေးပါ",
+
+		CodeBookingPaid:           "ဘိုကင်အတွက် ငွေပေးချေပြီးပါပြီ",
+		CodeSubscribed:            "အသင်းဝင်ပြီးပါပြီ",
+		CodeSubscriptionCancelled: "အသင်းဝင်မှုကို ပယ်ဖျက်ပြီးပါပြီ",
+		CodePackageSold:           "ပက်ကေ့ချ်ကို ရောင်းချပြီးပါပြီ",
+		CodeGiftCardIssued:        "လက်ဆောင်ကတ်ကို ထုတ်ပေးပြီးပါပြီ",
+		CodePointsAdjusted:        "အမှတ်များကို ချိန်ညှိပြီးပါပြီ",
+		CodeRefundIssued:          "ငွေပြန်အမ်းပြီးပါပြီ",
+		CodeRefundRejected:        "ငွေပြန်အမ်းမှုကို ငြင်းပယ်ပြီးပါပြီ",
+		CodeCategoriesReordered:   "အမျိုးအစားများကို နေရာပြန်စီပြီးပါပြီ",
+		CodeWebhookReceived:       "Clerk webhook ကို လက်ခံရရှိပါပြီ",
+
+		CodeFieldRequired: "{field} ကို ဖြည့်ရန် လိုအပ်ပါသည်",
+		CodeFieldMin:      "{field} သည် အနည်းဆုံး {param} ဖြစ်ရပါမည်",
+		CodeFieldMinLen:   "{field} သည် အနည်းဆုံး စာလုံး {param} လုံး ရှိရပါမည်",
+		CodeFieldMinItems: "{field} တွင် အနည်းဆုံး {param} ခု ပါရပါမည်",
+		CodeFieldMax:      "{field} သည် {param} ထက် မများရပါ",
+		CodeFieldMaxLen:   "{field} သည် စာလုံး {param} လုံးထက် မများရပါ",
+		CodeFieldMaxItems: "{field} တွင် {param} ခုထက် မများရပါ",
+		CodeFieldEmail:    "{field} သည် မှန်ကန်သော အီးမေးလ်လိပ်စာ ဖြစ်ရပါမည်",
+		CodeFieldOneOf:    "{field} သည် {param} ထဲမှ တစ်ခု ဖြစ်ရပါမည်",
+		CodeFieldUUID:     "{field} သည် မှန်ကန်သော UUID ဖြစ်ရပါမည်",
+		CodeFieldCurrency: "{field} သည် MMK ကဲ့သို့ ISO 4217 ငွေကြေးကုဒ် ဖြစ်ရပါမည်",
+		CodeFieldDatetime: "{field} သည် {param} ပုံစံ ဖြစ်ရပါမည်",
+		CodeFieldNumber:   "{field} သည် ဂဏန်း ဖြစ်ရပါမည်",
+		CodeFieldBoolean:  "{field} သည် true သို့မဟုတ် false ဖြစ်ရပါမည်",
+		CodeFieldInvalid:  "{field} မမှန်ကန်ပါ",
+
+		CodeNotifyBookingCancelled: "{date} {time} အတွက် {service} ဘိုကင်ကို ဝန်ဆောင်မှု မရှိတော့သဖြင့် ပယ်ဖျက်လိုက်ပါသည်။ ပေးချေထားသော ငွေကို ပြန်အမ်းပေးပါမည်။",
+	},
+}
+
+// glossary names the resources that appear in handler messages. English
+// needs no entry since handlers already write the name in English.
+var glossary = map[string]map[string]string{
+	locale.Myanmar: {
+		"service":               "ဝန်ဆောင်မှု",
+		"services":              "ဝန်ဆောင်မှုများ",
+		"branch":                "ဆိုင်ခွဲ",
+		"branches":              "ဆိုင်ခွဲများ",
+		"category":              "အမျိုးအစား",
+		"categories":            "အမျိုးအစားများ",
+		"category tree":         "အမျိုးအစား အဆင့်ဆင့်",
+		"booking":               "ဘိုကင်",
+		"bookings":              "ဘိုကင်များ",
+		"user":                  "အသုံးပြုသူ",
+		"users":                 "အသုံးပြုသူများ",
+		"user data":             "အသုံးပြုသူ အချက်အလက်",
+		"package":               "ပက်ကေ့ချ်",
+		"packages":              "ပက်ကေ့ချ်များ",
+		"gift card":             "လက်ဆောင်ကတ်",
+		"gift cards":            "လက်ဆောင်ကတ်များ",
+		"promotion":             "ပရိုမိုးရှင်း",
+		"promotions":            "ပရိုမိုးရှင်းများ",
+		"membership plan":       "အသင်းဝင် အစီအစဉ်",
+		"tax rate":              "အခွန်နှုန်း",
+		"tax rates":             "အခွန်နှုန်းများ",
+		"pricing rule":          "ဈေးနှုန်း စည်းမျဉ်း",
+		"pricing rules":         "ဈေးနှုန်း စည်းမျဉ်းများ",
+		"refund":                "ငွေပြန်အမ်းမှု",
+		"refunds":               "ငွေပြန်အမ်းမှုများ",
+		"refund rule":           "ငွေပြန်အမ်း စည်းမျဉ်း",
+		"loyalty rule":          "အမှတ်ပေး စည်းမျဉ်း",
+		"variant":               "အမျိုးကွဲ",
+		"variants":              "အမျိုးကွဲများ",
+		"add-on":                "အပိုဝန်ဆောင်မှု",
+		"add-ons":               "အပိုဝန်ဆောင်မှုများ",
+		"invoice":               "ပြေစာ",
+		"image":                 "ပုံ",
+		"translation":           "ဘာသာပြန်",
+		"deleted service":       "ဖျက်ထားသော ဝန်ဆောင်မှု",
+		"deleted services":      "ဖျက်ထားသော ဝန်ဆောင်မှုများ",
+		"deleted branch":        "ဖျက်ထားသော ဆိုင်ခွဲ",
+		"deleted branches":      "ဖျက်ထားသော ဆိုင်ခွဲများ",
+		"deleted category":      "ဖျက်ထားသော အမျိုးအစား",
+		"deleted categories":    "ဖျက်ထားသော အမျိုးအစားများ",
+		"service translations":  "ဝန်ဆောင်မှု ဘာသာပြန်များ",
+		"service translation":   "ဝန်ဆောင်မှု ဘာသာပြန်",
+		"category translations": "အမျိုးအစား ဘာသာပြန်များ",
+		"category translation":  "အမျိုးအစား ဘာသာပြန်",
+		"branch translations":   "ဆိုင်ခွဲ ဘာသာပြန်များ",
+		"branch translation":    "ဆိုင်ခွဲ ဘာသာပြန်",
+		"branch services":       "ဆိုင်ခွဲ ဝန်ဆောင်မှုများ",
+		"branch service":        "ဆိုင်ခွဲ ဝန်ဆောင်မှု",
+		"favorites":             "အနှစ်သက်ဆုံးများ",
+		"favorite":              "အနှစ်သက်ဆုံး",
+		"branch members":        "ဆိုင်ခွဲ ဝန်ထမ်းများ",
+		"branch member":         "ဆိုင်ခွဲ ဝန်ထမ်း",
+		"delete impact":         "ဖျက်ခြင်း၏ သက်ရောက်မှု",
+		"notification":          "အသိပေးချက်",
+		"notifications":         "အသိပေးချက်များ",
+		"product":               "ကုန်ပစ္စည်း",
+		"products":              "ကုန်ပစ္စည်းများ",
+		"stock":                 "လက်ကျန်ပစ္စည်း",
+		"stock movement":        "လက်ကျန် အဝင်အထွက်",
+		"stock movements":       "လက်ကျန် အဝင်အထွက်များ",
+		"low-stock report":      "လက်ကျန်နည်း အစီရင်ခံစာ",
+		"consumables":           "အသုံးပြုပစ္စည်းများ",
+		"review":                "သုံးသပ်ချက်",
+		"reviews":               "သုံးသပ်ချက်များ",
+		"staff member":          "ဝန်ထမ်း",
+		"service versions":      "ဝန်ဆောင်မှု ဗားရှင်းများ",
+		"service or variant":    "ဝန်ဆောင်မှု သို့မဟုတ် အမျိုးကွဲ",
+		"service or add-on":     "ဝန်ဆောင်မှု သို့မဟုတ် အပိုဝန်ဆောင်မှု",
+		"branch or service":     "ဆိုင်ခွဲ သို့မဟုတ် ဝန်ဆောင်မှု",
+		"service image":         "ဝန်ဆောင်မှု ပုံ",
+		"branch image":          "ဆိုင်ခွဲ ပုံ",
+		"available slots":       "အားလပ်သော အချိန်များ",
+		"user bookings":         "အသုံးပြုသူ၏ ဘိုကင်များ",
+		"user packages":         "အသုံးပြုသူ၏ ပက်ကေ့ချ်များ",
+		"booking payments":      "ဘိုကင် ငွေပေးချေမှုများ",
+		"suggestions":           "အကြံပြုချက်များ",
+		"membership plans":      "အသင်းဝင် အစီအစဉ်များ",
+		"subscription":          "အသင်းဝင်မှု",
+		"subscriptions":         "အသင်းဝင်မှုများ",
+		"refund rules":          "ငွေပြန်အမ်း စည်းမျဉ်းများ",
+		"loyalty rules":         "အမှတ်ပေး စည်းမျဉ်းများ",
+		"points balance":        "အမှတ် လက်ကျန်",
+		"points history":        "အမှတ် မှတ်တမ်း",
+		"ledger entries":        "စာရင်း မှတ်တမ်းများ",
+	},
+}
@@ -0,0 +1,105 @@
+// Package i18n renders API messages in the locale of a request. Each message
+// has a stable code clients can rely on, and a template per locale with
+// {name} placeholders.
+package i18n
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"KaungHtetHein116/IVY-backend/pkg/locale"
+)
+
+// Params fills the {name} placeholders of a template.
+type Params map[string]string
+
+// Render returns the message for code in lang, falling back to English and
+// then to the code itself.
+func Render(lang, code string, params Params) string {
+	template, ok := catalog[lang][code]
+	if !ok {
+		template, ok = catalog[locale.English][code]
+	}
+	if !ok {
+		return code
+	}
+
+	if len(params) == 0 {
+		return template
+	}
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// pattern recognizes the English messages handlers build around a resource
+// name, e.g. "Invalid service ID" or "Branch not found".
+type pattern struct {
+	re   *regexp.Regexp
+	code string
+}
+
+var patterns = []pattern{
+	{regexp.MustCompile(`^Invalid (.+) ID$`), CodeInvalidID},
+	{regexp.MustCompile(`^(.+) not found$`), CodeNotFound},
+	{regexp.MustCompile(`^(.+) retrieved successfully$`), CodeRetrieved},
+	{regexp.MustCompile(`^(.+) created successfully$`), CodeCreated},
+	{regexp.MustCompile(`^(.+) updated successfully$`), CodeUpdated},
+	{regexp.MustCompile(`^(.+) deleted successfully$`), CodeDeleted},
+	{regexp.MustCompile(`^(.+) restored successfully$`), CodeRestored},
+	{regexp.MustCompile(`^(.+) saved successfully$`), CodeSaved},
+	{regexp.MustCompile(`^(.+) uploaded successfully$`), CodeUploaded},
+	{regexp.MustCompile(`^(.+) cancelled successfully$`), CodeCancelled},
+}
+
+// failure recognizes the "Failed to ..." messages of internal errors. They
+// keep their English text and read as CodeInternalError in other locales.
+var failure = regexp.MustCompile(`^Failed to .+$`)
+
+// Localize returns the code of a message and its rendering in lang. The
+// message is either a code of the catalog or one of the English messages
+// handlers build around a resource name. Messages outside the catalog keep
+// their text, as does a resource name missing from the glossary, and get no
+// code.
+func Localize(lang, message string) (string, string) {
+	if _, ok := catalog[locale.English][message]; ok {
+		return message, Render(lang, message, nil)
+	}
+
+	if failure.MatchString(message) {
+		if lang == locale.English {
+			return CodeInternalError, message
+		}
+		return CodeInternalError, Render(lang, CodeInternalError, nil)
+	}
+
+	for _, p := range patterns {
+		match := p.re.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		resource, ok := glossary[lang][strings.ToLower(match[1])]
+		if !ok {
+			if lang != locale.English {
+				return p.code, message
+			}
+			resource = match[1]
+		}
+		return p.code, Render(lang, p.code, Params{"resource": resource})
+	}
+
+	return "", message
+}
+
+// StatusCode is the code of an error response whose message has none of its
+// own, derived from the HTTP status, e.g. NOT_FOUND for 404.
+func StatusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return CodeInternalError
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
@@ -0,0 +1,39 @@
+package i18n
+
+import (
+	"testing"
+
+	"KaungHtetHein116/IVY-backend/pkg/locale"
+)
+
+func TestCatalogTranslated(t *testing.T) {
+	for _, lang := range locale.Supported {
+		for code := range catalog[locale.English] {
+			if _, ok := catalog[lang][code]; !ok {
+				t.Errorf("%s has no %s message", code, lang)
+			}
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	tests := []struct {
+		lang, message string
+		code, text    string
+	}{
+		{locale.English, CodeSlotFull, CodeSlotFull, catalog[locale.English][CodeSlotFull]},
+		{locale.Myanmar, CodeSlotFull, CodeSlotFull, catalog[locale.Myanmar][CodeSlotFull]},
+		{locale.English, "Branch not found", CodeNotFound, "Branch not found"},
+		{locale.Myanmar, "Invalid service ID", CodeInvalidID, Render(locale.Myanmar, CodeInvalidID, Params{"resource": "ဝန်ဆောင်မှု"})},
+		{locale.Myanmar, "Widget not found", CodeNotFound, "Widget not found"},
+		{locale.English, "Failed to create booking", CodeInternalError, "Failed to create booking"},
+		{locale.Myanmar, "Failed to create booking", CodeInternalError, catalog[locale.Myanmar][CodeInternalError]},
+		{locale.Myanmar, "Something else", "", "Something else"},
+	}
+	for _, tt := range tests {
+		code, text := Localize(tt.lang, tt.message)
+		if code != tt.code || text != tt.text {
+			t.Errorf("Localize(%s, %q) = %s, %q; want %s, %q", tt.lang, tt.message, code, text, tt.code, tt.text)
+		}
+	}
+}
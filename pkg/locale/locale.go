@@ -0,0 +1,82 @@
+// Package locale picks the language of a response from the locales the
+// catalog is translated into, and carries it through a request's context.
+package locale
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	English = "en"
+	Myanmar = "my"
+)
+
+// Supported lists the locales content can be translated into.
+var Supported = []string{English, Myanmar}
+
+// IsSupported reports whether code is one of the Supported locales.
+func IsSupported(code string) bool {
+	for _, supported := range Supported {
+		if code == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate returns the locale asked for by the lang parameter if supported,
+// otherwise the most preferred supported language of an Accept-Language
+// header, otherwise fallback. Regional tags such as my-MM match their language.
+func Negotiate(lang, acceptLanguage, fallback string) string {
+	if code := normalize(lang); IsSupported(code) {
+		return code
+	}
+
+	type preference struct {
+		code string
+		q    float64
+	}
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if code := normalize(tag); q > 0 && IsSupported(code) {
+			preferences = append(preferences, preference{code: code, q: q})
+		}
+	}
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].q > preferences[j].q })
+	if len(preferences) > 0 {
+		return preferences[0].code
+	}
+	return fallback
+}
+
+// normalize reduces a language tag to its lower-case primary language.
+func normalize(tag string) string {
+	code, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	code, _, _ = strings.Cut(code, "_")
+	return strings.ToLower(code)
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying the locale.
+func WithContext(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, contextKey{}, code)
+}
+
+// FromContext returns the locale of the request, or "" if none was set.
+func FromContext(ctx context.Context) string {
+	code, _ := ctx.Value(contextKey{}).(string)
+	return code
+}
@@ -0,0 +1,80 @@
+// Package money holds helpers for amounts stored as int64 minor units
+// together with an ISO 4217 currency code.
+package money
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultCurrency is used when a price is created without a currency.
+const DefaultCurrency = "MMK"
+
+// minorUnitExponents lists the number of decimal places of the currencies
+// the studio deals with. Unknown currencies fall back to two decimals.
+var minorUnitExponents = map[string]int{
+	"MMK": 2,
+	"USD": 2,
+	"THB": 2,
+	"SGD": 2,
+	"JPY": 0,
+}
+
+// Exponent returns the number of minor-unit decimal places for a currency.
+func Exponent(currency string) int {
+	if exp, ok := minorUnitExponents[strings.ToUpper(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// FromMajor converts a whole-unit amount (e.g. 25000 MMK) into minor units.
+func FromMajor(amount int64, currency string) int64 {
+	for i := 0; i < Exponent(currency); i++ {
+		amount *= 10
+	}
+	return amount
+}
+
+// NormalizeCurrency upper-cases the code and falls back to DefaultCurrency.
+func NormalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
+
+// Format renders a minor-unit amount for display, e.g. "25,000.00 MMK".
+func Format(amount int64, currency string) string {
+	currency = NormalizeCurrency(currency)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	exp := Exponent(currency)
+	divisor := int64(1)
+	for i := 0; i < exp; i++ {
+		divisor *= 10
+	}
+
+	whole := groupThousands(amount / divisor)
+	if exp == 0 {
+		return fmt.Sprintf("%s%s %s", sign, whole, currency)
+	}
+	return fmt.Sprintf("%s%s.%0*d %s", sign, whole, exp, amount%divisor, currency)
+}
+
+func groupThousands(n int64) string {
+	digits := fmt.Sprintf("%d", n)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
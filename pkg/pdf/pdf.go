@@ -0,0 +1,145 @@
+// Package pdf writes simple text-only PDF documents such as receipts and
+// invoices. It uses the standard PDF fonts so no font files are embedded,
+// which limits text to the Latin-1 character set.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+)
+
+// Font selects one of the standard fonts registered in every document.
+type Font string
+
+const (
+	Regular   Font = "F1"
+	Bold      Font = "F2"
+	Monospace Font = "F3"
+)
+
+var baseFonts = []struct {
+	font Font
+	name string
+}{
+	{Regular, "Helvetica"},
+	{Bold, "Helvetica-Bold"},
+	{Monospace, "Courier"},
+}
+
+// monospaceAdvance is the glyph width of Courier as a fraction of the size.
+const monospaceAdvance = 0.6
+
+// Document is a PDF under construction.
+type Document struct {
+	pages []*Page
+}
+
+// Page is a single page of a Document. Coordinates are in points from the
+// bottom-left corner.
+type Page struct {
+	content bytes.Buffer
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage appends a blank A4 page and returns it.
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// Text draws s with its baseline starting at (x, y).
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(s))
+}
+
+// TextRight draws s in the monospace font so that it ends at x.
+func (p *Page) TextRight(x, y float64, size float64, s string) {
+	width := float64(len([]rune(s))) * size * monospaceAdvance
+	p.Text(x-width, y, Monospace, size, s)
+}
+
+// Line draws a thin line from (x1, y1) to (x2, y2).
+func (p *Page) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// Bytes serialises the document.
+func (d *Document) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	writeObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1 and 2 are the catalog and the page tree, followed by the
+	// fonts and then a page and content stream pair per page.
+	firstPage := 3 + len(baseFonts)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	fonts := make([]string, len(baseFonts))
+	for i, f := range baseFonts {
+		writeObject(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.name))
+		fonts[i] = fmt.Sprintf("/%s %d 0 R", f.font, 3+i)
+	}
+
+	for i, page := range d.pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, strings.Join(fonts, " "), firstPage+2*i+1))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// escape quotes the PDF string delimiters and replaces characters outside
+// Latin-1, which the standard fonts cannot draw.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 128:
+			b.WriteRune(r)
+		case r < 256:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
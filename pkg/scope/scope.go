@@ -0,0 +1,41 @@
+// Package scope describes the rows a caller may see and manage, and carries
+// it through a request's context so repositories can apply it.
+package scope
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Scope limits what a caller sees. The zero Scope, used by admins, public
+// routes and background jobs, sees everything.
+type Scope struct {
+	// UserID limits rows that belong to a customer, such as bookings, to
+	// the caller's own
+	UserID string
+	// Branches limits rows that belong to a branch to BranchIDs; rows that
+	// belong to UserID stay visible
+	Branches  bool
+	BranchIDs []uuid.UUID
+}
+
+// HasBranch reports whether the scope covers the branch.
+func (s Scope) HasBranch(branchID uuid.UUID) bool {
+	return !s.Branches || slices.Contains(s.BranchIDs, branchID)
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying the scope.
+func WithContext(ctx context.Context, s Scope) context.Context {
+	return context.WithValue(ctx, contextKey{}, s)
+}
+
+// FromContext returns the scope of the request, or the zero Scope if none
+// was set.
+func FromContext(ctx context.Context) Scope {
+	s, _ := ctx.Value(contextKey{}).(Scope)
+	return s
+}
@@ -0,0 +1,36 @@
+// Package tax splits amounts into their pre-tax subtotal, tax and total.
+// Rates are expressed in basis points, so 500 is 5%.
+package tax
+
+// Breakdown is the result of applying a tax rate to an amount in minor units.
+type Breakdown struct {
+	Subtotal  int64 `json:"subtotal"`
+	Tax       int64 `json:"tax"`
+	Total     int64 `json:"total"`
+	Rate      int   `json:"rate"`
+	Inclusive bool  `json:"inclusive"`
+}
+
+// Calculate applies rate to amount. In inclusive mode amount already
+// contains the tax and is the total; otherwise the tax is added on top.
+// Tax is rounded half up to the nearest minor unit.
+func Calculate(amount int64, rate int, inclusive bool) Breakdown {
+	if rate <= 0 {
+		return Breakdown{Subtotal: amount, Total: amount, Inclusive: inclusive}
+	}
+
+	if inclusive {
+		tax := divRound(amount*int64(rate), 10000+int64(rate))
+		return Breakdown{Subtotal: amount - tax, Tax: tax, Total: amount, Rate: rate, Inclusive: true}
+	}
+
+	tax := divRound(amount*int64(rate), 10000)
+	return Breakdown{Subtotal: amount, Tax: tax, Total: amount + tax, Rate: rate}
+}
+
+func divRound(a, b int64) int64 {
+	if a < 0 {
+		return -divRound(-a, b)
+	}
+	return (a + b/2) / b
+}
@@ -0,0 +1,34 @@
+package tax
+
+import "testing"
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    int64
+		rate      int
+		inclusive bool
+		want      Breakdown
+	}{
+		{"exclusive", 10000, 500, false, Breakdown{Subtotal: 10000, Tax: 500, Total: 10500, Rate: 500}},
+		{"exclusive rounds half up", 10, 500, false, Breakdown{Subtotal: 10, Tax: 1, Total: 11, Rate: 500}},
+		{"exclusive rounds down below half", 9, 500, false, Breakdown{Subtotal: 9, Tax: 0, Total: 9, Rate: 500}},
+		{"exclusive fractional rate", 333, 750, false, Breakdown{Subtotal: 333, Tax: 25, Total: 358, Rate: 750}},
+		{"exclusive negative amount", -10, 500, false, Breakdown{Subtotal: -10, Tax: -1, Total: -11, Rate: 500}},
+		{"inclusive", 10500, 500, true, Breakdown{Subtotal: 10000, Tax: 500, Total: 10500, Rate: 500, Inclusive: true}},
+		{"inclusive rounds up", 100, 500, true, Breakdown{Subtotal: 95, Tax: 5, Total: 100, Rate: 500, Inclusive: true}},
+		{"inclusive rounds half up", 1, 10000, true, Breakdown{Subtotal: 0, Tax: 1, Total: 1, Rate: 10000, Inclusive: true}},
+		{"inclusive rounds down below half", 30, 500, true, Breakdown{Subtotal: 29, Tax: 1, Total: 30, Rate: 500, Inclusive: true}},
+		{"zero rate", 10000, 0, false, Breakdown{Subtotal: 10000, Total: 10000}},
+		{"zero rate inclusive", 10000, 0, true, Breakdown{Subtotal: 10000, Total: 10000, Inclusive: true}},
+		{"negative rate", 10000, -500, false, Breakdown{Subtotal: 10000, Total: 10000}},
+		{"zero amount", 0, 500, false, Breakdown{Rate: 500}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Calculate(tt.amount, tt.rate, tt.inclusive); got != tt.want {
+				t.Errorf("Calculate(%d, %d, %v) = %+v, want %+v", tt.amount, tt.rate, tt.inclusive, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Package thumbnail decodes uploaded JPEG, PNG and GIF images and scales them
+// down to fixed widths using only the standard library.
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+)
+
+// MaxPixels bounds the decoded size of an image so a small file cannot
+// expand into a huge bitmap.
+const MaxPixels = 40_000_000
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrTooManyPixels     = errors.New("image dimensions are too large")
+)
+
+// Size is a named thumbnail width. The height follows the aspect ratio.
+type Size struct {
+	Name  string
+	Width int
+}
+
+// Sizes are the thumbnails made of every upload.
+var Sizes = []Size{
+	{Name: "small", Width: 160},
+	{Name: "medium", Width: 480},
+	{Name: "large", Width: 1024},
+}
+
+// ContentTypes maps the accepted content types to their file extensions.
+var ContentTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// Decode reads an image after checking its format and dimensions.
+func Decode(data []byte) (image.Image, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupportedFormat
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, "", ErrTooManyPixels
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	return img, format, nil
+}
+
+// Resize scales img to width, keeping the aspect ratio. Images already
+// narrower than width are copied at their own size rather than enlarged.
+func Resize(img image.Image, width int) *image.RGBA {
+	src := toRGBA(img)
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if width >= sw {
+		return src
+	}
+	height := sh * width / sw
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	// Each destination pixel averages the box of source pixels it covers
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, (y+1)*sh/height
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, (x+1)*sw/width
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					b += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+			o := dst.PixOffset(x, y)
+			dst.Pix[o] = uint8(r / n)
+			dst.Pix[o+1] = uint8(g / n)
+			dst.Pix[o+2] = uint8(b / n)
+			dst.Pix[o+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// Encode writes img as PNG when the original was a PNG or GIF, so
+// transparency survives, and as JPEG otherwise. It returns the content type.
+func Encode(img image.Image, format string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "png", "gif":
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/png", nil
+	default:
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+}
+
+// toRGBA copies img into an RGBA bitmap starting at the origin.
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// bookedDateLayouts are the date formats clients send in booked_date.
+var bookedDateLayouts = []string{"02/01/2006", "2006-01-02"}
+
+// ParseBookingTime combines a booked_date and booked_time such as
+// "25/12/2026" and "02:30 PM" into a time in the server's location.
+func ParseBookingTime(bookedDate, bookedTime string) (time.Time, error) {
+	bookedDate = strings.TrimSpace(bookedDate)
+	bookedTime = strings.ToUpper(strings.TrimSpace(bookedTime))
+
+	for _, layout := range bookedDateLayouts {
+		if t, err := time.ParseInLocation(layout+" 03:04 PM", bookedDate+" "+bookedTime, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid booking date or time: %q %q", bookedDate, bookedTime)
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"errors"
+
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+)
+
+// errorCodes gives every sentinel error its message code. Each needs an
+// entry here and in the i18n catalog.
+var errorCodes = map[error]string{
+	ErrDuplicateEntry:             i18n.CodeDuplicateEntry,
+	ErrRecordNotFound:             i18n.CodeRecordNotFound,
+	ErrInvalidData:                i18n.CodeInvalidData,
+	ErrDatabaseError:              i18n.CodeDatabaseError,
+	ErrInvalidCredentials:         i18n.CodeInvalidCredentials,
+	ErrForeignKeyViolation:        i18n.CodeForeignKeyViolation,
+	ErrUniqueConstraint:           i18n.CodeUniqueConstraint,
+	ErrCheckConstraint:            i18n.CodeCheckConstraint,
+	ErrNotNullConstraint:          i18n.CodeNotNullConstraint,
+	ErrTransactionConflict:        i18n.CodeTransactionConflict,
+	ErrDeadlock:                   i18n.CodeDeadlock,
+	ErrLockTimeout:                i18n.CodeLockTimeout,
+	ErrConnectionFailed:           i18n.CodeConnectionFailed,
+	ErrConnectionTimeout:          i18n.CodeConnectionTimeout,
+	ErrTooManyConnections:         i18n.CodeTooManyConnections,
+	ErrInvalidQuery:               i18n.CodeInvalidDatabaseQuery,
+	ErrQueryTimeout:               i18n.CodeQueryTimeout,
+	ErrRowScanError:               i18n.CodeRowScanError,
+	ErrBranchNotFound:             i18n.CodeBranchNotFound,
+	ErrUserNotFound:               i18n.CodeUserNotFound,
+	ErrServiceNotFound:            i18n.CodeServiceNotFound,
+	ErrCategoryNotFound:           i18n.CodeCategoryNotFound,
+	ErrUserHadBooking:             i18n.CodeAlreadyBooked,
+	ErrBookingCancelled:           i18n.CodeBookingCancelled,
+	ErrBookingCompleted:           i18n.CodeBookingCompleted,
+	ErrBookingHasPayments:         i18n.CodeBookingHasPayments,
+	ErrServiceNotAtBranch:         i18n.CodeServiceNotAtBranch,
+	ErrSlotFull:                   i18n.CodeSlotFull,
+	ErrPromotionNotFound:          i18n.CodePromotionNotFound,
+	ErrPromotionInactive:          i18n.CodePromotionInactive,
+	ErrPromotionNotApplicable:     i18n.CodePromotionNotApplicable,
+	ErrPromotionUsageLimitReached: i18n.CodePromotionUsageLimitReached,
+	ErrPromotionInvalid:           i18n.CodePromotionInvalid,
+	ErrPackageNotFound:            i18n.CodePackageNotFound,
+	ErrPackageCreditNotFound:      i18n.CodePackageCreditNotFound,
+	ErrPackageCreditExhausted:     i18n.CodePackageCreditExhausted,
+	ErrGiftCardNotFound:           i18n.CodeGiftCardNotFound,
+	ErrGiftCardUnusable:           i18n.CodeGiftCardUnusable,
+	ErrRedemptionConflict:         i18n.CodeRedemptionConflict,
+	ErrInsufficientPoints:         i18n.CodeInsufficientPoints,
+	ErrInvalidPoints:              i18n.CodeInvalidPoints,
+	ErrInvoiceNotAvailable:        i18n.CodeInvoiceNotAvailable,
+	ErrTaxRateConflict:            i18n.CodeTaxRateConflict,
+	ErrPricingRuleInvalid:         i18n.CodePricingRuleInvalid,
+	ErrPriceChanged:               i18n.CodePriceChanged,
+	ErrMembershipPlanNotFound:     i18n.CodeMembershipPlanNotFound,
+	ErrMembershipPlanInUse:        i18n.CodeMembershipPlanInUse,
+	ErrSubscriptionNotFound:       i18n.CodeSubscriptionNotFound,
+	ErrAlreadySubscribed:          i18n.CodeAlreadySubscribed,
+	ErrMembershipCreditsExhausted: i18n.CodeMembershipCreditsExhausted,
+	ErrPaymentDeclined:            i18n.CodePaymentDeclined,
+	ErrBookingNotPayable:          i18n.CodeBookingNotPayable,
+	ErrInvalidPaymentAmount:       i18n.CodeInvalidPaymentAmount,
+	ErrRefundNotFound:             i18n.CodeRefundNotFound,
+	ErrRefundProcessed:            i18n.CodeRefundProcessed,
+	ErrInvalidRefundAmount:        i18n.CodeInvalidRefundAmount,
+	ErrRefundFailed:               i18n.CodeRefundFailed,
+	ErrVariantNotFound:            i18n.CodeVariantNotFound,
+	ErrVariantRequired:            i18n.CodeVariantRequired,
+	ErrAddOnNotFound:              i18n.CodeAddOnNotFound,
+	ErrParentCategoryNotFound:     i18n.CodeParentCategoryNotFound,
+	ErrCategoryCycle:              i18n.CodeCategoryCycle,
+	ErrCategoryHasChildren:        i18n.CodeCategoryHasChildren,
+	ErrCategoryNotSibling:         i18n.CodeCategoryNotSibling,
+	ErrParentCategoryInTrash:      i18n.CodeParentCategoryInTrash,
+	ErrCategoryInTrash:            i18n.CodeCategoryInTrash,
+	ErrDeleteBlocked:              i18n.CodeDeleteBlocked,
+	ErrInvalidDeleteStrategy:      i18n.CodeInvalidDeleteStrategy,
+	ErrReassignNotAllowed:         i18n.CodeReassignNotAllowed,
+	ErrReassignTargetInvalid:      i18n.CodeReassignTargetInvalid,
+	ErrProductNotFound:            i18n.CodeProductNotFound,
+	ErrProductInUse:               i18n.CodeProductInUse,
+	ErrInsufficientStock:          i18n.CodeInsufficientStock,
+	ErrInvalidStockChange:         i18n.CodeInvalidStockChange,
+	ErrReviewNotFound:             i18n.CodeReviewNotFound,
+	ErrReviewExists:               i18n.CodeReviewExists,
+	ErrBookingNotCompleted:        i18n.CodeBookingNotCompleted,
+	ErrStaffRatingInvalid:         i18n.CodeStaffRatingInvalid,
+	ErrNotBranchStaff:             i18n.CodeNotBranchStaff,
+	ErrNotBranchRole:              i18n.CodeNotBranchRole,
+	ErrInvalidCoordinates:         i18n.CodeInvalidCoordinates,
+	ErrInvalidRadius:              i18n.CodeInvalidRadius,
+	ErrImageTooLarge:              i18n.CodeImageTooLarge,
+	ErrUnsupportedImageType:       i18n.CodeUnsupportedImageType,
+	ErrUnsupportedLocale:          i18n.CodeUnsupportedLocale,
+	ErrDefaultLocale:              i18n.CodeDefaultLocale,
+	ErrTranslationNotFound:        i18n.CodeTranslationNotFound,
+}
+
+// ErrorCode returns the message code of the sentinel error err is or wraps,
+// or err's own text if it wraps none, for handlers to answer with.
+func ErrorCode(err error) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if code, ok := errorCodes[e]; ok {
+			return code
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
+	"KaungHtetHein116/IVY-backend/pkg/locale"
+)
+
+func TestErrorCodes(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "gorm_errors.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sentinels := 0
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				if strings.HasPrefix(name.Name, "Err") {
+					sentinels++
+				}
+			}
+		}
+	}
+	if len(errorCodes) != sentinels {
+		t.Errorf("errorCodes has %d entries for %d sentinel errors", len(errorCodes), sentinels)
+	}
+
+	seen := map[string]error{}
+	for err, code := range errorCodes {
+		if other, ok := seen[code]; ok {
+			t.Errorf("%q and %q share the code %s", err, other, code)
+		}
+		seen[code] = err
+		for _, lang := range locale.Supported {
+			if i18n.Render(lang, code, nil) == code {
+				t.Errorf("%s has no %s message", code, lang)
+			}
+		}
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{ErrSlotFull, i18n.CodeSlotFull},
+		{fmt.Errorf("booking: %w", ErrPriceChanged), i18n.CodePriceChanged},
+		{ErrPromotionUsageLimitReached, i18n.CodePromotionUsageLimitReached},
+		{fmt.Errorf("unexpected"), "unexpected"},
+	}
+	for _, tt := range tests {
+		if got := ErrorCode(tt.err); got != tt.want {
+			t.Errorf("ErrorCode(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
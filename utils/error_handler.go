@@ -3,7 +3,7 @@ package utils
 
 import (
 	transport "KaungHtetHein116/IVY-backend/api/transport"
-	"KaungHtetHein116/IVY-backend/pkg/constants"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
 	"errors"
 	"net/http"
 
@@ -31,10 +31,10 @@ func CustomHTTPErrorHandler(err error, c echo.Context) {
 	// These occur when request payload validation fails
 	var ve validator.ValidationErrors
 	if errors.As(err, &ve) {
-		validationErrors, _ := FormatValidationErrors(err)
+		validationErrors, _ := FormatValidationErrors(err, transport.RequestLocale(c))
 
 		transport.NewApiErrorResponse(c,
-			http.StatusBadRequest, constants.ErrValidationFailed,
+			http.StatusBadRequest, i18n.CodeValidationFailed,
 			validationErrors)
 
 		return
@@ -44,7 +44,7 @@ func CustomHTTPErrorHandler(err error, c echo.Context) {
 	// This covers both GORM's native not found error and custom not found error
 	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, ErrRecordNotFound) {
 		transport.NewApiErrorResponse(c,
-			http.StatusNotFound, constants.ErrErrorRecordNotFound,
+			http.StatusNotFound, i18n.CodeRecordNotFound,
 			nil)
 		return
 	}
@@ -53,7 +53,7 @@ func CustomHTTPErrorHandler(err error, c echo.Context) {
 	// This occurs when trying to create a record that violates unique constraints
 	if errors.Is(err, ErrDuplicateEntry) {
 		transport.NewApiErrorResponse(c,
-			http.StatusBadRequest, constants.ErrDuplicatedData,
+			http.StatusBadRequest, i18n.CodeDuplicateEntry,
 			nil)
 		return
 	}
@@ -67,5 +67,5 @@ func CustomHTTPErrorHandler(err error, c echo.Context) {
 	}
 
 	// Handle all other unspecified errors as internal server errors
-	transport.NewApiErrorResponse(c, http.StatusInternalServerError, err.Error(), nil)
+	transport.NewApiErrorResponse(c, http.StatusInternalServerError, i18n.CodeInternalError, nil)
 }
@@ -43,7 +43,105 @@ var (
 	ErrServiceNotFound  = errors.New("service ID not found")
 	ErrCategoryNotFound = errors.New("category ID not found")
 
-	ErrUserHadBooking = errors.New("user already has a booking for this service at this time")
+	ErrUserHadBooking     = errors.New("user already has a booking for this service at this time")
+	ErrBookingCancelled   = errors.New("booking has been cancelled")
+	ErrBookingCompleted   = errors.New("booking has been completed")
+	ErrBookingHasPayments = errors.New("booking has payments, cancel it instead")
+	ErrServiceNotAtBranch = errors.New("the service is not offered at this branch")
+	ErrSlotFull           = errors.New("the branch takes no more bookings of this service at this time")
+
+	// Promotion errors
+	ErrPromotionNotFound          = errors.New("promotion code not found")
+	ErrPromotionInactive          = errors.New("promotion is not active")
+	ErrPromotionNotApplicable     = errors.New("promotion does not apply to this booking")
+	ErrPromotionUsageLimitReached = errors.New("promotion usage limit reached")
+	ErrPromotionInvalid           = errors.New("invalid promotion rule")
+
+	// Prepaid package and gift card errors
+	ErrPackageNotFound        = errors.New("package ID not found")
+	ErrPackageCreditNotFound  = errors.New("no package credit for this service")
+	ErrPackageCreditExhausted = errors.New("package credit is used up or expired")
+	ErrGiftCardNotFound       = errors.New("gift card not found")
+	ErrGiftCardUnusable       = errors.New("gift card is inactive, expired or has no balance")
+	ErrRedemptionConflict     = errors.New("a package credit cannot be combined with other discounts")
+
+	// Loyalty errors
+	ErrInsufficientPoints = errors.New("not enough loyalty points")
+	ErrInvalidPoints      = errors.New("points must not be zero")
+
+	// Invoice errors
+	ErrInvoiceNotAvailable = errors.New("invoice is issued once the booking is completed or paid")
+
+	// Tax errors
+	ErrTaxRateConflict = errors.New("an active tax rate already exists for this branch and category")
+
+	// Pricing errors
+	ErrPricingRuleInvalid = errors.New("invalid pricing rule")
+	ErrPriceChanged       = errors.New("the price has changed since it was quoted")
+
+	// Membership errors
+	ErrMembershipPlanNotFound     = errors.New("membership plan not found")
+	ErrMembershipPlanInUse        = errors.New("membership plan has subscriptions, deactivate it instead")
+	ErrSubscriptionNotFound       = errors.New("no active membership subscription")
+	ErrAlreadySubscribed          = errors.New("user already has a membership subscription")
+	ErrMembershipCreditsExhausted = errors.New("included membership sessions are used up")
+	ErrPaymentDeclined            = errors.New("payment was declined")
+
+	// Payment and refund errors
+	ErrBookingNotPayable    = errors.New("booking is cancelled or already paid")
+	ErrInvalidPaymentAmount = errors.New("payment amount must be positive and at most the amount due")
+	ErrRefundNotFound       = errors.New("refund not found")
+	ErrRefundProcessed      = errors.New("refund has already been processed")
+	ErrInvalidRefundAmount  = errors.New("refund amount exceeds what was paid")
+	ErrRefundFailed         = errors.New("the payment provider could not issue the refund")
+
+	// Service variant and add-on errors
+	ErrVariantNotFound = errors.New("variant not found for this service")
+	ErrVariantRequired = errors.New("this service has variants, choose one")
+	ErrAddOnNotFound   = errors.New("add-on not found for this service")
+
+	// Category tree errors
+	ErrParentCategoryNotFound = errors.New("parent category not found")
+	ErrCategoryCycle          = errors.New("a category cannot be moved under itself or its descendants")
+	ErrCategoryHasChildren    = errors.New("category has subcategories, move or delete them first")
+	ErrCategoryNotSibling     = errors.New("categories to reorder must all belong to the same parent")
+	ErrParentCategoryInTrash  = errors.New("the parent category is in the trash, restore it first")
+	ErrCategoryInTrash        = errors.New("the service's category is in the trash, restore it first")
+
+	// Delete safeguard errors
+	ErrDeleteBlocked         = errors.New("other records depend on this one, choose a delete strategy")
+	ErrInvalidDeleteStrategy = errors.New("strategy must be block, reassign or cancel")
+	ErrReassignNotAllowed    = errors.New("only a category's services can be reassigned")
+	ErrReassignTargetInvalid = errors.New("reassign_to must be another category that is not in the trash")
+
+	// Inventory errors
+	ErrProductNotFound    = errors.New("product not found")
+	ErrProductInUse       = errors.New("product has stock movements, deactivate it instead")
+	ErrInsufficientStock  = errors.New("not enough stock at this branch")
+	ErrInvalidStockChange = errors.New("restocks and sales take a positive quantity, adjustments a non-zero one")
+
+	// Review errors
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrReviewExists        = errors.New("this booking has already been reviewed")
+	ErrBookingNotCompleted = errors.New("only completed bookings can be reviewed")
+	ErrStaffRatingInvalid  = errors.New("staff_id and staff_rating must be given together")
+	ErrNotBranchStaff      = errors.New("staff_id must be a staff member of the booking's branch")
+
+	// Branch member errors
+	ErrNotBranchRole = errors.New("only STAFF and BRANCH_MANAGER users can be branch members")
+
+	// Branch search errors
+	ErrInvalidCoordinates = errors.New("lat must be between -90 and 90 and lng between -180 and 180")
+	ErrInvalidRadius      = errors.New("radius_km must be greater than 0 and at most 100")
+
+	// Image upload errors
+	ErrImageTooLarge        = errors.New("image exceeds the maximum upload size")
+	ErrUnsupportedImageType = errors.New("only JPEG, PNG and GIF images are accepted")
+
+	// Translation errors
+	ErrUnsupportedLocale   = errors.New("locale is not supported")
+	ErrDefaultLocale       = errors.New("the default locale is edited on the record itself, not as a translation")
+	ErrTranslationNotFound = errors.New("translation not found for this locale")
 )
 
 func HandleGormError(err error, entity string) error {
@@ -54,6 +54,12 @@ func (qb *QueryBuilder) ApplyInFilter(field, value string) *QueryBuilder {
 	return qb
 }
 
+// ApplyCondition applies a raw where condition with its arguments
+func (qb *QueryBuilder) ApplyCondition(condition string, args ...interface{}) *QueryBuilder {
+	qb.query = qb.query.Where(condition, args...)
+	return qb
+}
+
 // ApplySorting applies sorting based on the provided parameters
 func (qb *QueryBuilder) ApplySorting(sortBy, sortOrder string) *QueryBuilder {
 	if sortBy != "" {
@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"crypto/rand"
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 )
 
 func ParseStringToInt(s string) (int, error) {
@@ -25,3 +28,24 @@ func ParseBoolToString(b *bool) string {
 	}
 	return "false"
 }
+
+// codeAlphabet leaves out characters that are easy to misread (0/O, 1/I).
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GenerateCode returns a random code made of groups of four characters,
+// e.g. "7KQ2-MZ9D-XW4P" for groups = 3.
+func GenerateCode(groups int) (string, error) {
+	parts := make([]string, groups)
+	for g := range parts {
+		var sb strings.Builder
+		for i := 0; i < 4; i++ {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(codeAlphabet))))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteByte(codeAlphabet[n.Int64()])
+		}
+		parts[g] = sb.String()
+	}
+	return strings.Join(parts, "-"), nil
+}
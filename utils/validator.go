@@ -2,8 +2,12 @@ package utils
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
+
+	"KaungHtetHein116/IVY-backend/api/transport"
+	"KaungHtetHein116/IVY-backend/pkg/i18n"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -11,6 +15,7 @@ import (
 
 type ValidationError struct {
 	Field   string `json:"field"`
+	Code    string `json:"code"`
 	Message string `json:"message"`
 }
 
@@ -18,30 +23,51 @@ type CustomValidator struct {
 	Validator *validator.Validate
 }
 
+// NewValidator returns a validator that reports fields by their JSON names,
+// the names clients send, rather than the Go struct field names.
+func NewValidator() *CustomValidator {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return &CustomValidator{Validator: v}
+}
+
 func BindAndValidateDecorator[T any](fn func(echo.Context, *T) error) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		input := new(T)
 		if err := c.Bind(input); err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+			return echo.NewHTTPError(http.StatusBadRequest, i18n.CodeInvalidRequest)
 		}
 
 		if err := c.Validate(input); err != nil {
-			// Format validation errors and return 400
-			if verrs, ok := FormatValidationErrors(err); ok {
+			// Format validation errors and return 422
+			if verrs, ok := FormatValidationErrors(err, transport.RequestLocale(c)); ok {
+				code, message := transport.Localize(c, i18n.CodeValidationFailed)
 				return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
-					"message": "Validation failed",
-					"errors":  verrs,
+					"error_code": code,
+					"message":    message,
+					"errors":     verrs,
 				})
 			}
 			// fallback for other errors
-			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+			return echo.NewHTTPError(http.StatusBadRequest, i18n.CodeInvalidRequest)
 		}
 
 		return fn(c, input)
 	}
 }
 
-func FormatValidationErrors(err error) ([]ValidationError, bool) {
+// FormatValidationErrors turns validator errors into one entry per field,
+// with messages in lang.
+func FormatValidationErrors(err error, lang string) ([]ValidationError, bool) {
 	var verrs validator.ValidationErrors
 	if ok := errors.As(err, &verrs); !ok {
 		return nil, false
@@ -49,22 +75,66 @@ func FormatValidationErrors(err error) ([]ValidationError, bool) {
 
 	formatted := make([]ValidationError, 0, len(verrs))
 	for _, fe := range verrs {
+		field := fieldPath(fe)
+		code, param := validationCode(fe)
 		formatted = append(formatted, ValidationError{
-			Field:   (fe.Field()),
-			Message: getValidationMessage(fe),
+			Field:   field,
+			Code:    code,
+			Message: i18n.Render(lang, code, i18n.Params{"field": field, "param": param}),
 		})
 	}
 	return formatted, true
 }
 
-func getValidationMessage(fe validator.FieldError) string {
+// fieldPath is the path of the field within the request body, e.g.
+// "items[0].name", without the name of the request struct.
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
+
+// validationCode returns the message code for the failed tag and the
+// parameter to show with it. Elements checked through dive report the tag
+// of the element, e.g. uuid for service_ids[0].
+func validationCode(fe validator.FieldError) (string, string) {
 	switch fe.Tag() {
 	case "required":
-		return fmt.Sprintf("%s is required", (fe.Field()))
+		return i18n.CodeFieldRequired, ""
 	case "min":
-		return fmt.Sprintf("%s must be at least %s characters", (fe.Field()), fe.Param())
+		return sizeCode(fe.Kind(), i18n.CodeFieldMin, i18n.CodeFieldMinLen, i18n.CodeFieldMinItems), fe.Param()
+	case "max":
+		return sizeCode(fe.Kind(), i18n.CodeFieldMax, i18n.CodeFieldMaxLen, i18n.CodeFieldMaxItems), fe.Param()
+	case "email":
+		return i18n.CodeFieldEmail, ""
+	case "oneof":
+		return i18n.CodeFieldOneOf, strings.Join(strings.Fields(fe.Param()), ", ")
+	case "uuid":
+		return i18n.CodeFieldUUID, ""
+	case "iso4217":
+		return i18n.CodeFieldCurrency, ""
+	case "datetime":
+		return i18n.CodeFieldDatetime, fe.Param()
+	case "number":
+		return i18n.CodeFieldNumber, ""
+	case "boolean":
+		return i18n.CodeFieldBoolean, ""
+	default:
+		return i18n.CodeFieldInvalid, fe.Param()
+	}
+}
+
+// sizeCode picks how min and max read: a length for text, a count for
+// lists and a value for numbers.
+func sizeCode(kind reflect.Kind, value, length, items string) string {
+	switch kind {
+	case reflect.String:
+		return length
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return items
 	default:
-		return fmt.Sprintf("%s is invalid", (fe.Field()))
+		return value
 	}
 }
 